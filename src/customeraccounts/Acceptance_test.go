@@ -1,8 +1,10 @@
 package customeraccounts_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
@@ -23,10 +25,27 @@ var atPurgeCustomerEventStream application.ForPurgingCustomerEventStreams
 type acceptanceTestCollaborators struct {
 	registerCustomer            hexagon.ForRegisteringCustomers
 	confirmCustomerEmailAddress hexagon.ForConfirmingCustomerEmailAddresses
+	requestConfirmationResend   hexagon.ForRequestingCustomerEmailAddressConfirmationResends
 	changeCustomerEmailAddress  hexagon.ForChangingCustomerEmailAddresses
+	cancelEmailAddressChange    hexagon.ForCancellingCustomerEmailAddressChanges
 	changeCustomerName          hexagon.ForChangingCustomerNames
+	addCustomerAddress          hexagon.ForAddingCustomerAddresses
+	changeCustomerAddress       hexagon.ForChangingCustomerAddresses
+	removeCustomerAddress       hexagon.ForRemovingCustomerAddresses
+	changeCustomerPhoneNumber   hexagon.ForChangingCustomerPhoneNumbers
+	confirmCustomerPhoneNumber  hexagon.ForConfirmingCustomerPhoneNumbers
+	suspendCustomer             hexagon.ForSuspendingCustomers
+	reactivateCustomer          hexagon.ForReactivatingCustomers
 	deleteCustomer              hexagon.ForDeletingCustomers
+	restoreCustomer             hexagon.ForRestoringCustomers
+	erasePersonalData           hexagon.ForErasingCustomerPersonalData
 	customerViewByID            hexagon.ForRetrievingCustomerViews
+	customerViewByIDAsOf        hexagon.ForRetrievingCustomerViewsAsOf
+	customerViewByEmailAddress  hexagon.ForRetrievingCustomerViewsByEmailAddress
+	exportCustomerData          hexagon.ForExportingCustomerData
+	customerEventHistory        hexagon.ForRetrievingCustomerEventHistories
+	searchCustomerViews         hexagon.ForSearchingCustomerViews
+	watchCustomer               hexagon.ForWatchingCustomers
 }
 
 type acceptanceTestValues struct {
@@ -44,12 +63,16 @@ type acceptanceTestValues struct {
 	fn                  string // familyName
 	cgn                 string // changeGivenName
 	cfn                 string // changedFamilyName
+	sr                  string // suspensionReason
 }
 
 func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRegisteringCustomers)
+}
 
+func customerAcceptanceScenariosForRegisteringCustomers(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 		var expectedCustomerView customer.View
 		var actualCustomerView customer.View
@@ -58,7 +81,7 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 
 		Convey("\nSCENARIO: A prospective Customer registers her account", func() {
 			Convey(fmt.Sprintf("When a Customer registers as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
-				err = ac.registerCustomer(v.customerID, v.ea, v.gn, v.fn)
+				_, err = ac.registerCustomer(ctx, v.customerID, v.ea, v.gn, v.fn)
 				So(err, ShouldBeNil)
 
 				expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
@@ -68,9 +91,31 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 				details += fmt.Sprintf("\n\tIsEmailAddressConfirmed: %t", expectedCustomerView.IsEmailAddressConfirmed)
 
 				Convey(fmt.Sprintf("Then her account should show the data she supplied: %s", details), func() {
-					actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+					actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 					So(err, ShouldBeNil)
-					So(actualCustomerView, ShouldResemble, expectedCustomerView)
+					So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A prospective Customer retries her registration with the same idempotency key", func() {
+			idempotentCtx := es.ContextWithIdempotencyKey(ctx, es.BuildIdempotencyKey(v.customerID.String(), "Register"))
+
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s] and an idempotency key", v.gn, v.fn, v.ea), func() {
+				registeredID, err := ac.registerCustomer(idempotentCtx, v.customerID, v.ea, v.gn, v.fn)
+				So(err, ShouldBeNil)
+				So(registeredID, ShouldEqual, v.customerID)
+
+				Convey("When she retries the registration with the same idempotency key", func() {
+					retriedID, err := ac.registerCustomer(idempotentCtx, v.otherCustomerID, v.ea, v.gn, v.fn)
+
+					Convey("Then she should receive the ID of her original registration", func() {
+						So(err, ShouldBeNil)
+						So(retriedID, ShouldEqual, v.customerID)
+
+						_, err = ac.customerViewByID(ctx, v.otherCustomerID.String())
+						So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+					})
 				})
 			})
 		})
@@ -80,7 +125,7 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey(fmt.Sprintf("When another Customer registers with the same email address [%s]", v.ea), func() {
-					err = ac.registerCustomer(v.customerID, v.ea, v.gn, v.fn)
+					_, err = ac.registerCustomer(ctx, v.customerID, v.ea, v.gn, v.fn)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -95,11 +140,11 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("And given the first Customer deleted her account", func() {
-					err = ac.deleteCustomer(v.customerID.String())
+					_, err = ac.deleteCustomer(ctx, v.customerID.String(), 0)
 					So(err, ShouldBeNil)
 
 					Convey(fmt.Sprintf("When another Customer registers with the same email address [%s]", v.ea), func() {
-						err = ac.registerCustomer(v.otherCustomerID, v.ea, v.gn, v.fn)
+						_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.ea, v.gn, v.fn)
 
 						Convey("Then she should be able to register", func() {
 							So(err, ShouldBeNil)
@@ -108,11 +153,11 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 				})
 
 				Convey(fmt.Sprintf("Or given the first Customer changed her email address to [%s]", v.cea), func() {
-					err = ac.changeCustomerEmailAddress(v.customerID.String(), v.cea)
+					_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), v.cea, 0)
 					So(err, ShouldBeNil)
 
 					Convey(fmt.Sprintf("When another Customer registers with the same email address [%s]", v.ea), func() {
-						err = ac.registerCustomer(v.otherCustomerID, v.ea, v.gn, v.fn)
+						_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.ea, v.gn, v.fn)
 
 						Convey("Then she should be able to register", func() {
 							So(err, ShouldBeNil)
@@ -126,7 +171,7 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 			invalidEmailAddress := "fiona@galagher.c"
 
 			Convey(fmt.Sprintf("When she supplies an invalid email address [%s]", invalidEmailAddress), func() {
-				err = ac.registerCustomer(v.customerID, invalidEmailAddress, v.gn, v.fn)
+				_, err = ac.registerCustomer(ctx, v.customerID, invalidEmailAddress, v.gn, v.fn)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -135,7 +180,7 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 			})
 
 			Convey("When she supplies an empty givenName", func() {
-				err = ac.registerCustomer(v.customerID, v.ea, "", v.fn)
+				_, err = ac.registerCustomer(ctx, v.customerID, v.ea, "", v.fn)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -144,7 +189,7 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 			})
 
 			Convey("When she supplies an empty familyName", func() {
-				err = ac.registerCustomer(v.customerID, v.ea, v.gn, "")
+				_, err = ac.registerCustomer(ctx, v.customerID, v.ea, v.gn, "")
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -154,19 +199,22 @@ func TestCustomerAcceptanceScenarios_ForRegisteringCustomers(t *testing.T) {
 		})
 
 		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
 			So(err, ShouldBeNil)
 
-			err = atPurgeCustomerEventStream(v.otherCustomerID)
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
 			So(err, ShouldBeNil)
 		})
 	})
 }
 
 func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForConfirmingCustomerEmailAddresses)
+}
 
+func customerAcceptanceScenariosForConfirmingCustomerEmailAddresses(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 		var expectedCustomerView customer.View
 		var actualCustomerView customer.View
@@ -178,25 +226,25 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("When she confirms her email address", func() {
-					err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.ch)
+					_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
 					So(err, ShouldBeNil)
 
 					Convey("Then her email address should be confirmed", func() {
-						actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 						So(err, ShouldBeNil)
 						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
 						expectedCustomerView.IsEmailAddressConfirmed = true
 						expectedCustomerView.Version = 2
-						So(actualCustomerView, ShouldResemble, expectedCustomerView)
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 
 						Convey("And when she confirms her email address again", func() {
-							err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.ch)
+							_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
 							So(err, ShouldBeNil)
 
 							Convey("Then her email address should still be confirmed", func() {
-								actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 								So(err, ShouldBeNil)
-								So(actualCustomerView, ShouldResemble, expectedCustomerView)
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 							})
 						})
 					})
@@ -209,17 +257,46 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("When she tries to confirm her email address with a wrong confirmation hash", func() {
-					err = ac.confirmCustomerEmailAddress(v.customerID.String(), "invalid_confirmation_hash")
+					_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), "invalid_confirmation_hash", 0)
 
 					Convey("Then she should receive an error", func() {
 						So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
 
 						Convey("And her email address should still be unconfirmed", func() {
-							actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 							So(err, ShouldBeNil)
 							expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
 							expectedCustomerView.Version = 2
-							So(actualCustomerView, ShouldResemble, expectedCustomerView)
+							So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer is locked out after too many wrong confirmation hashes", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("And given she tried to confirm her email address with wrong confirmation hashes too often", func() {
+					config := grpc.MustBuildConfigFromEnv(shared.NewNilLogger())
+
+					for i := uint(0); i < config.Customer.ConfirmationMaxFailedAttempts; i++ {
+						_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), "invalid_confirmation_hash", 0)
+						So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+					}
+
+					Convey("When she confirms her email address with the right confirmation hash", func() {
+						_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
+
+						Convey("Then she should receive an error", func() {
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+
+							Convey("And her email address should still be unconfirmed", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								So(actualCustomerView.IsEmailAddressConfirmed, ShouldBeFalse)
+							})
 						})
 					})
 				})
@@ -234,17 +311,17 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
 
 					Convey("When she tries to confirm her email address again with a wrong confirmation hash", func() {
-						err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.ch)
+						_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
 						So(err, ShouldBeNil)
 
 						Convey("Then her email address should still be confirmed", func() {
-							actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 							So(err, ShouldBeNil)
 
 							expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
 							expectedCustomerView.IsEmailAddressConfirmed = true
 							expectedCustomerView.Version = 2
-							So(actualCustomerView, ShouldResemble, expectedCustomerView)
+							So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 						})
 					})
 				})
@@ -262,17 +339,51 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 						givenCustomerEmailAddressWasChanged(v.customerID, v.changedEmailAddress, 3)
 
 						Convey("When she confirms her changed email address", func() {
-							err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.cch)
+							_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.cch, 0)
+							So(err, ShouldBeNil)
+
+							Convey(fmt.Sprintf("Then her email address should be [%s] and confirmed", v.cea), func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+								expectedCustomerView.EmailAddress = v.cea
+								expectedCustomerView.IsEmailAddressConfirmed = true
+								expectedCustomerView.Version = 4
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer confirms her pending email address", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("And given she confirmed her email address", func() {
+					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
+
+					Convey(fmt.Sprintf("And given she requested to change her email address to [%s]", v.cea), func() {
+						givenCustomerEmailAddressChangeWasRequested(v.customerID, v.changedEmailAddress, 3)
+
+						Convey("When she confirms her pending email address", func() {
+							_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.cch, 0)
 							So(err, ShouldBeNil)
 
 							Convey(fmt.Sprintf("Then her email address should be [%s] and confirmed", v.cea), func() {
-								actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 								So(err, ShouldBeNil)
 								expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
 								expectedCustomerView.EmailAddress = v.cea
 								expectedCustomerView.IsEmailAddressConfirmed = true
 								expectedCustomerView.Version = 4
-								So(actualCustomerView, ShouldResemble, expectedCustomerView)
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+
+								Convey(fmt.Sprintf("And another Customer should be able to register with [%s]", v.ea), func() {
+									_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.ea, v.gn, v.fn)
+									So(err, ShouldBeNil)
+								})
 							})
 						})
 					})
@@ -285,7 +396,7 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("When she supplies an empty confirmation hash", func() {
-					err = ac.confirmCustomerEmailAddress(v.customerID.String(), "")
+					_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), "", 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -296,16 +407,94 @@ func TestCustomerAcceptanceScenarios_ForConfirmingCustomerEmailAddresses(t *test
 		})
 
 		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRequestingCustomerEmailAddressConfirmationResends(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRequestingCustomerEmailAddressConfirmationResends)
+}
+
+func customerAcceptanceScenariosForRequestingCustomerEmailAddressConfirmationResends(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var expectedCustomerView customer.View
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer requests a new confirmation hash because she lost the confirmation email", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s] a while ago", v.gn, v.fn, v.ea), func() {
+				registeredAWhileAgo := value.RebuildUnconfirmedEmailAddress(v.ea, v.ch, time.Now().Add(-time.Hour))
+				givenCustomerRegistered(v.customerID, registeredAWhileAgo, v.name)
+
+				Convey("When she requests a resend of the confirmation email", func() {
+					_, err = ac.requestConfirmationResend(ctx, v.customerID.String(), 0)
+					So(err, ShouldBeNil)
+
+					Convey("Then her email address should still be unconfirmed", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+						So(err, ShouldBeNil)
+						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+						expectedCustomerView.Version = 2
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+
+						Convey("And when she tries to confirm her email address with the previous confirmation hash", func() {
+							_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
+
+							Convey("Then she should receive an error", func() {
+								So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							})
+						})
+
+						Convey("And when she immediately requests another resend", func() {
+							_, err = ac.requestConfirmationResend(ctx, v.customerID.String(), 0)
+
+							Convey("Then she should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrRateLimited), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer can't request a new confirmation hash right after she registered", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When she requests a resend of the confirmation email", func() {
+					_, err = ac.requestConfirmationResend(ctx, v.customerID.String(), 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrRateLimited), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
 			So(err, ShouldBeNil)
 		})
 	})
 }
 
 func TestCustomerAcceptanceScenarios_ForChangingCustomerEmailAddresses(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForChangingCustomerEmailAddresses)
+}
 
+func customerAcceptanceScenariosForChangingCustomerEmailAddresses(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 		var expectedCustomerView customer.View
 		var actualCustomerView customer.View
@@ -320,25 +509,26 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerEmailAddresses(t *testin
 					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
 
 					Convey(fmt.Sprintf("When she changes her email address to [%s]", v.cea), func() {
-						err = ac.changeCustomerEmailAddress(v.customerID.String(), v.cea)
+						_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), v.cea, 0)
 						So(err, ShouldBeNil)
 
-						Convey(fmt.Sprintf("Then her email address should be [%s] and unconfirmed", v.cea), func() {
-							actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+						Convey(fmt.Sprintf("Then her email address should still be [%s] and [%s] should be pending", v.ea, v.cea), func() {
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 							So(err, ShouldBeNil)
 							expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
-							expectedCustomerView.EmailAddress = v.cea
+							expectedCustomerView.IsEmailAddressConfirmed = true
+							expectedCustomerView.PendingEmailAddress = v.cea
 							expectedCustomerView.Version = 3
-							So(actualCustomerView, ShouldResemble, expectedCustomerView)
+							So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 
 							Convey(fmt.Sprintf("And when she tries to change her email address to [%s] again", v.cea), func() {
-								err = ac.changeCustomerEmailAddress(v.customerID.String(), v.cea)
+								_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), v.cea, 0)
 								So(err, ShouldBeNil)
 
-								Convey(fmt.Sprintf("Then her email address should still be [%s]", v.cea), func() {
-									actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+								Convey(fmt.Sprintf("Then [%s] should still be pending", v.cea), func() {
+									actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 									So(err, ShouldBeNil)
-									So(actualCustomerView, ShouldResemble, expectedCustomerView)
+									So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 								})
 							})
 						})
@@ -358,7 +548,30 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerEmailAddresses(t *testin
 						givenCustomerRegistered(v.otherCustomerID, v.emailAddress, v.name)
 
 						Convey(fmt.Sprintf("When she also tries to change her email address to [%s]", v.cea), func() {
-							err = ac.changeCustomerEmailAddress(v.otherCustomerID.String(), v.cea)
+							_, err = ac.changeCustomerEmailAddress(ctx, v.otherCustomerID.String(), v.cea, 0)
+
+							Convey("Then she should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer can't change her email address to one which is pending for another Customer", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("And given she confirmed her email address", func() {
+					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
+
+					Convey(fmt.Sprintf("And given she requested to change her email address to [%s]", v.cea), func() {
+						givenCustomerEmailAddressChangeWasRequested(v.customerID, v.changedEmailAddress, 3)
+
+						Convey(fmt.Sprintf("When another Customer tries to register with [%s]", v.cea), func() {
+							_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.cea, v.gn, v.fn)
 
 							Convey("Then she should receive an error", func() {
 								So(err, ShouldBeError)
@@ -377,7 +590,7 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerEmailAddresses(t *testin
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey(fmt.Sprintf("When she supplies an invalid email address [%s]", invalidEmailAddress), func() {
-					err = ac.changeCustomerEmailAddress(v.customerID.String(), invalidEmailAddress)
+					_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), invalidEmailAddress, 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -388,19 +601,90 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerEmailAddresses(t *testin
 		})
 
 		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForCancellingCustomerEmailAddressChanges(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForCancellingCustomerEmailAddressChanges)
+}
+
+func customerAcceptanceScenariosForCancellingCustomerEmailAddressChanges(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var expectedCustomerView customer.View
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer cancels the change of her email address because she made a typo", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("And given she confirmed her email address", func() {
+					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
+
+					Convey(fmt.Sprintf("And given she requested to change her email address to [%s]", v.cea), func() {
+						givenCustomerEmailAddressChangeWasRequested(v.customerID, v.changedEmailAddress, 3)
+
+						Convey("When she cancels the change", func() {
+							_, err = ac.cancelEmailAddressChange(ctx, v.customerID.String(), 0)
+							So(err, ShouldBeNil)
+
+							Convey(fmt.Sprintf("Then her email address should still be [%s] and nothing should be pending", v.ea), func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+								expectedCustomerView.IsEmailAddressConfirmed = true
+								expectedCustomerView.Version = 4
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+
+								Convey(fmt.Sprintf("And another Customer should be able to register with [%s]", v.cea), func() {
+									_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.cea, v.gn, v.fn)
+									So(err, ShouldBeNil)
+								})
+							})
+
+							Convey("And when she confirms the cancelled email address", func() {
+								_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.cch, 0)
+								So(err, ShouldBeNil)
+
+								Convey(fmt.Sprintf("Then her email address should still be [%s]", v.ea), func() {
+									actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+									So(err, ShouldBeNil)
+									So(actualCustomerView.EmailAddress, ShouldEqual, v.ea)
+									So(actualCustomerView.Version, ShouldEqual, 4)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
 			So(err, ShouldBeNil)
 
-			err = atPurgeCustomerEventStream(v.otherCustomerID)
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
 			So(err, ShouldBeNil)
 		})
 	})
 }
 
 func TestCustomerAcceptanceScenarios_ForChangingCustomerNames(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForChangingCustomerNames)
+}
 
+func customerAcceptanceScenariosForChangingCustomerNames(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 		var expectedCustomerView customer.View
 		var actualCustomerView customer.View
@@ -412,26 +696,26 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerNames(t *testing.T) {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey(fmt.Sprintf("When she changes her name to [%s %s]", v.cgn, v.cfn), func() {
-					err = ac.changeCustomerName(v.customerID.String(), v.cgn, v.cfn)
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 0)
 					So(err, ShouldBeNil)
 
 					Convey(fmt.Sprintf("Then her name should be [%s %s]", v.cgn, v.cfn), func() {
-						actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 						So(err, ShouldBeNil)
 						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
 						expectedCustomerView.GivenName = v.cgn
 						expectedCustomerView.FamilyName = v.cfn
 						expectedCustomerView.Version = 2
-						So(actualCustomerView, ShouldResemble, expectedCustomerView)
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 
 						Convey(fmt.Sprintf("And when she tries to change her name to [%s %s] again", v.cgn, v.cfn), func() {
-							err = ac.changeCustomerName(v.customerID.String(), v.cgn, v.cfn)
+							_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 0)
 							So(err, ShouldBeNil)
 
 							Convey(fmt.Sprintf("Then her name should still be [%s %s]", v.cgn, v.cfn), func() {
-								actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 								So(err, ShouldBeNil)
-								So(actualCustomerView, ShouldResemble, expectedCustomerView)
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 							})
 						})
 					})
@@ -444,7 +728,7 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerNames(t *testing.T) {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("When she supplies an empty given name", func() {
-					err = ac.changeCustomerName(v.customerID.String(), "", v.cfn)
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), "", v.cfn, 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -453,7 +737,7 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerNames(t *testing.T) {
 				})
 
 				Convey("When she supplies an empty family name", func() {
-					err = ac.changeCustomerName(v.customerID.String(), v.cgn, "")
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, "", 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -463,40 +747,46 @@ func TestCustomerAcceptanceScenarios_ForChangingCustomerNames(t *testing.T) {
 			})
 		})
 
-		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
-			So(err, ShouldBeNil)
-		})
-	})
-}
+		Convey("\nSCENARIO: A Customer tries to change her name based on an outdated version", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
-func TestCustomerAcceptanceScenarios_ForAddingBillingProfiles(t *testing.T) {
-	Convey("Prepare test artifacts", t, func() {
-		v := initAcceptanceTestValues()
+				Convey("When she changes her name expecting version 2", func() {
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 2)
 
-		Convey("\nSCENARIO: A Customer adds a billing profile to her account", func() {
-			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
-				Convey("When she adds a billing profile ", func() {
-					Convey("Then her account should contain one billing profile", func() {
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+					})
+				})
 
+				Convey("When she changes her name expecting version 1", func() {
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+
+					Convey("Then her name should be changed", func() {
+						So(err, ShouldBeNil)
 					})
 				})
 			})
 		})
-	})
-}
-
-func TestCustomerAcceptanceScenarios_ForRemovingBillingProfiles(t *testing.T) {
-	Convey("Prepare test artifacts", t, func() {
-		v := initAcceptanceTestValues()
 
-		Convey("\nSCENARIO: A Customer removes a billing profile from her account", func() {
+		Convey("\nSCENARIO: A Customer changes her name based on the version returned by her last change", func() {
 			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
-				Convey("And given she added a billing profile", func() {
-					Convey("And given she added another billing profile", func() {
-						Convey("When she removes the first billing profile from her account", func() {
-							Convey("Then her account should contain only the second billing profile", func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("When she changes her name to [%s %s]", v.cgn, v.cfn), func() {
+					newVersion, err := ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+					So(err, ShouldBeNil)
+
+					Convey("Then the new version of her account should be returned", func() {
+						So(newVersion, ShouldEqual, 2)
 
+						Convey(fmt.Sprintf("And when she changes her name back to [%s %s] expecting this version", v.gn, v.fn), func() {
+							newVersion, err = ac.changeCustomerName(ctx, v.customerID.String(), v.gn, v.fn, newVersion)
+
+							Convey("Then her name should be changed", func() {
+								So(err, ShouldBeNil)
+								So(newVersion, ShouldEqual, 3)
 							})
 						})
 					})
@@ -504,81 +794,1205 @@ func TestCustomerAcceptanceScenarios_ForRemovingBillingProfiles(t *testing.T) {
 			})
 		})
 
-		Convey("\nSCENARIO: A Customer tries to remove the only billing profile from her account", func() {
+		Convey("\nSCENARIO: A Customer changes her name based on the version she read right after her last change", func() {
 			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
-				Convey("And given she added a billing profile", func() {
-					Convey("When she tries to remove the billing profile from her account", func() {
-						Convey("Then she should receive an error", func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("And given she changed her name to [%s %s]", v.cgn, v.cfn), func() {
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+					So(err, ShouldBeNil)
+
+					Convey("When she reads her account before the customer view projection caught up", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+						So(err, ShouldBeNil)
+
+						Convey("Then she should see her changed name with the current version", func() {
+							So(actualCustomerView.GivenName, ShouldEqual, v.cgn)
+							So(actualCustomerView.FamilyName, ShouldEqual, v.cfn)
+							So(actualCustomerView.Version, ShouldEqual, 2)
 
+							Convey(fmt.Sprintf("And when she changes her name back to [%s %s] expecting this version", v.gn, v.fn), func() {
+								_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.gn, v.fn, actualCustomerView.Version)
+
+								Convey("Then her name should be changed", func() {
+									So(err, ShouldBeNil)
+								})
+							})
 						})
 					})
 				})
 			})
 		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
 	})
 }
 
-func TestCustomerAcceptanceScenarios_ForDeletingCustomers(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+func TestCustomerAcceptanceScenarios_ForManagingCustomerAddresses(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForManagingCustomerAddresses)
+}
 
+func customerAcceptanceScenariosForManagingCustomerAddresses(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
+		var expectedCustomerView customer.View
 		var actualCustomerView customer.View
 
 		v := initAcceptanceTestValues()
 
-		Convey("\nSCENARIO: A Customer deletes her account", func() {
+		billingAddress := customer.AddressView{
+			Kind:        "billing",
+			Street:      "Main Street 1",
+			PostalCode:  "10115",
+			City:        "Berlin",
+			CountryCode: "DE",
+		}
+
+		shippingAddress := customer.AddressView{
+			Kind:        "shipping",
+			Street:      "Side Street 2",
+			PostalCode:  "SW1A 1AA",
+			City:        "London",
+			CountryCode: "GB",
+		}
+
+		addAddress := func(address customer.AddressView, expectedVersion uint) (uint, error) {
+			return ac.addCustomerAddress(
+				ctx,
+				v.customerID.String(),
+				address.Kind,
+				address.Street,
+				address.PostalCode,
+				address.City,
+				address.CountryCode,
+				expectedVersion,
+			)
+		}
+
+		Convey("\nSCENARIO: A Customer adds, changes and removes her addresses", func() {
 			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
-				Convey("When she deletes her account", func() {
-					err = ac.deleteCustomer(v.customerID.String())
+				Convey("When she adds a billing and a shipping address", func() {
+					_, err = addAddress(billingAddress, 0)
+					So(err, ShouldBeNil)
+					_, err = addAddress(shippingAddress, 0)
 					So(err, ShouldBeNil)
 
-					Convey("And when she tries to retrieve her account data", func() {
-						actualCustomerView, err = ac.customerViewByID(v.customerID.String())
-
-						Convey("Then she should receive an error", func() {
-							So(err, ShouldBeError)
-							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
-							So(actualCustomerView, ShouldBeZeroValue)
-						})
-					})
-
-					Convey("And when she tries to delete her account again", func() {
-						err = ac.deleteCustomer(v.customerID.String())
+					Convey("Then her account should contain both addresses", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 						So(err, ShouldBeNil)
+						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+						expectedCustomerView.Addresses = []customer.AddressView{billingAddress, shippingAddress}
+						expectedCustomerView.Version = 3
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
 
-						Convey("Then her account should still be deleted", func() {
-							actualCustomerView, err = ac.customerViewByID(v.customerID.String())
-							So(err, ShouldBeError)
-							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
-							So(actualCustomerView, ShouldBeZeroValue)
+						Convey("And when she tries to add the same billing address again", func() {
+							_, err = addAddress(billingAddress, 0)
+							So(err, ShouldBeNil)
+
+							Convey("Then her account should still contain both addresses", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+							})
 						})
-					})
 
-					Convey("And when she tries to confirm her email address", func() {
-						err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.ch)
+						Convey("And when she tries to add another billing address", func() {
+							_, err = addAddress(customer.AddressView{
+								Kind:        "billing",
+								Street:      "Other Street 3",
+								PostalCode:  "10115",
+								City:        "Berlin",
+								CountryCode: "DE",
+							}, 0)
 
-						Convey("Then she should receive an error", func() {
+							Convey("Then she should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+							})
+						})
+
+						Convey("And when she changes her billing address", func() {
+							_, err = ac.changeCustomerAddress(ctx, v.customerID.String(), "billing", "Other Street 3", "80331", "Munich", "de", 0)
+							So(err, ShouldBeNil)
+
+							Convey("Then her account should contain the changed billing address", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								expectedCustomerView.Addresses[0] = customer.AddressView{
+									Kind:        "billing",
+									Street:      "Other Street 3",
+									PostalCode:  "80331",
+									City:        "Munich",
+									CountryCode: "DE",
+								}
+								expectedCustomerView.Version = 4
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+							})
+						})
+
+						Convey("And when she removes her shipping address", func() {
+							_, err = ac.removeCustomerAddress(ctx, v.customerID.String(), "shipping", 0)
+							So(err, ShouldBeNil)
+
+							Convey("Then her account should only contain the billing address", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								expectedCustomerView.Addresses = []customer.AddressView{billingAddress}
+								expectedCustomerView.Version = 4
+								So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+
+								Convey("And when she tries to change her shipping address", func() {
+									_, err = ac.changeCustomerAddress(ctx, v.customerID.String(), "shipping", "Side Street 2", "SW1A 1AA", "London", "GB", 0)
+
+									Convey("Then she should receive an error", func() {
+										So(err, ShouldBeError)
+										So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+									})
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer tries to add an address with invalid input", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When she supplies an unknown address kind", func() {
+					_, err = ac.addCustomerAddress(ctx, v.customerID.String(), "holiday", "Main Street 1", "10115", "Berlin", "DE", 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+
+				Convey("When she supplies a postal code which is invalid for the country", func() {
+					_, err = ac.addCustomerAddress(ctx, v.customerID.String(), "billing", "Main Street 1", "1011", "Berlin", "DE", 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+
+				Convey("When she supplies an empty street", func() {
+					_, err = ac.addCustomerAddress(ctx, v.customerID.String(), "billing", "", "10115", "Berlin", "DE", 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer tries to add an address based on an outdated version", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When she adds an address expecting version 2", func() {
+					_, err = addAddress(billingAddress, 2)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForChangingAndConfirmingCustomerPhoneNumbers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForChangingAndConfirmingCustomerPhoneNumbers)
+}
+
+func customerAcceptanceScenariosForChangingAndConfirmingCustomerPhoneNumbers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var expectedCustomerView customer.View
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("+49 30 12345678")
+		So(err, ShouldBeNil)
+
+		Convey("\nSCENARIO: A Customer adds a phone number", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("When she changes her phone number to [%s]", "+49 30 12345678"), func() {
+					_, err = ac.changeCustomerPhoneNumber(ctx, v.customerID.String(), "+49 30 12345678", 0)
+					So(err, ShouldBeNil)
+
+					Convey("Then her account should contain the normalized, unconfirmed phone number", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+						So(err, ShouldBeNil)
+						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+						expectedCustomerView.PhoneNumber = "+493012345678"
+						expectedCustomerView.Version = 2
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+					})
+				})
+
+				Convey("When she supplies a phone number which is not in international format", func() {
+					_, err = ac.changeCustomerPhoneNumber(ctx, v.customerID.String(), "030 12345678", 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+
+				Convey("When she tries to confirm a phone number", func() {
+					_, err = ac.confirmCustomerPhoneNumber(ctx, v.customerID.String(), "123456", 0)
+
+					Convey("Then she should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer confirms her phone number", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("and she changed her phone number to [%s]", phoneNumber.String()), func() {
+					givenCustomerPhoneNumberWasChanged(v.customerID, phoneNumber, 2)
+
+					Convey("When she confirms it with the right confirmation code", func() {
+						_, err = ac.confirmCustomerPhoneNumber(ctx, v.customerID.String(), phoneNumber.ConfirmationCode().String(), 0)
+						So(err, ShouldBeNil)
+
+						Convey("Then her phone number should be confirmed", func() {
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+							So(err, ShouldBeNil)
+							expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+							expectedCustomerView.PhoneNumber = phoneNumber.String()
+							expectedCustomerView.IsPhoneNumberConfirmed = true
+							expectedCustomerView.Version = 3
+							So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+						})
+					})
+
+					Convey("When she tries to confirm it with a wrong confirmation code", func() {
+						_, err = ac.confirmCustomerPhoneNumber(ctx, v.customerID.String(), "000000", 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+
+							Convey("and her phone number should still be unconfirmed", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeNil)
+								So(actualCustomerView.PhoneNumber, ShouldEqual, phoneNumber.String())
+								So(actualCustomerView.IsPhoneNumberConfirmed, ShouldBeFalse)
+								So(actualCustomerView.Version, ShouldEqual, 3)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForAddingBillingProfiles(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer adds a billing profile to her account", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				Convey("When she adds a billing profile ", func() {
+					Convey("Then her account should contain one billing profile", func() {
+
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRemovingBillingProfiles(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer removes a billing profile from her account", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				Convey("And given she added a billing profile", func() {
+					Convey("And given she added another billing profile", func() {
+						Convey("When she removes the first billing profile from her account", func() {
+							Convey("Then her account should contain only the second billing profile", func() {
+
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer tries to remove the only billing profile from her account", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				Convey("And given she added a billing profile", func() {
+					Convey("When she tries to remove the billing profile from her account", func() {
+						Convey("Then she should receive an error", func() {
+
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForSuspendingAndReactivatingCustomers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForSuspendingAndReactivatingCustomers)
+}
+
+func customerAcceptanceScenariosForSuspendingAndReactivatingCustomers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var expectedCustomerView customer.View
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: The support team suspends and reactivates a Customer's account", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("When the support team suspends her account because of [%s]", v.sr), func() {
+					_, err = ac.suspendCustomer(ctx, v.customerID.String(), v.sr, 0)
+					So(err, ShouldBeNil)
+
+					Convey("Then her account should be suspended", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+						So(err, ShouldBeNil)
+						expectedCustomerView = buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+						expectedCustomerView.Status = customer.StatusSuspended
+						expectedCustomerView.Version = 2
+						So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+					})
+
+					Convey("And when she tries to change her name", func() {
+						_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+
+					Convey("And when she tries to delete her account", func() {
+						_, err = ac.deleteCustomer(ctx, v.customerID.String(), 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+
+					Convey("And when the support team reactivates her account", func() {
+						_, err = ac.reactivateCustomer(ctx, v.customerID.String(), 0)
+						So(err, ShouldBeNil)
+
+						Convey("Then her account should be active again", func() {
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+							So(err, ShouldBeNil)
+							So(actualCustomerView.Status, ShouldEqual, customer.StatusActive)
+
+							Convey(fmt.Sprintf("And when she changes her name to [%s %s]", v.cgn, v.cfn), func() {
+								_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 0)
+
+								Convey("Then it should succeed", func() {
+									So(err, ShouldBeNil)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: The support team tries to suspend a Customer's account without a reason", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When the support team suspends her account with an empty reason", func() {
+					_, err = ac.suspendCustomer(ctx, v.customerID.String(), " ", 0)
+
+					Convey("Then it should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForDeletingCustomers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForDeletingCustomers)
+}
+
+func customerAcceptanceScenariosForDeletingCustomers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer deletes her account", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When she deletes her account", func() {
+					_, err = ac.deleteCustomer(ctx, v.customerID.String(), 0)
+					So(err, ShouldBeNil)
+
+					Convey("And when she tries to retrieve her account data", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							So(actualCustomerView, ShouldBeZeroValue)
+						})
+					})
+
+					Convey("And when she tries to delete her account again", func() {
+						_, err = ac.deleteCustomer(ctx, v.customerID.String(), 0)
+						So(err, ShouldBeNil)
+
+						Convey("Then her account should still be deleted", func() {
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							So(actualCustomerView, ShouldBeZeroValue)
+						})
+					})
+
+					Convey("And when she tries to confirm her email address", func() {
+						_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+
+					Convey("And when she tries to change her email address", func() {
+						_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), v.cea, 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+
+					Convey("And when she tries to change her name", func() {
+						_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRestoringCustomers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRestoringCustomers)
+}
+
+func customerAcceptanceScenariosForRestoringCustomers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer restores her account right after she deleted it", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("and she deleted her account", func() {
+					givenCustomerWasDeleted(v.customerID, 2)
+
+					Convey("When she restores her account", func() {
+						_, err = ac.restoreCustomer(ctx, v.customerID.String(), 2)
+						So(err, ShouldBeNil)
+
+						Convey("Then her account data should be retrievable again", func() {
+							expectedCustomerView := buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.name)
+							expectedCustomerView.Version = 3
+
+							actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+							So(err, ShouldBeNil)
+							So(actualCustomerView, shouldResembleCustomerView, expectedCustomerView)
+						})
+
+						Convey("and when she changes her name", func() {
+							_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 3)
+
+							Convey("Then it should succeed", func() {
+								So(err, ShouldBeNil)
+							})
+						})
+
+						Convey(fmt.Sprintf("and when another Customer tries to register with [%s]", v.ea), func() {
+							_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.ea, v.gn, v.fn)
+
+							Convey("Then it should fail, because the email address was reclaimed", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: A Customer can't restore her account, because her email address is now used by another Customer", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("and she deleted her account", func() {
+					givenCustomerWasDeleted(v.customerID, 2)
+
+					Convey(fmt.Sprintf("and another Customer registered with [%s]", v.ea), func() {
+						givenCustomerRegistered(v.otherCustomerID, v.emailAddress, v.name)
+
+						Convey("When she tries to restore her account", func() {
+							_, err = ac.restoreCustomer(ctx, v.customerID.String(), 2)
+
+							Convey("Then she should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+							})
+
+							Convey("and her account should still be deleted", func() {
+								actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForErasingCustomerPersonalData(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForErasingCustomerPersonalData)
+}
+
+func customerAcceptanceScenariosForErasingCustomerPersonalData(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer requests the erasure of her personal data", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When her personal data is erased", func() {
+					_, err = ac.erasePersonalData(ctx, v.customerID.String(), 1)
+					So(err, ShouldBeNil)
+
+					Convey("Then her account data should not be retrievable any more", func() {
+						actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						So(actualCustomerView, ShouldBeZeroValue)
+					})
+
+					Convey("and when she tries to restore her account", func() {
+						_, err = ac.restoreCustomer(ctx, v.customerID.String(), 0)
+
+						Convey("Then she should receive an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+
+					Convey("and when her personal data is erased again", func() {
+						_, err = ac.erasePersonalData(ctx, v.customerID.String(), 0)
+
+						Convey("Then it should succeed", func() {
+							So(err, ShouldBeNil)
+						})
+					})
+
+					Convey(fmt.Sprintf("and when another Customer registers with [%s]", v.ea), func() {
+						_, err = ac.registerCustomer(ctx, v.otherCustomerID, v.ea, v.gn, v.fn)
+
+						Convey("Then it should succeed", func() {
+							So(err, ShouldBeNil)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: The personal data of a deleted Customer is erased", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("and she deleted her account", func() {
+					givenCustomerWasDeleted(v.customerID, 2)
+
+					Convey("When her personal data is erased", func() {
+						_, err = ac.erasePersonalData(ctx, v.customerID.String(), 2)
+						So(err, ShouldBeNil)
+
+						Convey("and when she tries to restore her account", func() {
+							_, err = ac.restoreCustomer(ctx, v.customerID.String(), 0)
+
+							Convey("Then she should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForExportingCustomerData(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForExportingCustomerData)
+}
+
+func customerAcceptanceScenariosForExportingCustomerData(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualExport customer.Export
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: A Customer requests all data which is stored about her", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("and she tried to confirm her email address with a wrong confirmation hash", func() {
+					_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), "invalid_confirmation_hash", 1)
+					So(err, ShouldBeError)
+
+					Convey("and she changed her name", func() {
+						_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 2)
+						So(err, ShouldBeNil)
+
+						Convey("When her data is exported", func() {
+							actualExport, err = ac.exportCustomerData(ctx, v.customerID.String())
+							So(err, ShouldBeNil)
+
+							Convey("Then it should contain her current account data", func() {
+								expectedCustomerView := buildDefaultCustomerViewForAcceptanceTest(v.customerID, v.emailAddress, v.changedName)
+								expectedCustomerView.Version = 3
+
+								So(actualExport.View, shouldResembleCustomerView, expectedCustomerView)
+							})
+
+							Convey("and the full history of her account, including the failed confirmation", func() {
+								So(actualExport.Events, ShouldHaveLength, 3)
+								So(actualExport.Events[0].Name, ShouldEqual, "CustomerRegistered")
+								So(actualExport.Events[1].Name, ShouldEqual, "CustomerEmailAddressConfirmationFailed")
+								So(actualExport.Events[1].IsFailure, ShouldBeTrue)
+								So(actualExport.Events[2].Name, ShouldEqual, "CustomerNameChanged")
+
+								for _, event := range actualExport.Events {
+									So(event.Description, ShouldNotBeEmpty)
+									So(event.OccurredAt, ShouldNotBeEmpty)
+								}
+							})
+
+							Convey("and her reserved email address", func() {
+								So(actualExport.UniqueEmailAddresses, ShouldResemble, []string{v.emailAddress.Canonical()})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRetrievingCustomerEventHistories(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRetrievingCustomerEventHistories)
+}
+
+func customerAcceptanceScenariosForRetrievingCustomerEventHistories(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualPage customer.EventHistoryPage
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: Support retrieves the event history of a Customer", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("and she tried to confirm her email address with a wrong confirmation hash", func() {
+					_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), "invalid_confirmation_hash", 1)
+					So(err, ShouldBeError)
+
+					Convey("and she changed her name", func() {
+						_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 2)
+						So(err, ShouldBeNil)
+
+						Convey("When support retrieves her event history", func() {
+							actualPage, err = ac.customerEventHistory(ctx, v.customerID.String(), 0, 0)
+							So(err, ShouldBeNil)
+
+							Convey("Then it should contain all events with their metadata and redacted payload", func() {
+								So(actualPage.Events, ShouldHaveLength, 3)
+								So(actualPage.NextFromVersion, ShouldBeZeroValue)
+								So(actualPage.Events[0].Name, ShouldEqual, "CustomerRegistered")
+								So(actualPage.Events[0].Payload["emailAddress"], ShouldEqual, "f***@gallagher.net")
+								So(actualPage.Events[1].Name, ShouldEqual, "CustomerEmailAddressConfirmationFailed")
+								So(actualPage.Events[1].IsFailure, ShouldBeTrue)
+								So(actualPage.Events[2].Name, ShouldEqual, "CustomerNameChanged")
+								So(actualPage.Events[2].Payload["personFamilyName"], ShouldEqual, "L***")
+
+								for i, event := range actualPage.Events {
+									So(event.StreamVersion, ShouldEqual, i+1)
+									So(event.OccurredAt, ShouldNotBeEmpty)
+									So(event.MessageID, ShouldNotBeEmpty)
+									So(event.CausationID, ShouldNotBeEmpty)
+								}
+							})
+						})
+
+						Convey("When support retrieves her event history two events at a time", func() {
+							actualPage, err = ac.customerEventHistory(ctx, v.customerID.String(), 0, 2)
+							So(err, ShouldBeNil)
+
+							Convey("Then the first page should contain the first two events", func() {
+								So(actualPage.Events, ShouldHaveLength, 2)
+								So(actualPage.NextFromVersion, ShouldEqual, 3)
+
+								Convey("and the next page should contain the last event", func() {
+									actualPage, err = ac.customerEventHistory(ctx, v.customerID.String(), actualPage.NextFromVersion, 2)
+									So(err, ShouldBeNil)
+									So(actualPage.Events, ShouldHaveLength, 1)
+									So(actualPage.Events[0].Name, ShouldEqual, "CustomerNameChanged")
+									So(actualPage.NextFromVersion, ShouldBeZeroValue)
+								})
+							})
+						})
+
+						Convey("When support retrieves her event history after the last event", func() {
+							actualPage, err = ac.customerEventHistory(ctx, v.customerID.String(), 4, 0)
+
+							Convey("Then it should be empty", func() {
+								So(err, ShouldBeNil)
+								So(actualPage.Events, ShouldBeEmpty)
+								So(actualPage.NextFromVersion, ShouldBeZeroValue)
+							})
+						})
+
+						Convey("When support requests too many events at once", func() {
+							_, err = ac.customerEventHistory(ctx, v.customerID.String(), 0, customer.MaxEventHistoryPageSize+1)
+
+							Convey("Then it should receive an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForSearchingCustomers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForSearchingCustomers)
+}
+
+func customerAcceptanceScenariosForSearchingCustomers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualPage customer.ViewPage
+
+		v := initAcceptanceTestValues()
+
+		// Customers who registered before this test (e.g. in a shared database) must not be found
+		registeredFrom := time.Now().UTC().Add(-time.Second).Format(time.RFC3339Nano)
+
+		Convey("\nSCENARIO: The back-office searches for Customers", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s] with [%s]", v.gn, v.fn, v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("and another Customer registered as [%s %s] with [%s]", v.cgn, v.cfn, v.cea), func() {
+					givenCustomerRegistered(v.otherCustomerID, v.changedEmailAddress, v.changedName)
+
+					Convey("and the other Customer confirmed her email address", func() {
+						givenCustomerEmailAddressWasConfirmed(v.otherCustomerID, v.changedEmailAddress, 2)
+
+						Convey("When the back-office searches for the name fragment [gallag]", func() {
+							actualPage, err = ac.searchCustomerViews(
+								ctx,
+								customer.ViewSearchCriteria{NameFragment: "gallag", RegisteredFrom: registeredFrom},
+							)
+
+							Convey("Then it should only find the first Customer", func() {
+								So(err, ShouldBeNil)
+								So(actualPage.Views, ShouldHaveLength, 1)
+								So(actualPage.Views[0].ID, ShouldEqual, v.customerID.String())
+								So(actualPage.NextCursor, ShouldBeEmpty)
+							})
+						})
+
+						Convey("When the back-office searches for her email address in different case", func() {
+							actualPage, err = ac.searchCustomerViews(
+								ctx,
+								customer.ViewSearchCriteria{EmailAddress: " Fiona@Gallagher.NET "},
+							)
+
+							Convey("Then it should find the first Customer", func() {
+								So(err, ShouldBeNil)
+								So(actualPage.Views, ShouldHaveLength, 1)
+								So(actualPage.Views[0].ID, ShouldEqual, v.customerID.String())
+							})
+						})
+
+						Convey("When the back-office searches for Customers with a confirmed email address", func() {
+							actualPage, err = ac.searchCustomerViews(
+								ctx,
+								customer.ViewSearchCriteria{
+									ConfirmationStatus: customer.ConfirmationStatusConfirmed,
+									RegisteredFrom:     registeredFrom,
+								},
+							)
+
+							Convey("Then it should only find the other Customer", func() {
+								So(err, ShouldBeNil)
+								So(actualPage.Views, ShouldHaveLength, 1)
+								So(actualPage.Views[0].ID, ShouldEqual, v.otherCustomerID.String())
+							})
+						})
+
+						Convey("When the back-office searches for Customers who registered before this test", func() {
+							actualPage, err = ac.searchCustomerViews(
+								ctx,
+								customer.ViewSearchCriteria{NameFragment: "fiona", RegisteredBefore: registeredFrom},
+							)
+
+							Convey("Then it should find none of them", func() {
+								So(err, ShouldBeNil)
+
+								for _, view := range actualPage.Views {
+									So(view.ID, ShouldNotBeIn, v.customerID.String(), v.otherCustomerID.String())
+								}
+							})
+						})
+
+						Convey("When the back-office lists the Customers by family name, one per page", func() {
+							criteria := customer.ViewSearchCriteria{
+								RegisteredFrom: registeredFrom,
+								SortBy:         customer.SortByFamilyName,
+								PageSize:       1,
+							}
+
+							actualPage, err = ac.searchCustomerViews(ctx, criteria)
+							So(err, ShouldBeNil)
+
+							Convey("Then the first page should contain the first Customer and a cursor", func() {
+								So(actualPage.Views, ShouldHaveLength, 1)
+								So(actualPage.Views[0].FamilyName, ShouldEqual, v.fn)
+								So(actualPage.NextCursor, ShouldNotBeEmpty)
+
+								Convey("and the next page should contain the other Customer and no cursor", func() {
+									criteria.Cursor = actualPage.NextCursor
+									actualPage, err = ac.searchCustomerViews(ctx, criteria)
+									So(err, ShouldBeNil)
+									So(actualPage.Views, ShouldHaveLength, 1)
+									So(actualPage.Views[0].FamilyName, ShouldEqual, v.cfn)
+									So(actualPage.NextCursor, ShouldBeEmpty)
+								})
+
+								Convey("and the cursor should not work with another sorting", func() {
+									criteria.Cursor = actualPage.NextCursor
+									criteria.SortBy = customer.SortByEmailAddress
+									_, err = ac.searchCustomerViews(ctx, criteria)
+									So(err, ShouldBeError)
+									So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+								})
+							})
+						})
+
+						Convey("and the first Customer deleted her account", func() {
+							givenCustomerWasDeleted(v.customerID, 2)
+
+							Convey("When the back-office lists the Customers", func() {
+								actualPage, err = ac.searchCustomerViews(
+									ctx,
+									customer.ViewSearchCriteria{RegisteredFrom: registeredFrom},
+								)
+
+								Convey("Then it should only find the other Customer", func() {
+									So(err, ShouldBeNil)
+									So(actualPage.Views, ShouldHaveLength, 1)
+									So(actualPage.Views[0].ID, ShouldEqual, v.otherCustomerID.String())
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: The back-office searches with invalid criteria", func() {
+			Convey("When it sorts by an unknown field", func() {
+				_, err = ac.searchCustomerViews(ctx, customer.ViewSearchCriteria{SortBy: "shoeSize"})
+
+				Convey("Then it should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+
+			Convey("When it requests too many Customers per page", func() {
+				_, err = ac.searchCustomerViews(ctx, customer.ViewSearchCriteria{PageSize: customer.MaxViewPageSize + 1})
+
+				Convey("Then it should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+			err = atPurgeCustomerEventStream(ctx, v.otherCustomerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRetrievingHistoricCustomerViews(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRetrievingHistoricCustomerViews)
+}
+
+func customerAcceptanceScenariosForRetrievingHistoricCustomerViews(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: Support looks up how a Customer's account looked in the past", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s]", v.gn, v.fn), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey(fmt.Sprintf("and she changed her name to [%s %s]", v.cgn, v.cfn), func() {
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+					So(err, ShouldBeNil)
+
+					Convey("When support retrieves her account as of the first version", func() {
+						actualCustomerView, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 1, "")
+
+						Convey("Then it should show her previous name", func() {
+							So(err, ShouldBeNil)
+							So(actualCustomerView.FamilyName, ShouldEqual, v.fn)
+							So(actualCustomerView.Version, ShouldEqual, 1)
+						})
+					})
+
+					Convey("When support retrieves her account as of now", func() {
+						asOfTime := time.Now().UTC().Format(time.RFC3339Nano)
+						actualCustomerView, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 0, asOfTime)
+
+						Convey("Then it should show her current name", func() {
+							So(err, ShouldBeNil)
+							So(actualCustomerView.FamilyName, ShouldEqual, v.cfn)
+							So(actualCustomerView.Version, ShouldEqual, 2)
+						})
+					})
+
+					Convey("When support retrieves her account as of a time before she registered", func() {
+						_, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 0, "2020-01-01")
+
+						Convey("Then it should not find her", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+
+					Convey("and given she deleted her account", func() {
+						givenCustomerWasDeleted(v.customerID, 3)
+
+						Convey("When support retrieves her account as of the version before", func() {
+							actualCustomerView, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 2, "")
+
+							Convey("Then it should still show her account", func() {
+								So(err, ShouldBeNil)
+								So(actualCustomerView.FamilyName, ShouldEqual, v.cfn)
+							})
+						})
+
+						Convey("When support retrieves her account as of the deletion", func() {
+							_, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 3, "")
+
+							Convey("Then it should not find her", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							})
+						})
+					})
+				})
+
+				Convey("When support supplies both a version and a time", func() {
+					_, err = ac.customerViewByIDAsOf(ctx, v.customerID.String(), 1, "2021-03-01")
+
+					Convey("Then it should receive an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForRetrievingCustomersByEmailAddress(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForRetrievingCustomersByEmailAddress)
+}
+
+func customerAcceptanceScenariosForRetrievingCustomersByEmailAddress(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+		var actualCustomerView customer.View
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: Support looks up a Customer by her email address", func() {
+			Convey(fmt.Sprintf("Given a Customer registered with [%s]", v.ea), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When support looks her up by her email address in different case", func() {
+					actualCustomerView, err = ac.customerViewByEmailAddress(ctx, " Fiona@Gallagher.NET ")
+
+					Convey("Then it should find her", func() {
+						So(err, ShouldBeNil)
+						So(actualCustomerView.ID, ShouldEqual, v.customerID.String())
+						So(actualCustomerView.EmailAddress, ShouldEqual, v.ea)
+					})
+				})
+
+				Convey(fmt.Sprintf("and given she confirmed her email address and requested to change it to [%s]", v.cea), func() {
+					givenCustomerEmailAddressWasConfirmed(v.customerID, v.emailAddress, 2)
+					givenCustomerEmailAddressChangeWasRequested(v.customerID, v.changedEmailAddress, 3)
+
+					Convey("When support looks her up by her pending email address", func() {
+						_, err = ac.customerViewByEmailAddress(ctx, v.cea)
+
+						Convey("Then it should not find her", func() {
 							So(err, ShouldBeError)
 							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
 						})
 					})
 
-					Convey("And when she tries to change her email address", func() {
-						err = ac.changeCustomerEmailAddress(v.customerID.String(), v.cea)
+					Convey("When support looks her up by her confirmed email address", func() {
+						actualCustomerView, err = ac.customerViewByEmailAddress(ctx, v.ea)
 
-						Convey("Then she should receive an error", func() {
-							So(err, ShouldBeError)
-							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						Convey("Then it should find her", func() {
+							So(err, ShouldBeNil)
+							So(actualCustomerView.ID, ShouldEqual, v.customerID.String())
 						})
 					})
+				})
 
-					Convey("And when she tries to change her name", func() {
-						err = ac.changeCustomerName(v.customerID.String(), v.cgn, v.cfn)
+				Convey("and given she deleted her account", func() {
+					givenCustomerWasDeleted(v.customerID, 2)
 
-						Convey("Then she should receive an error", func() {
+					Convey("When support looks her up by her email address", func() {
+						_, err = ac.customerViewByEmailAddress(ctx, v.ea)
+
+						Convey("Then it should not find her", func() {
 							So(err, ShouldBeError)
 							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
 						})
@@ -587,17 +2001,127 @@ func TestCustomerAcceptanceScenarios_ForDeletingCustomers(t *testing.T) {
 			})
 		})
 
+		Convey("\nSCENARIO: Support looks up an email address which is not registered", func() {
+			Convey("When support looks up the email address", func() {
+				_, err = ac.customerViewByEmailAddress(ctx, v.ea)
+
+				Convey("Then it should not find anyone", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: Support looks up an invalid email address", func() {
+			Convey("When support looks up the email address", func() {
+				_, err = ac.customerViewByEmailAddress(ctx, "fiona@")
+
+				Convey("Then it should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+		})
+
+		Reset(func() {
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCustomerAcceptanceScenarios_ForWatchingCustomers(t *testing.T) {
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosForWatchingCustomers)
+}
+
+func customerAcceptanceScenariosForWatchingCustomers(t *testing.T, ac acceptanceTestCollaborators) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		var err error
+
+		v := initAcceptanceTestValues()
+
+		Convey("\nSCENARIO: Another service watches a Customer for changes", func() {
+			Convey(fmt.Sprintf("Given a Customer registered as [%s %s]", v.gn, v.fn), func() {
+				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
+
+				Convey("When another service starts to watch her", func() {
+					watchCtx, stopWatching := context.WithCancel(ctx)
+					defer stopWatching()
+
+					pushed, watchErr := startWatchingCustomer(watchCtx, ac, v.customerID, 0)
+
+					Convey("Then it should receive her registration", func() {
+						So(receivePushedEvent(pushed).Name, ShouldEqual, "CustomerRegistered")
+
+						Convey(fmt.Sprintf("and when she changes her name to [%s %s]", v.cgn, v.cfn), func() {
+							_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+							So(err, ShouldBeNil)
+
+							Convey("Then the change should be pushed right away", func() {
+								event := receivePushedEvent(pushed)
+								So(event.Name, ShouldEqual, "CustomerNameChanged")
+								So(event.StreamVersion, ShouldEqual, 2)
+							})
+						})
+
+						Convey("and when it stops watching", func() {
+							stopWatching()
+
+							Convey("Then the watch should end without an error", func() {
+								So(<-watchErr, ShouldBeNil)
+							})
+						})
+					})
+				})
+
+				Convey(fmt.Sprintf("and given she changed her name to [%s %s]", v.cgn, v.cfn), func() {
+					_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.cgn, v.cfn, 1)
+					So(err, ShouldBeNil)
+
+					Convey("When another service resumes watching her after her registration", func() {
+						watchCtx, stopWatching := context.WithCancel(ctx)
+						defer stopWatching()
+
+						pushed, _ := startWatchingCustomer(watchCtx, ac, v.customerID, 1)
+
+						Convey("Then it should only receive the change of her name", func() {
+							event := receivePushedEvent(pushed)
+							So(event.Name, ShouldEqual, "CustomerNameChanged")
+							So(event.StreamVersion, ShouldEqual, 2)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO: Another service tries to watch a Customer which does not exist", func() {
+			Convey("When it starts to watch her", func() {
+				err = ac.watchCustomer(ctx, v.customerID.String(), 0, func(event customer.HistoricEvent) error {
+					return nil
+				})
+
+				Convey("Then it should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+		})
+
 		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
 			So(err, ShouldBeNil)
 		})
 	})
 }
 
 func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosWhenCustomerWasNeverRegistered)
+}
 
+func customerAcceptanceScenariosWhenCustomerWasNeverRegistered(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 		var actualCustomerView customer.View
 
@@ -605,7 +2129,7 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 
 		Convey("\nSCENARIO: A hacker tries to play around with a non existing Customer account by guessing IDs", func() {
 			Convey("When she tries to retrieve data for a non existing account", func() {
-				actualCustomerView, err = ac.customerViewByID(v.customerID.String())
+				actualCustomerView, err = ac.customerViewByID(ctx, v.customerID.String())
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -615,7 +2139,16 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 			})
 
 			Convey("And when she tries to confirm an email address", func() {
-				err = ac.confirmCustomerEmailAddress(v.customerID.String(), v.ch)
+				_, err = ac.confirmCustomerEmailAddress(ctx, v.customerID.String(), v.ch, 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she requests a resend of a confirmation email", func() {
+				_, err = ac.requestConfirmationResend(ctx, v.customerID.String(), 0)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -624,7 +2157,16 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 			})
 
 			Convey("And when she tries to change an email address", func() {
-				err = ac.changeCustomerEmailAddress(v.customerID.String(), v.ea)
+				_, err = ac.changeCustomerEmailAddress(ctx, v.customerID.String(), v.ea, 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to cancel an email address change", func() {
+				_, err = ac.cancelEmailAddressChange(ctx, v.customerID.String(), 0)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -633,7 +2175,61 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 			})
 
 			Convey("And when she tries to change a name", func() {
-				err = ac.changeCustomerName(v.customerID.String(), v.gn, v.fn)
+				_, err = ac.changeCustomerName(ctx, v.customerID.String(), v.gn, v.fn, 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to suspend an account", func() {
+				_, err = ac.suspendCustomer(ctx, v.customerID.String(), v.sr, 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to reactivate an account", func() {
+				_, err = ac.reactivateCustomer(ctx, v.customerID.String(), 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to add an address", func() {
+				_, err = ac.addCustomerAddress(ctx, v.customerID.String(), "billing", "Main Street 1", "10115", "Berlin", "DE", 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to remove an address", func() {
+				_, err = ac.removeCustomerAddress(ctx, v.customerID.String(), "billing", 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to change a phone number", func() {
+				_, err = ac.changeCustomerPhoneNumber(ctx, v.customerID.String(), "+49 30 12345678", 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to confirm a phone number", func() {
+				_, err = ac.confirmCustomerPhoneNumber(ctx, v.customerID.String(), "123456", 0)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -642,7 +2238,43 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 			})
 
 			Convey("And when she tries to delete an account", func() {
-				err = ac.deleteCustomer(v.customerID.String())
+				_, err = ac.deleteCustomer(ctx, v.customerID.String(), 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to restore an account", func() {
+				_, err = ac.restoreCustomer(ctx, v.customerID.String(), 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to erase the personal data of an account", func() {
+				_, err = ac.erasePersonalData(ctx, v.customerID.String(), 0)
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to export the data of an account", func() {
+				_, err = ac.exportCustomerData(ctx, v.customerID.String())
+
+				Convey("Then she should receive an error", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("And when she tries to retrieve the event history of an account", func() {
+				_, err = ac.customerEventHistory(ctx, v.customerID.String(), 0, 0)
 
 				Convey("Then she should receive an error", func() {
 					So(err, ShouldBeError)
@@ -654,9 +2286,12 @@ func TestCustomerAcceptanceScenarios_WhenCustomerWasNeverRegistered(t *testing.T
 }
 
 func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
-	ac := initAcceptanceTestCollaborators()
+	forEachAcceptanceTestEventStore(t, customerAcceptanceScenariosInvalidClientInput)
+}
 
+func customerAcceptanceScenariosInvalidClientInput(t *testing.T, ac acceptanceTestCollaborators) {
 	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
 		var err error
 
 		v := initAcceptanceTestValues()
@@ -666,7 +2301,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 				givenCustomerRegistered(v.customerID, v.emailAddress, v.name)
 
 				Convey("When she tries to confirm her email address with an empty id", func() {
-					err = ac.confirmCustomerEmailAddress("", v.ch)
+					_, err = ac.confirmCustomerEmailAddress(ctx, "", v.ch, 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -675,7 +2310,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 				})
 
 				Convey("When she tries to change her email address with an empty id", func() {
-					err = ac.changeCustomerEmailAddress("", v.ea)
+					_, err = ac.changeCustomerEmailAddress(ctx, "", v.ea, 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -684,7 +2319,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 				})
 
 				Convey("When she tries to change her name with an empty id", func() {
-					err = ac.changeCustomerName("", v.gn, v.fn)
+					_, err = ac.changeCustomerName(ctx, "", v.gn, v.fn, 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -693,7 +2328,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 				})
 
 				Convey("When she tries to delete her account with an empty id", func() {
-					err = ac.deleteCustomer("")
+					_, err = ac.deleteCustomer(ctx, "", 0)
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -702,7 +2337,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 				})
 
 				Convey("When she tries to retrieve her account with an empty id", func() {
-					_, err = ac.customerViewByID("")
+					_, err = ac.customerViewByID(ctx, "")
 
 					Convey("Then she should receive an error", func() {
 						So(err, ShouldBeError)
@@ -713,7 +2348,7 @@ func TestCustomerAcceptanceScenarios_InvalidClientInput(t *testing.T) {
 		})
 
 		Reset(func() {
-			err = atPurgeCustomerEventStream(v.customerID)
+			err = atPurgeCustomerEventStream(ctx, v.customerID)
 			So(err, ShouldBeNil)
 		})
 	})
@@ -733,7 +2368,7 @@ func givenCustomerRegistered(
 		1,
 	)
 
-	err := atStartCustomerEventStream(registered)
+	err := atStartCustomerEventStream(context.Background(), registered)
 	So(err, ShouldBeNil)
 }
 
@@ -743,7 +2378,7 @@ func givenCustomerEmailAddressWasConfirmed(
 	streamVersion uint,
 ) {
 
-	confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash())
+	confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
 	So(err, ShouldBeNil)
 
 	event := domain.BuildCustomerEmailAddressConfirmed(
@@ -753,7 +2388,7 @@ func givenCustomerEmailAddressWasConfirmed(
 		streamVersion,
 	)
 
-	err = atAppendToCustomerEventStream(es.RecordedEvents{event}, customerID)
+	err = atAppendToCustomerEventStream(context.Background(), es.RecordedEvents{event}, customerID)
 	So(err, ShouldBeNil)
 }
 
@@ -770,7 +2405,56 @@ func givenCustomerEmailAddressWasChanged(
 		streamVersion,
 	)
 
-	err := atAppendToCustomerEventStream(es.RecordedEvents{event}, customerID)
+	err := atAppendToCustomerEventStream(context.Background(), es.RecordedEvents{event}, customerID)
+	So(err, ShouldBeNil)
+}
+
+func givenCustomerEmailAddressChangeWasRequested(
+	customerID value.CustomerID,
+	emailAddress value.UnconfirmedEmailAddress,
+	streamVersion uint,
+) {
+
+	event := domain.BuildCustomerEmailAddressChangeRequested(
+		customerID,
+		emailAddress,
+		es.GenerateMessageID(),
+		streamVersion,
+	)
+
+	err := atAppendToCustomerEventStream(context.Background(), es.RecordedEvents{event}, customerID)
+	So(err, ShouldBeNil)
+}
+
+func givenCustomerPhoneNumberWasChanged(
+	customerID value.CustomerID,
+	phoneNumber value.UnconfirmedPhoneNumber,
+	streamVersion uint,
+) {
+
+	event := domain.BuildCustomerPhoneNumberChanged(
+		customerID,
+		phoneNumber,
+		es.GenerateMessageID(),
+		streamVersion,
+	)
+
+	err := atAppendToCustomerEventStream(context.Background(), es.RecordedEvents{event}, customerID)
+	So(err, ShouldBeNil)
+}
+
+func givenCustomerWasDeleted(
+	customerID value.CustomerID,
+	streamVersion uint,
+) {
+
+	event := domain.BuildCustomerDeleted(
+		customerID,
+		es.GenerateMessageID(),
+		streamVersion,
+	)
+
+	err := atAppendToCustomerEventStream(context.Background(), es.RecordedEvents{event}, customerID)
 	So(err, ShouldBeNil)
 }
 
@@ -786,15 +2470,60 @@ func buildDefaultCustomerViewForAcceptanceTest(
 		IsEmailAddressConfirmed: false,
 		GivenName:               name.GivenName(),
 		FamilyName:              name.FamilyName(),
+		Status:                  customer.StatusActive,
 		Version:                 1,
 	}
 }
 
-func initAcceptanceTestCollaborators() acceptanceTestCollaborators {
+// shouldResembleCustomerView is ShouldResemble for Views, except that it only expects RegisteredAt to be recent,
+// because the Customers of the acceptance tests register right before.
+func shouldResembleCustomerView(actual interface{}, expected ...interface{}) string {
+	actualCustomerView, ok := actual.(customer.View)
+	if !ok {
+		return "actual is not a customer.View"
+	}
+
+	if time.Since(actualCustomerView.RegisteredAt) > time.Minute {
+		return fmt.Sprintf("Expected RegisteredAt to be recent, but it is [%s]", actualCustomerView.RegisteredAt)
+	}
+
+	expectedCustomerView, ok := expected[0].(customer.View)
+	if !ok {
+		return "expected is not a customer.View"
+	}
+
+	expectedCustomerView.RegisteredAt = actualCustomerView.RegisteredAt
+
+	return ShouldResemble(actualCustomerView, expectedCustomerView)
+}
+
+// forEachAcceptanceTestEventStore runs the scenarios against the in-memory event store and against Postgres,
+// unless no POSTGRES_DSN is configured.
+func forEachAcceptanceTestEventStore(t *testing.T, scenarios func(t *testing.T, ac acceptanceTestCollaborators)) {
 	logger := shared.NewNilLogger()
 	config := grpc.MustBuildConfigFromEnv(logger)
-	postgresDBConn := grpc.MustInitPostgresDB(config, logger)
-	diContainer := grpc.MustBuildDIContainer(config, logger, grpc.UsePostgresDBConn(postgresDBConn))
+
+	t.Run("InMemoryEventStore", func(t *testing.T) {
+		scenarios(t, initAcceptanceTestCollaborators(config, logger, grpc.UseInMemoryCustomerEventStore()))
+	})
+
+	t.Run("PostgresEventStore", func(t *testing.T) {
+		if config.Postgres.DSN == "" {
+			t.Skip("no POSTGRES_DSN configured")
+		}
+
+		postgresDBConn := grpc.MustInitPostgresDB(config, logger)
+		scenarios(t, initAcceptanceTestCollaborators(config, logger, grpc.UsePostgresDBConn(postgresDBConn)))
+	})
+}
+
+func initAcceptanceTestCollaborators(
+	config *grpc.Config,
+	logger *shared.Logger,
+	storageOption grpc.DIOption,
+) acceptanceTestCollaborators {
+
+	diContainer := grpc.MustBuildDIContainer(config, logger, storageOption)
 	eventStore := diContainer.GetCustomerEventStore()
 	atStartCustomerEventStream = eventStore.StartEventStream
 	atAppendToCustomerEventStream = eventStore.AppendToEventStream
@@ -803,10 +2532,71 @@ func initAcceptanceTestCollaborators() acceptanceTestCollaborators {
 	return acceptanceTestCollaborators{
 		registerCustomer:            diContainer.GetCustomerCommandHandler().RegisterCustomer,
 		confirmCustomerEmailAddress: diContainer.GetCustomerCommandHandler().ConfirmCustomerEmailAddress,
+		requestConfirmationResend:   diContainer.GetCustomerCommandHandler().RequestCustomerEmailAddressConfirmationResend,
 		changeCustomerEmailAddress:  diContainer.GetCustomerCommandHandler().ChangeCustomerEmailAddress,
+		cancelEmailAddressChange:    diContainer.GetCustomerCommandHandler().CancelCustomerEmailAddressChange,
 		changeCustomerName:          diContainer.GetCustomerCommandHandler().ChangeCustomerName,
+		addCustomerAddress:          diContainer.GetCustomerCommandHandler().AddCustomerAddress,
+		changeCustomerAddress:       diContainer.GetCustomerCommandHandler().ChangeCustomerAddress,
+		removeCustomerAddress:       diContainer.GetCustomerCommandHandler().RemoveCustomerAddress,
+		changeCustomerPhoneNumber:   diContainer.GetCustomerCommandHandler().ChangeCustomerPhoneNumber,
+		confirmCustomerPhoneNumber:  diContainer.GetCustomerCommandHandler().ConfirmCustomerPhoneNumber,
+		suspendCustomer:             diContainer.GetCustomerCommandHandler().SuspendCustomer,
+		reactivateCustomer:          diContainer.GetCustomerCommandHandler().ReactivateCustomer,
 		deleteCustomer:              diContainer.GetCustomerCommandHandler().DeleteCustomer,
+		restoreCustomer:             diContainer.GetCustomerCommandHandler().RestoreCustomer,
+		erasePersonalData:           diContainer.GetCustomerCommandHandler().ErasePersonalData,
 		customerViewByID:            diContainer.GetCustomerQueryHandler().CustomerViewByID,
+		customerViewByIDAsOf:        diContainer.GetCustomerQueryHandler().CustomerViewByIDAsOf,
+		customerViewByEmailAddress:  diContainer.GetCustomerQueryHandler().CustomerViewByEmailAddress,
+		exportCustomerData:          diContainer.GetCustomerQueryHandler().ExportCustomerData,
+		customerEventHistory:        diContainer.GetCustomerQueryHandler().CustomerEventHistory,
+		searchCustomerViews:         catchUpAndSearchCustomerViews(diContainer),
+		watchCustomer:               diContainer.GetCustomerQueryHandler().WatchCustomer,
+	}
+}
+
+// catchUpAndSearchCustomerViews makes searches consistent with the preceding writes by synchronously catching up the
+// (otherwise eventually consistent) customer view projection before each query.
+func catchUpAndSearchCustomerViews(diContainer *grpc.DIContainer) hexagon.ForSearchingCustomerViews {
+	return func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+		if customerViewProjector := diContainer.GetCustomerViewProjector(); customerViewProjector != nil {
+			if err := customerViewProjector.CatchUp(ctx); err != nil {
+				return customer.ViewPage{}, err
+			}
+		}
+
+		return diContainer.GetCustomerQueryHandler().SearchCustomerViews(ctx, criteria)
+	}
+}
+
+func startWatchingCustomer(
+	ctx context.Context,
+	ac acceptanceTestCollaborators,
+	customerID value.CustomerID,
+	afterVersion uint,
+) (<-chan customer.HistoricEvent, <-chan error) {
+
+	pushed := make(chan customer.HistoricEvent, 10)
+	watchErr := make(chan error, 1)
+
+	go func() {
+		watchErr <- ac.watchCustomer(ctx, customerID.String(), afterVersion, func(event customer.HistoricEvent) error {
+			pushed <- event
+			return nil
+		})
+	}()
+
+	return pushed, watchErr
+}
+
+// receivePushedEvent waits shorter than the poll interval of the watch, so the event must have been notified.
+func receivePushedEvent(pushed <-chan customer.HistoricEvent) customer.HistoricEvent {
+	select {
+	case event := <-pushed:
+		return event
+	case <-time.After(500 * time.Millisecond):
+		return customer.HistoricEvent{}
 	}
 }
 
@@ -837,5 +2627,6 @@ func initAcceptanceTestValues() acceptanceTestValues {
 		fn:                  name.FamilyName(),
 		cgn:                 changedName.GivenName(),
 		cfn:                 changedName.FamilyName(),
+		sr:                  "suspicion of fraud",
 	}
 }
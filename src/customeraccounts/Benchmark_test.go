@@ -1,11 +1,11 @@
 package customeraccounts_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
-	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/postgres"
 	"github.com/AntonStoeckl/go-iddd/src/service/grpc"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 )
@@ -34,11 +34,11 @@ func BenchmarkCustomerCommand(b *testing.B) {
 	b.Run("ChangeName", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			if n%2 == 0 {
-				if err = commandHandler.ChangeCustomerName(v.customerID.String(), v.newGivenName, v.newFamilyName); err != nil {
+				if _, err = commandHandler.ChangeCustomerName(context.Background(), v.customerID.String(), v.newGivenName, v.newFamilyName, 0); err != nil {
 					b.FailNow()
 				}
 			} else {
-				if err = commandHandler.ChangeCustomerName(v.customerID.String(), v.givenName, v.familyName); err != nil {
+				if _, err = commandHandler.ChangeCustomerName(context.Background(), v.customerID.String(), v.givenName, v.familyName, 0); err != nil {
 					b.FailNow()
 				}
 			}
@@ -63,9 +63,13 @@ func BenchmarkCustomerQuery(b *testing.B) {
 	v := initBenchmarkTestValues()
 	prepareForBenchmark(b, commandHandler, &v)
 
+	if err := diContainer.GetCustomerViewProjector().CatchUp(context.Background()); err != nil {
+		b.FailNow()
+	}
+
 	b.Run("CustomerViewByID", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
-			if _, err := queryHandler.CustomerViewByID(v.customerID.String()); err != nil {
+			if _, err := queryHandler.CustomerViewByID(context.Background(), v.customerID.String()); err != nil {
 				b.FailNow()
 			}
 		}
@@ -102,17 +106,17 @@ func prepareForBenchmark(
 
 	v.customerID = value.GenerateCustomerID()
 
-	if err = commandHandler.RegisterCustomer(v.customerID, v.emailAddress, v.givenName, v.familyName); err != nil {
+	if _, err = commandHandler.RegisterCustomer(context.Background(), v.customerID, v.emailAddress, v.givenName, v.familyName); err != nil {
 		b.FailNow()
 	}
 
 	for n := 0; n < 100; n++ {
 		if n%2 == 0 {
-			if err = commandHandler.ChangeCustomerEmailAddress(v.customerID.String(), v.newEmailAddress); err != nil {
+			if _, err = commandHandler.ChangeCustomerEmailAddress(context.Background(), v.customerID.String(), v.newEmailAddress, 0); err != nil {
 				b.FailNow()
 			}
 		} else {
-			if err = commandHandler.ChangeCustomerEmailAddress(v.customerID.String(), v.emailAddress); err != nil {
+			if _, err = commandHandler.ChangeCustomerEmailAddress(context.Background(), v.customerID.String(), v.emailAddress, 0); err != nil {
 				b.FailNow()
 			}
 		}
@@ -121,16 +125,16 @@ func prepareForBenchmark(
 
 func cleanUpAfterBenchmark(
 	b *testing.B,
-	eventstore *postgres.CustomerEventStore,
+	eventstore grpc.CustomerEventStore,
 	commandHandler *application.CustomerCommandHandler,
 	id value.CustomerID,
 ) {
 
-	if err := commandHandler.DeleteCustomer(id.String()); err != nil {
+	if _, err := commandHandler.DeleteCustomer(context.Background(), id.String(), 0); err != nil {
 		b.FailNow()
 	}
 
-	if err := eventstore.PurgeEventStream(id); err != nil {
+	if err := eventstore.PurgeEventStream(context.Background(), id); err != nil {
 		b.FailNow()
 	}
 }
@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForAddingCustomerAddresses func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (newVersion uint, err error)
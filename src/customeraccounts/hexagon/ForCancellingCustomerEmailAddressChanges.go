@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForCancellingCustomerEmailAddressChanges func(ctx context.Context, customerID string, expectedVersion uint) (newVersion uint, err error)
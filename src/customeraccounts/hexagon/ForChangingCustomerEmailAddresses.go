@@ -1,3 +1,5 @@
 package hexagon
 
-type ForChangingCustomerEmailAddresses func(customerID, emailAddress string) error
+import "context"
+
+type ForChangingCustomerEmailAddresses func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (newVersion uint, err error)
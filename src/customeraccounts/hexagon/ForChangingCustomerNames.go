@@ -1,3 +1,5 @@
 package hexagon
 
-type ForChangingCustomerNames func(customerID, givenName, familyName string) error
+import "context"
+
+type ForChangingCustomerNames func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (newVersion uint, err error)
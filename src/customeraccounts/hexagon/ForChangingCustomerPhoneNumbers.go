@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForChangingCustomerPhoneNumbers func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (newVersion uint, err error)
@@ -1,3 +1,5 @@
 package hexagon
 
-type ForConfirmingCustomerEmailAddresses func(customerID, confirmationHash string) error
+import "context"
+
+type ForConfirmingCustomerEmailAddresses func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (newVersion uint, err error)
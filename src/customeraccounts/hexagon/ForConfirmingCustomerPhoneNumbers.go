@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForConfirmingCustomerPhoneNumbers func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (newVersion uint, err error)
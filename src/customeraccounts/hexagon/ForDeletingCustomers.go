@@ -1,3 +1,5 @@
 package hexagon
 
-type ForDeletingCustomers func(customerID string) error
+import "context"
+
+type ForDeletingCustomers func(ctx context.Context, customerID string, expectedVersion uint) (newVersion uint, err error)
@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForErasingCustomerPersonalData func(ctx context.Context, customerID string, expectedVersion uint) (newVersion uint, err error)
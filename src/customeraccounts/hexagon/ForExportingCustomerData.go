@@ -0,0 +1,9 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+type ForExportingCustomerData func(ctx context.Context, customerID string) (customer.Export, error)
@@ -1,5 +1,11 @@
 package hexagon
 
-import "github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+import (
+	"context"
 
-type ForRegisteringCustomers func(customerIDValue value.CustomerID, emailAddress, givenName, familyName string) error
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+// ForRegisteringCustomers returns the ID of the registered Customer. If the same command was already handled with the
+// idempotency key from the ctx, this is the original ID instead of customerIDValue.
+type ForRegisteringCustomers func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error)
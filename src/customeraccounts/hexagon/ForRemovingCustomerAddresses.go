@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForRemovingCustomerAddresses func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (newVersion uint, err error)
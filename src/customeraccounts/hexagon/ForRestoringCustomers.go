@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForRestoringCustomers func(ctx context.Context, customerID string, expectedVersion uint) (newVersion uint, err error)
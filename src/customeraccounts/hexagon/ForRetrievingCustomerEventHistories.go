@@ -0,0 +1,14 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+type ForRetrievingCustomerEventHistories func(
+	ctx context.Context,
+	customerID string,
+	fromVersion uint,
+	maxEvents uint,
+) (customer.EventHistoryPage, error)
@@ -0,0 +1,14 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+type ForRetrievingCustomerViewsAsOf func(
+	ctx context.Context,
+	customerID string,
+	asOfVersion uint,
+	asOfTime string,
+) (customer.View, error)
@@ -0,0 +1,9 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+type ForRetrievingCustomerViewsByEmailAddress func(ctx context.Context, emailAddress string) (customer.View, error)
@@ -0,0 +1,9 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+type ForSearchingCustomerViews func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error)
@@ -0,0 +1,5 @@
+package hexagon
+
+import "context"
+
+type ForSuspendingCustomers func(ctx context.Context, customerID string, reason string, expectedVersion uint) (newVersion uint, err error)
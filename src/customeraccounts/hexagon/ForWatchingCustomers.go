@@ -0,0 +1,15 @@
+package hexagon
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+// ForWatchingCustomers pushes the events of a Customer after afterVersion until the ctx is done or pushing fails.
+type ForWatchingCustomers func(
+	ctx context.Context,
+	customerID string,
+	afterVersion uint,
+	push func(event customer.HistoricEvent) error,
+) error
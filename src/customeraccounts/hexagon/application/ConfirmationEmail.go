@@ -0,0 +1,17 @@
+package application
+
+const (
+	ConfirmationEmailForRegistration       = "registration"
+	ConfirmationEmailForEmailAddressChange = "email-address-change"
+	ConfirmationEmailForResend             = "resend"
+)
+
+// ConfirmationEmail contains everything the adapters need to render the email a Customer confirms her email address with.
+type ConfirmationEmail struct {
+	Reason           string
+	CustomerID       string
+	EmailAddress     string
+	ConfirmationHash string
+	GivenName        string
+	FamilyName       string
+}
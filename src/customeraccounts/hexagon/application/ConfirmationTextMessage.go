@@ -0,0 +1,8 @@
+package application
+
+// ConfirmationTextMessage contains everything the adapters need to send the text message a Customer confirms her phone number with.
+type ConfirmationTextMessage struct {
+	CustomerID       string
+	PhoneNumber      string
+	ConfirmationCode string
+}
@@ -1,51 +1,80 @@
 package application
 
 import (
+	"context"
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 	"github.com/cockroachdb/errors"
 )
 
 const maxCustomerCommandHandlerRetries = uint8(10)
 
 type CustomerCommandHandler struct {
-	retrieveCustomerEventStream ForRetrievingCustomerEventStreams
-	startCustomerEventStream    ForStartingCustomerEventStreams
-	appendToCustomerEventStream ForAppendingToCustomerEventStreams
+	retrieveCustomerEventStream         ForRetrievingCustomerEventStreams
+	startCustomerEventStream            ForStartingCustomerEventStreams
+	appendToCustomerEventStream         ForAppendingToCustomerEventStreams
+	retrieveCustomerIDForIdempotencyKey ForRetrievingCustomerIDsForIdempotencyKeys
+	deleteCustomerPersonalDataKey       ForDeletingCustomerPersonalDataKeys
+	confirmationHashTTL                 time.Duration
+	confirmationResendInterval          time.Duration
+	confirmationMaxFailedAttempts       uint
+	confirmationLockoutDuration         time.Duration
+	phoneConfirmationCodeTTL            time.Duration
+	restoreGracePeriod                  time.Duration
 }
 
 func NewCustomerCommandHandler(
 	retrieveCustomerEventStream ForRetrievingCustomerEventStreams,
 	startCustomerEventStream ForStartingCustomerEventStreams,
 	appendToCustomerEventStream ForAppendingToCustomerEventStreams,
+	retrieveCustomerIDForIdempotencyKey ForRetrievingCustomerIDsForIdempotencyKeys,
+	deleteCustomerPersonalDataKey ForDeletingCustomerPersonalDataKeys,
+	confirmationHashTTL time.Duration,
+	confirmationResendInterval time.Duration,
+	confirmationMaxFailedAttempts uint,
+	confirmationLockoutDuration time.Duration,
+	phoneConfirmationCodeTTL time.Duration,
+	restoreGracePeriod time.Duration,
 ) *CustomerCommandHandler {
 
 	return &CustomerCommandHandler{
-		retrieveCustomerEventStream: retrieveCustomerEventStream,
-		startCustomerEventStream:    startCustomerEventStream,
-		appendToCustomerEventStream: appendToCustomerEventStream,
+		retrieveCustomerEventStream:         retrieveCustomerEventStream,
+		startCustomerEventStream:            startCustomerEventStream,
+		appendToCustomerEventStream:         appendToCustomerEventStream,
+		retrieveCustomerIDForIdempotencyKey: retrieveCustomerIDForIdempotencyKey,
+		deleteCustomerPersonalDataKey:       deleteCustomerPersonalDataKey,
+		confirmationHashTTL:                 confirmationHashTTL,
+		confirmationResendInterval:          confirmationResendInterval,
+		confirmationMaxFailedAttempts:       confirmationMaxFailedAttempts,
+		confirmationLockoutDuration:         confirmationLockoutDuration,
+		phoneConfirmationCodeTTL:            phoneConfirmationCodeTTL,
+		restoreGracePeriod:                  restoreGracePeriod,
 	}
 }
 
 func (h *CustomerCommandHandler) RegisterCustomer(
+	ctx context.Context,
 	customerIDValue value.CustomerID,
 	emailAddress string,
 	givenName string,
 	familyName string,
-) error {
+) (value.CustomerID, error) {
 
 	wrapWithMsg := "CustomerCommandHandler.RegisterCustomer"
 
 	emailAddressValue, err := value.BuildUnconfirmedEmailAddress(emailAddress)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return "", errors.Wrap(err, wrapWithMsg)
 	}
 
 	personNameValue, err := value.BuildPersonName(givenName, familyName)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return "", errors.Wrap(err, wrapWithMsg)
 	}
 
 	command := domain.BuildRegisterCustomer(
@@ -54,100 +83,201 @@ func (h *CustomerCommandHandler) RegisterCustomer(
 		personNameValue,
 	)
 
+	registeredCustomerID := command.CustomerID()
+
 	doRegister := func() error {
+		handledForCustomerID, isHandled, err := h.customerIDOfHandledCommand(ctx)
+		if err != nil {
+			return err
+		}
+
+		if isHandled {
+			registeredCustomerID = handledForCustomerID
+
+			return nil
+		}
+
 		customerRegistered := customer.Register(command)
 
-		if err := h.startCustomerEventStream(customerRegistered); err != nil {
+		if err := h.startCustomerEventStream(ctx, customerRegistered); err != nil {
 			return err
 		}
 
 		return nil
 	}
 
-	if err := shared.RetryOnConcurrencyConflict(doRegister, maxCustomerCommandHandlerRetries); err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+	if err := shared.RetryOnConcurrencyConflict(ctx, doRegister, maxCustomerCommandHandlerRetries); err != nil {
+		return "", errors.Wrap(err, wrapWithMsg)
 	}
 
-	return nil
+	return registeredCustomerID, nil
 }
 
 func (h *CustomerCommandHandler) ConfirmCustomerEmailAddress(
+	ctx context.Context,
 	customerID string,
 	confirmationHash string,
-) error {
+	expectedVersion uint,
+) (uint, error) {
 
 	wrapWithMsg := "CustomerCommandHandler.ConfirmCustomerEmailAddress"
 
 	customerIDValue, err := value.BuildCustomerID(customerID)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	confirmationHashValue, err := value.BuildConfirmationHash(confirmationHash)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	command := domain.BuildConfirmCustomerEmailAddress(
 		customerIDValue,
 		confirmationHashValue,
+		h.confirmationHashTTL,
+		h.confirmationMaxFailedAttempts,
+		h.confirmationLockoutDuration,
+		expectedVersion,
 	)
 
+	var newVersion uint
+
 	doConfirmEmailAddress := func() error {
-		eventStream, err := h.retrieveCustomerEventStream(command.CustomerID())
-		if err != nil {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
 			return err
 		}
 
-		recordedEvents, err := customer.ConfirmEmailAddress(eventStream, command)
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
 		if err != nil {
 			return err
 		}
 
-		if err := h.appendToCustomerEventStream(recordedEvents, command.CustomerID()); err != nil {
+		recordedEvents, err := customer.ConfirmEmailAddress(eventStream, command)
+		if err != nil {
 			return err
 		}
 
+		var failureReason error
+
 		for _, event := range recordedEvents {
 			if isError := event.IsFailureEvent(); isError {
-				return event.FailureReason()
+				failureReason = event.FailureReason()
 			}
 		}
 
+		appendCtx := ctx
+		if failureReason != nil {
+			appendCtx = es.ContextWithoutIdempotencyKey(ctx) // a retry must fail again instead of replaying a success
+		}
+
+		if err := h.appendToCustomerEventStream(appendCtx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return failureReason
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doConfirmEmailAddress, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) RequestCustomerEmailAddressConfirmationResend(
+	ctx context.Context,
+	customerID string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.RequestCustomerEmailAddressConfirmationResend"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildRequestCustomerEmailAddressConfirmationResend(
+		customerIDValue,
+		h.confirmationResendInterval,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doRequestConfirmationResend := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.RequestEmailAddressConfirmationResend(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
 		return nil
 	}
 
-	if err := shared.RetryOnConcurrencyConflict(doConfirmEmailAddress, maxCustomerCommandHandlerRetries); err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+	if err := shared.RetryOnConcurrencyConflict(ctx, doRequestConfirmationResend, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
 func (h *CustomerCommandHandler) ChangeCustomerEmailAddress(
+	ctx context.Context,
 	customerID string,
 	emailAddress string,
-) error {
+	expectedVersion uint,
+) (uint, error) {
 
 	wrapWithMsg := "CustomerCommandHandler.ChangeCustomerEmailAddress"
 
 	customerIDValue, err := value.BuildCustomerID(customerID)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	emailAddressValue, err := value.BuildUnconfirmedEmailAddress(emailAddress)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	command := domain.BuildChangeCustomerEmailAddress(
 		customerIDValue,
 		emailAddressValue,
+		expectedVersion,
 	)
 
+	var newVersion uint
+
 	doChangeEmailAddress := func() error {
-		eventStream, err := h.retrieveCustomerEventStream(command.CustomerID())
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
 		if err != nil {
 			return err
 		}
@@ -157,45 +287,111 @@ func (h *CustomerCommandHandler) ChangeCustomerEmailAddress(
 			return err
 		}
 
-		if err := h.appendToCustomerEventStream(recordedEvents, command.CustomerID()); err != nil {
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doChangeEmailAddress, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) CancelCustomerEmailAddressChange(
+	ctx context.Context,
+	customerID string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.CancelCustomerEmailAddressChange"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildCancelCustomerEmailAddressChange(
+		customerIDValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doCancelEmailAddressChange := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.CancelEmailAddressChange(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
 			return err
 		}
 
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
 		return nil
 	}
 
-	if err := shared.RetryOnConcurrencyConflict(doChangeEmailAddress, maxCustomerCommandHandlerRetries); err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+	if err := shared.RetryOnConcurrencyConflict(ctx, doCancelEmailAddressChange, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
 func (h *CustomerCommandHandler) ChangeCustomerName(
+	ctx context.Context,
 	customerID string,
 	givenName string,
 	familyName string,
-) error {
+	expectedVersion uint,
+) (uint, error) {
 
 	wrapWithMsg := "CustomerCommandHandler.ChangeCustomerName"
 
 	customerIDValue, err := value.BuildCustomerID(customerID)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	personNameValue, err := value.BuildPersonName(givenName, familyName)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
 	command := domain.BuildChangeCustomerName(
 		customerIDValue,
 		personNameValue,
+		expectedVersion,
 	)
 
+	var newVersion uint
+
 	doChangeName := func() error {
-		eventStream, err := h.retrieveCustomerEventStream(command.CustomerID())
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
 		if err != nil {
 			return err
 		}
@@ -205,48 +401,659 @@ func (h *CustomerCommandHandler) ChangeCustomerName(
 			return err
 		}
 
-		if err := h.appendToCustomerEventStream(recordedEvents, command.CustomerID()); err != nil {
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doChangeName, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) AddCustomerAddress(
+	ctx context.Context,
+	customerID string,
+	addressKind string,
+	street string,
+	postalCode string,
+	city string,
+	countryCode string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.AddCustomerAddress"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	addressKindValue, err := value.BuildAddressKind(addressKind)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	addressValue, err := value.BuildAddress(street, postalCode, city, countryCode)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildAddCustomerAddress(
+		customerIDValue,
+		addressKindValue,
+		addressValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doAddAddress := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.AddAddress(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doAddAddress, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) ChangeCustomerAddress(
+	ctx context.Context,
+	customerID string,
+	addressKind string,
+	street string,
+	postalCode string,
+	city string,
+	countryCode string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.ChangeCustomerAddress"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	addressKindValue, err := value.BuildAddressKind(addressKind)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	addressValue, err := value.BuildAddress(street, postalCode, city, countryCode)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildChangeCustomerAddress(
+		customerIDValue,
+		addressKindValue,
+		addressValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doChangeAddress := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.ChangeAddress(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doChangeAddress, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) RemoveCustomerAddress(
+	ctx context.Context,
+	customerID string,
+	addressKind string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.RemoveCustomerAddress"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	addressKindValue, err := value.BuildAddressKind(addressKind)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildRemoveCustomerAddress(
+		customerIDValue,
+		addressKindValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doRemoveAddress := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.RemoveAddress(eventStream, command)
+		if err != nil {
 			return err
 		}
 
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
 		return nil
 	}
 
-	if err := shared.RetryOnConcurrencyConflict(doChangeName, maxCustomerCommandHandlerRetries); err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+	if err := shared.RetryOnConcurrencyConflict(ctx, doRemoveAddress, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
-func (h *CustomerCommandHandler) DeleteCustomer(customerID string) error {
+func (h *CustomerCommandHandler) ChangeCustomerPhoneNumber(
+	ctx context.Context,
+	customerID string,
+	phoneNumber string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.ChangeCustomerPhoneNumber"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	phoneNumberValue, err := value.BuildUnconfirmedPhoneNumber(phoneNumber)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildChangeCustomerPhoneNumber(
+		customerIDValue,
+		phoneNumberValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doChangePhoneNumber := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.ChangePhoneNumber(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doChangePhoneNumber, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) ConfirmCustomerPhoneNumber(
+	ctx context.Context,
+	customerID string,
+	confirmationCode string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.ConfirmCustomerPhoneNumber"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	confirmationCodeValue, err := value.BuildConfirmationCode(confirmationCode)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildConfirmCustomerPhoneNumber(
+		customerIDValue,
+		confirmationCodeValue,
+		h.phoneConfirmationCodeTTL,
+		h.confirmationMaxFailedAttempts,
+		h.confirmationLockoutDuration,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doConfirmPhoneNumber := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.ConfirmPhoneNumber(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		var failureReason error
+
+		for _, event := range recordedEvents {
+			if isError := event.IsFailureEvent(); isError {
+				failureReason = event.FailureReason()
+			}
+		}
+
+		appendCtx := ctx
+		if failureReason != nil {
+			appendCtx = es.ContextWithoutIdempotencyKey(ctx) // a retry must fail again instead of replaying a success
+		}
+
+		if err := h.appendToCustomerEventStream(appendCtx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return failureReason
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doConfirmPhoneNumber, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) SuspendCustomer(
+	ctx context.Context,
+	customerID string,
+	reason string,
+	expectedVersion uint,
+) (uint, error) {
+
+	wrapWithMsg := "CustomerCommandHandler.SuspendCustomer"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	reasonValue, err := value.BuildSuspensionReason(reason)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildSuspendCustomer(
+		customerIDValue,
+		reasonValue,
+		expectedVersion,
+	)
+
+	var newVersion uint
+
+	doSuspend := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.Suspend(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doSuspend, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) ReactivateCustomer(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+	wrapWithMsg := "CustomerCommandHandler.ReactivateCustomer"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildReactivateCustomer(customerIDValue, expectedVersion)
+
+	var newVersion uint
+
+	doReactivate := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.Reactivate(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doReactivate, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) DeleteCustomer(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
 	wrapWithMsg := "customerCommandHandler.DeleteCustomer"
 
 	customerIDValue, err := value.BuildCustomerID(customerID)
 	if err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+		return 0, errors.Wrap(err, wrapWithMsg)
 	}
 
-	command := domain.BuildDeleteCustomer(customerIDValue)
+	command := domain.BuildDeleteCustomer(customerIDValue, expectedVersion)
+
+	var newVersion uint
 
 	doDelete := func() error {
-		eventStream, err := h.retrieveCustomerEventStream(command.CustomerID())
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.Delete(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doDelete, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+// RestoreCustomer undoes the deletion of a Customer, as long as the restore grace period is not over.
+func (h *CustomerCommandHandler) RestoreCustomer(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+	wrapWithMsg := "CustomerCommandHandler.RestoreCustomer"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildRestoreCustomer(customerIDValue, h.restoreGracePeriod, expectedVersion)
+
+	var newVersion uint
+
+	doRestore := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
 		if err != nil {
 			return err
 		}
 
-		recordedEvents := customer.Delete(eventStream, command)
+		recordedEvents, err := customer.Restore(eventStream, command)
+		if err != nil {
+			return err
+		}
 
-		if err := h.appendToCustomerEventStream(recordedEvents, command.CustomerID()); err != nil {
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
 			return err
 		}
 
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
 		return nil
 	}
 
-	if err := shared.RetryOnConcurrencyConflict(doDelete, maxCustomerCommandHandlerRetries); err != nil {
-		return errors.Wrap(err, wrapWithMsg)
+	if err := shared.RetryOnConcurrencyConflict(ctx, doRestore, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+func (h *CustomerCommandHandler) ErasePersonalData(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+	wrapWithMsg := "CustomerCommandHandler.ErasePersonalData"
+
+	customerIDValue, err := value.BuildCustomerID(customerID)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	command := domain.BuildEraseCustomerPersonalData(customerIDValue, expectedVersion)
+
+	var newVersion uint
+
+	doErase := func() error {
+		if handledVersion, isHandled, err := h.versionOfHandledCommandFor(ctx, command.CustomerID()); err != nil || isHandled {
+			newVersion = handledVersion
+
+			return err
+		}
+
+		eventStream, err := h.retrieveCustomerEventStream(ctx, command.CustomerID())
+		if err != nil {
+			return err
+		}
+
+		recordedEvents, err := customer.ErasePersonalData(eventStream, command)
+		if err != nil {
+			return err
+		}
+
+		// before the erasure is appended, so that nobody who reacts to it can still decrypt the personal data,
+		// and also if the personal data was already erased, so that a retry succeeds if deleting the key failed before
+		if err := h.deleteCustomerPersonalDataKey(ctx, command.CustomerID()); err != nil {
+			return err
+		}
+
+		if err := h.appendToCustomerEventStream(ctx, recordedEvents, command.CustomerID()); err != nil {
+			return err
+		}
+
+		newVersion = streamVersionAfter(eventStream, recordedEvents)
+
+		return nil
+	}
+
+	if err := shared.RetryOnConcurrencyConflict(ctx, doErase, maxCustomerCommandHandlerRetries); err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return newVersion, nil
+}
+
+// customerIDOfHandledCommand returns the ID of the Customer for which a command with the same idempotency key
+// from the ctx was already handled successfully.
+func (h *CustomerCommandHandler) customerIDOfHandledCommand(ctx context.Context) (value.CustomerID, bool, error) {
+	idempotencyKey, ok := es.IdempotencyKeyFrom(ctx)
+	if !ok {
+		return "", false, nil
+	}
+
+	customerID, err := h.retrieveCustomerIDForIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return customerID, true, nil
+}
+
+// versionOfHandledCommandFor returns the current version of the Customer if a command with the same idempotency key
+// from the ctx was already handled for her, because the version which that command resulted in is not stored.
+func (h *CustomerCommandHandler) versionOfHandledCommandFor(
+	ctx context.Context,
+	customerID value.CustomerID,
+) (uint, bool, error) {
+
+	handledForCustomerID, isHandled, err := h.customerIDOfHandledCommand(ctx)
+	if err != nil || !isHandled {
+		return 0, false, err
+	}
+
+	if !handledForCustomerID.Equals(customerID) {
+		err := errors.New("idempotency key was already used for another customer")
+		return 0, false, errors.Mark(err, shared.ErrInputIsInvalid)
+	}
+
+	eventStream, err := h.retrieveCustomerEventStream(ctx, customerID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return streamVersionAfter(eventStream, nil), true, nil
+}
+
+// streamVersionAfter returns the version of the Customer once the recordedEvents are appended to her eventStream.
+func streamVersionAfter(eventStream es.EventStream, recordedEvents es.RecordedEvents) uint {
+	if len(recordedEvents) > 0 {
+		return recordedEvents[len(recordedEvents)-1].Meta().StreamVersion()
+	}
+
+	if len(eventStream) > 0 {
+		return eventStream[len(eventStream)-1].Meta().StreamVersion()
 	}
 
-	return nil
+	return 0
 }
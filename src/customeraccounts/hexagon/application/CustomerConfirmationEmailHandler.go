@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// CustomerConfirmationEmailHandler sends the confirmation hash to the Customer whenever she has a new
+// unconfirmed email address. Sending is retried with an exponential backoff, so that short outages of the
+// mail server don't hold up the caller for too long; after maxAttempts the error is returned to the caller.
+// If the email can never be sent, e.g. because the mail server rejects the address, it is not retried at all.
+type CustomerConfirmationEmailHandler struct {
+	sendConfirmationEmail ForSendingConfirmationEmails
+	maxAttempts           uint8
+	retryBackoff          time.Duration
+}
+
+func NewCustomerConfirmationEmailHandler(
+	sendConfirmationEmail ForSendingConfirmationEmails,
+	maxAttempts uint8,
+	retryBackoff time.Duration,
+) *CustomerConfirmationEmailHandler {
+
+	return &CustomerConfirmationEmailHandler{
+		sendConfirmationEmail: sendConfirmationEmail,
+		maxAttempts:           maxAttempts,
+		retryBackoff:          retryBackoff,
+	}
+}
+
+// HandleEvent ignores all events which don't require a confirmation of an email address.
+func (h *CustomerConfirmationEmailHandler) HandleEvent(ctx context.Context, event es.DomainEvent) error {
+	var confirmationEmail ConfirmationEmail
+
+	switch actualEvent := event.(type) {
+	case domain.CustomerRegistered:
+		confirmationEmail = ConfirmationEmail{
+			Reason:           ConfirmationEmailForRegistration,
+			CustomerID:       actualEvent.CustomerID().String(),
+			EmailAddress:     actualEvent.EmailAddress().String(),
+			ConfirmationHash: actualEvent.EmailAddress().ConfirmationHash().String(),
+			GivenName:        actualEvent.PersonName().GivenName(),
+			FamilyName:       actualEvent.PersonName().FamilyName(),
+		}
+	case domain.CustomerEmailAddressChanged:
+		confirmationEmail = ConfirmationEmail{
+			Reason:           ConfirmationEmailForEmailAddressChange,
+			CustomerID:       actualEvent.CustomerID().String(),
+			EmailAddress:     actualEvent.EmailAddress().String(),
+			ConfirmationHash: actualEvent.EmailAddress().ConfirmationHash().String(),
+		}
+	case domain.CustomerEmailAddressChangeRequested:
+		confirmationEmail = ConfirmationEmail{
+			Reason:           ConfirmationEmailForEmailAddressChange,
+			CustomerID:       actualEvent.CustomerID().String(),
+			EmailAddress:     actualEvent.EmailAddress().String(),
+			ConfirmationHash: actualEvent.EmailAddress().ConfirmationHash().String(),
+		}
+	case domain.CustomerEmailAddressConfirmationResendRequested:
+		confirmationEmail = ConfirmationEmail{
+			Reason:           ConfirmationEmailForResend,
+			CustomerID:       actualEvent.CustomerID().String(),
+			EmailAddress:     actualEvent.EmailAddress().String(),
+			ConfirmationHash: actualEvent.EmailAddress().ConfirmationHash().String(),
+		}
+	default:
+		return nil
+	}
+
+	if err := h.send(ctx, confirmationEmail); err != nil {
+		return errors.Wrap(err, "customerConfirmationEmailHandler.HandleEvent")
+	}
+
+	return nil
+}
+
+func (h *CustomerConfirmationEmailHandler) send(ctx context.Context, confirmationEmail ConfirmationEmail) error {
+	sendConfirmationEmail := func() error {
+		return h.sendConfirmationEmail(ctx, confirmationEmail)
+	}
+
+	return retryWithBackoff(ctx, sendConfirmationEmail, h.maxAttempts, h.retryBackoff)
+}
@@ -0,0 +1,148 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCustomerConfirmationEmailHandler(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+
+		var sentEmails []application.ConfirmationEmail
+		var failures, attempts int
+		var isRejected bool
+
+		sendConfirmationEmail := func(ctx context.Context, confirmationEmail application.ConfirmationEmail) error {
+			attempts++
+
+			if isRejected {
+				return errors.Mark(errors.New("mailbox does not exist"), shared.ErrInputIsInvalid)
+			}
+
+			if failures > 0 {
+				failures--
+				return errors.New("mail server unavailable")
+			}
+
+			sentEmails = append(sentEmails, confirmationEmail)
+
+			return nil
+		}
+
+		handler := application.NewCustomerConfirmationEmailHandler(sendConfirmationEmail, 3, time.Millisecond)
+
+		Convey("When a CustomerRegistered event is handled", func() {
+			err = handler.HandleEvent(ctx, domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1))
+
+			Convey("Then a confirmation email for the registration should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentEmails, ShouldHaveLength, 1)
+				So(sentEmails[0].Reason, ShouldEqual, application.ConfirmationEmailForRegistration)
+				So(sentEmails[0].EmailAddress, ShouldEqual, emailAddress.String())
+				So(sentEmails[0].ConfirmationHash, ShouldEqual, emailAddress.ConfirmationHash().String())
+				So(sentEmails[0].GivenName, ShouldEqual, personName.GivenName())
+			})
+		})
+
+		Convey("When a CustomerEmailAddressChanged event is handled", func() {
+			err = handler.HandleEvent(ctx, domain.BuildCustomerEmailAddressChanged(customerID, emailAddress, es.GenerateMessageID(), 2))
+
+			Convey("Then a confirmation email for the changed email address should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentEmails, ShouldHaveLength, 1)
+				So(sentEmails[0].Reason, ShouldEqual, application.ConfirmationEmailForEmailAddressChange)
+			})
+		})
+
+		Convey("When a CustomerEmailAddressChangeRequested event is handled", func() {
+			err = handler.HandleEvent(ctx, domain.BuildCustomerEmailAddressChangeRequested(customerID, emailAddress, es.GenerateMessageID(), 3))
+
+			Convey("Then a confirmation email for the pending email address should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentEmails, ShouldHaveLength, 1)
+				So(sentEmails[0].Reason, ShouldEqual, application.ConfirmationEmailForEmailAddressChange)
+				So(sentEmails[0].EmailAddress, ShouldEqual, emailAddress.String())
+			})
+		})
+
+		Convey("When a CustomerEmailAddressConfirmationResendRequested event is handled", func() {
+			resentEmailAddress := emailAddress.WithRegeneratedConfirmationHash()
+			err = handler.HandleEvent(
+				ctx,
+				domain.BuildCustomerEmailAddressConfirmationResendRequested(customerID, resentEmailAddress, es.GenerateMessageID(), 2),
+			)
+
+			Convey("Then a confirmation email with the new confirmation hash should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentEmails, ShouldHaveLength, 1)
+				So(sentEmails[0].Reason, ShouldEqual, application.ConfirmationEmailForResend)
+				So(sentEmails[0].ConfirmationHash, ShouldEqual, resentEmailAddress.ConfirmationHash().String())
+			})
+		})
+
+		Convey("When another event is handled", func() {
+			err = handler.HandleEvent(ctx, domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 2))
+
+			Convey("Then no email should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentEmails, ShouldBeEmpty)
+			})
+		})
+
+		Convey("Given sending fails twice", func() {
+			failures = 2
+
+			Convey("When a CustomerRegistered event is handled", func() {
+				err = handler.HandleEvent(ctx, domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1))
+
+				Convey("Then the email should be sent with the third attempt", func() {
+					So(err, ShouldBeNil)
+					So(sentEmails, ShouldHaveLength, 1)
+				})
+			})
+		})
+
+		Convey("Given sending always fails", func() {
+			failures = 10
+
+			Convey("When a CustomerRegistered event is handled", func() {
+				err = handler.HandleEvent(ctx, domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1))
+
+				Convey("Then it should fail after the max attempts", func() {
+					So(err, ShouldBeError)
+					So(err.Error(), ShouldContainSubstring, shared.ErrMaxRetriesExceeded.Error())
+					So(failures, ShouldEqual, 7)
+				})
+			})
+		})
+
+		Convey("Given the mail server rejects the email address", func() {
+			isRejected = true
+
+			Convey("When a CustomerRegistered event is handled", func() {
+				err = handler.HandleEvent(ctx, domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1))
+
+				Convey("Then it should fail without retrying", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+					So(attempts, ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// CustomerConfirmationTextMessageHandler sends the confirmation code to the Customer whenever she has a new
+// unconfirmed phone number. Sending is retried the same way as in CustomerConfirmationEmailHandler.
+type CustomerConfirmationTextMessageHandler struct {
+	sendConfirmationTextMessage ForSendingConfirmationTextMessages
+	maxAttempts                 uint8
+	retryBackoff                time.Duration
+}
+
+func NewCustomerConfirmationTextMessageHandler(
+	sendConfirmationTextMessage ForSendingConfirmationTextMessages,
+	maxAttempts uint8,
+	retryBackoff time.Duration,
+) *CustomerConfirmationTextMessageHandler {
+
+	return &CustomerConfirmationTextMessageHandler{
+		sendConfirmationTextMessage: sendConfirmationTextMessage,
+		maxAttempts:                 maxAttempts,
+		retryBackoff:                retryBackoff,
+	}
+}
+
+// HandleEvent ignores all events which don't require a confirmation of a phone number.
+func (h *CustomerConfirmationTextMessageHandler) HandleEvent(ctx context.Context, event es.DomainEvent) error {
+	actualEvent, ok := event.(domain.CustomerPhoneNumberChanged)
+	if !ok {
+		return nil
+	}
+
+	confirmationTextMessage := ConfirmationTextMessage{
+		CustomerID:       actualEvent.CustomerID().String(),
+		PhoneNumber:      actualEvent.PhoneNumber().String(),
+		ConfirmationCode: actualEvent.PhoneNumber().ConfirmationCode().String(),
+	}
+
+	sendConfirmationTextMessage := func() error {
+		return h.sendConfirmationTextMessage(ctx, confirmationTextMessage)
+	}
+
+	if err := retryWithBackoff(ctx, sendConfirmationTextMessage, h.maxAttempts, h.retryBackoff); err != nil {
+		return errors.Wrap(err, "customerConfirmationTextMessageHandler.HandleEvent")
+	}
+
+	return nil
+}
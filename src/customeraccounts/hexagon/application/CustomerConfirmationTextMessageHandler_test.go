@@ -0,0 +1,76 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCustomerConfirmationTextMessageHandler(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		customerID := value.GenerateCustomerID()
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("+49 30 12345678")
+		So(err, ShouldBeNil)
+
+		var sentTextMessages []application.ConfirmationTextMessage
+		var failures int
+
+		sendConfirmationTextMessage := func(ctx context.Context, confirmationTextMessage application.ConfirmationTextMessage) error {
+			if failures > 0 {
+				failures--
+				return errors.New("text message gateway unavailable")
+			}
+
+			sentTextMessages = append(sentTextMessages, confirmationTextMessage)
+
+			return nil
+		}
+
+		handler := application.NewCustomerConfirmationTextMessageHandler(sendConfirmationTextMessage, 3, time.Millisecond)
+		phoneNumberChanged := domain.BuildCustomerPhoneNumberChanged(customerID, phoneNumber, es.GenerateMessageID(), 2)
+
+		Convey("When a CustomerPhoneNumberChanged event is handled", func() {
+			err = handler.HandleEvent(ctx, phoneNumberChanged)
+
+			Convey("Then a confirmation text message should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentTextMessages, ShouldHaveLength, 1)
+				So(sentTextMessages[0].CustomerID, ShouldEqual, customerID.String())
+				So(sentTextMessages[0].PhoneNumber, ShouldEqual, phoneNumber.String())
+				So(sentTextMessages[0].ConfirmationCode, ShouldEqual, phoneNumber.ConfirmationCode().String())
+			})
+		})
+
+		Convey("When another event is handled", func() {
+			err = handler.HandleEvent(ctx, domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 2))
+
+			Convey("Then no text message should be sent", func() {
+				So(err, ShouldBeNil)
+				So(sentTextMessages, ShouldBeEmpty)
+			})
+		})
+
+		Convey("Given sending always fails", func() {
+			failures = 10
+
+			Convey("When a CustomerPhoneNumberChanged event is handled", func() {
+				err = handler.HandleEvent(ctx, phoneNumberChanged)
+
+				Convey("Then it should fail after the max attempts", func() {
+					So(err, ShouldBeError)
+					So(err.Error(), ShouldContainSubstring, shared.ErrMaxRetriesExceeded.Error())
+					So(failures, ShouldEqual, 7)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CustomerPurger hard-purges the EventStreams of Customers which were deleted longer ago than the grace period,
+// within which they could still be restored. It's meant to be run periodically by a background worker.
+type CustomerPurger struct {
+	retrieveCustomerIDsDeletedBefore ForRetrievingCustomerIDsDeletedBefore
+	purgeCustomerEventStream         ForPurgingCustomerEventStreams
+	gracePeriod                      time.Duration
+}
+
+func NewCustomerPurger(
+	retrieveCustomerIDsDeletedBefore ForRetrievingCustomerIDsDeletedBefore,
+	purgeCustomerEventStream ForPurgingCustomerEventStreams,
+	gracePeriod time.Duration,
+) *CustomerPurger {
+
+	return &CustomerPurger{
+		retrieveCustomerIDsDeletedBefore: retrieveCustomerIDsDeletedBefore,
+		purgeCustomerEventStream:         purgeCustomerEventStream,
+		gracePeriod:                      gracePeriod,
+	}
+}
+
+// PurgeDeletedCustomers stops at the first failure, the remaining Customers are purged with the next run.
+func (p *CustomerPurger) PurgeDeletedCustomers(ctx context.Context) error {
+	wrapWithMsg := "CustomerPurger.PurgeDeletedCustomers"
+
+	customerIDs, err := p.retrieveCustomerIDsDeletedBefore(ctx, time.Now().Add(-p.gracePeriod))
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	for _, customerID := range customerIDs {
+		if err := p.purgeCustomerEventStream(ctx, customerID); err != nil {
+			return errors.Wrapf(err, "%s: customer [%s]", wrapWithMsg, customerID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCustomerPurger(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		gracePeriod := 24 * time.Hour
+		deletedCustomerIDs := []value.CustomerID{value.GenerateCustomerID(), value.GenerateCustomerID()}
+
+		var receivedDeletedBefore time.Time
+		var purgedCustomerIDs []value.CustomerID
+		var failures int
+
+		retrieveCustomerIDsDeletedBefore := func(ctx context.Context, deletedBefore time.Time) ([]value.CustomerID, error) {
+			receivedDeletedBefore = deletedBefore
+
+			return deletedCustomerIDs, nil
+		}
+
+		purgeCustomerEventStream := func(ctx context.Context, id value.CustomerID) error {
+			if failures > 0 {
+				failures--
+				return errors.Mark(errors.New("database unavailable"), shared.ErrTechnical)
+			}
+
+			purgedCustomerIDs = append(purgedCustomerIDs, id)
+
+			return nil
+		}
+
+		purger := application.NewCustomerPurger(retrieveCustomerIDsDeletedBefore, purgeCustomerEventStream, gracePeriod)
+
+		Convey("When the deleted Customers are purged", func() {
+			err := purger.PurgeDeletedCustomers(ctx)
+
+			Convey("Then it should only ask for the Customers deleted before the grace period", func() {
+				So(err, ShouldBeNil)
+				So(receivedDeletedBefore, ShouldHappenWithin, time.Second, time.Now().Add(-gracePeriod))
+			})
+
+			Convey("Then it should purge their EventStreams", func() {
+				So(purgedCustomerIDs, ShouldResemble, deletedCustomerIDs)
+			})
+		})
+
+		Convey("When purging an EventStream fails", func() {
+			failures = 1
+			err := purger.PurgeDeletedCustomers(ctx)
+
+			Convey("Then it should report the error and leave the remaining Customers for the next run", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrTechnical), ShouldBeTrue)
+				So(purgedCustomerIDs, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package application
 
 import (
+	"context"
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
@@ -8,16 +11,43 @@ import (
 )
 
 type CustomerQueryHandler struct {
-	retrieveCustomerEventStream ForRetrievingCustomerEventStreams
+	retrieveProjectedCustomerView    ForRetrievingProjectedCustomerViews
+	searchProjectedCustomerViews     ForSearchingProjectedCustomerViews
+	retrieveCustomerEventStream      ForRetrievingCustomerEventStreams
+	retrieveFullCustomerEventStream  ForRetrievingFullCustomerEventStreams
+	retrieveCustomerEventStreamRange ForRetrievingCustomerEventStreamRanges
+	retrieveUniqueEmailAddresses     ForRetrievingUniqueCustomerEmailAddresses
+	retrieveCustomerIDForEmail       ForRetrievingCustomerIDsForEmailAddresses
+	watchCustomerEventStream         ForWatchingCustomerEventStreams
+	watchPollInterval                time.Duration
 }
 
-func NewCustomerQueryHandler(retrieveCustomerEventStream ForRetrievingCustomerEventStreams) *CustomerQueryHandler {
+func NewCustomerQueryHandler(
+	retrieveProjectedCustomerView ForRetrievingProjectedCustomerViews,
+	searchProjectedCustomerViews ForSearchingProjectedCustomerViews,
+	retrieveCustomerEventStream ForRetrievingCustomerEventStreams,
+	retrieveFullCustomerEventStream ForRetrievingFullCustomerEventStreams,
+	retrieveCustomerEventStreamRange ForRetrievingCustomerEventStreamRanges,
+	retrieveUniqueEmailAddresses ForRetrievingUniqueCustomerEmailAddresses,
+	retrieveCustomerIDForEmail ForRetrievingCustomerIDsForEmailAddresses,
+	watchCustomerEventStream ForWatchingCustomerEventStreams,
+	watchPollInterval time.Duration,
+) *CustomerQueryHandler {
+
 	return &CustomerQueryHandler{
-		retrieveCustomerEventStream: retrieveCustomerEventStream,
+		retrieveProjectedCustomerView:    retrieveProjectedCustomerView,
+		searchProjectedCustomerViews:     searchProjectedCustomerViews,
+		retrieveCustomerEventStream:      retrieveCustomerEventStream,
+		retrieveFullCustomerEventStream:  retrieveFullCustomerEventStream,
+		retrieveCustomerEventStreamRange: retrieveCustomerEventStreamRange,
+		retrieveUniqueEmailAddresses:     retrieveUniqueEmailAddresses,
+		retrieveCustomerIDForEmail:       retrieveCustomerIDForEmail,
+		watchCustomerEventStream:         watchCustomerEventStream,
+		watchPollInterval:                watchPollInterval,
 	}
 }
 
-func (h *CustomerQueryHandler) CustomerViewByID(customerID string) (customer.View, error) {
+func (h *CustomerQueryHandler) CustomerViewByID(ctx context.Context, customerID string) (customer.View, error) {
 	var err error
 	var customerIDValue value.CustomerID
 	wrapWithMsg := "customerQueryHandler.CustomerViewByID"
@@ -26,12 +56,49 @@ func (h *CustomerQueryHandler) CustomerViewByID(customerID string) (customer.Vie
 		return customer.View{}, errors.Wrap(err, wrapWithMsg)
 	}
 
-	eventStream, err := h.retrieveCustomerEventStream(customerIDValue)
+	customerView, err := h.retrieveCurrentCustomerView(ctx, customerIDValue)
 	if err != nil {
 		return customer.View{}, errors.Wrap(err, wrapWithMsg)
 	}
 
-	customerView := customer.BuildViewFrom(eventStream)
+	if customerView.IsDeleted {
+		err := errors.New("customer not found")
+
+		return customer.View{}, shared.MarkAndWrapError(err, shared.ErrNotFound, wrapWithMsg)
+	}
+
+	return customerView, nil
+}
+
+func (h *CustomerQueryHandler) CustomerViewByIDAsOf(
+	ctx context.Context,
+	customerID string,
+	asOfVersion uint,
+	asOfTime string,
+) (customer.View, error) {
+
+	var err error
+	var customerIDValue value.CustomerID
+	wrapWithMsg := "customerQueryHandler.CustomerViewByIDAsOf"
+
+	if customerIDValue, err = value.BuildCustomerID(customerID); err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	asOf, err := customer.BuildViewAsOf(asOfVersion, asOfTime)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	eventStream, err := h.retrieveFullCustomerEventStream(ctx, customerIDValue)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	customerView, err := customer.BuildViewAsOfFrom(eventStream, asOf)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
 
 	if customerView.IsDeleted {
 		err := errors.New("customer not found")
@@ -41,3 +108,192 @@ func (h *CustomerQueryHandler) CustomerViewByID(customerID string) (customer.Vie
 
 	return customerView, nil
 }
+
+func (h *CustomerQueryHandler) CustomerViewByEmailAddress(ctx context.Context, emailAddress string) (customer.View, error) {
+	var err error
+	var emailAddressValue value.UnconfirmedEmailAddress
+	wrapWithMsg := "customerQueryHandler.CustomerViewByEmailAddress"
+
+	if emailAddressValue, err = value.BuildUnconfirmedEmailAddress(emailAddress); err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	customerID, err := h.retrieveCustomerIDForEmail(ctx, emailAddressValue)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	customerView, err := h.retrieveCurrentCustomerView(ctx, customerID)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	// an email address which is only reserved for a pending change does not belong to the Customer yet
+	activeEmailAddress := value.RebuildConfirmedEmailAddress(customerView.EmailAddress)
+
+	if customerView.IsDeleted || activeEmailAddress.Canonical() != emailAddressValue.Canonical() {
+		err := errors.New("customer not found")
+
+		return customer.View{}, shared.MarkAndWrapError(err, shared.ErrNotFound, wrapWithMsg)
+	}
+
+	return customerView, nil
+}
+
+// retrieveCurrentCustomerView builds the View from the EventStream while the projection lags behind it,
+// so that a Customer always sees her own writes, e.g. to continue with the current ETag.
+func (h *CustomerQueryHandler) retrieveCurrentCustomerView(ctx context.Context, id value.CustomerID) (customer.View, error) {
+	customerView, err := h.retrieveProjectedCustomerView(ctx, id)
+	if err != nil && !errors.Is(err, shared.ErrNotFound) {
+		return customer.View{}, err
+	}
+
+	newerEvents, err := h.retrieveCustomerEventStreamRange(ctx, id, customerView.Version+1, 1)
+	if err != nil {
+		return customer.View{}, err
+	}
+
+	if len(newerEvents) == 0 {
+		return customerView, nil
+	}
+
+	eventStream, err := h.retrieveCustomerEventStream(ctx, id)
+	if err != nil {
+		return customer.View{}, err
+	}
+
+	return customer.BuildViewFrom(eventStream), nil
+}
+
+func (h *CustomerQueryHandler) SearchCustomerViews(
+	ctx context.Context,
+	criteria customer.ViewSearchCriteria,
+) (customer.ViewPage, error) {
+
+	wrapWithMsg := "customerQueryHandler.SearchCustomerViews"
+
+	search, err := customer.BuildViewSearch(criteria)
+	if err != nil {
+		return customer.ViewPage{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	customerViews, err := h.searchProjectedCustomerViews(ctx, search)
+	if err != nil {
+		return customer.ViewPage{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return customer.BuildViewPageFrom(search, customerViews), nil
+}
+
+func (h *CustomerQueryHandler) ExportCustomerData(ctx context.Context, customerID string) (customer.Export, error) {
+	var err error
+	var customerIDValue value.CustomerID
+	wrapWithMsg := "customerQueryHandler.ExportCustomerData"
+
+	if customerIDValue, err = value.BuildCustomerID(customerID); err != nil {
+		return customer.Export{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	eventStream, err := h.retrieveFullCustomerEventStream(ctx, customerIDValue)
+	if err != nil {
+		return customer.Export{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	uniqueEmailAddresses, err := h.retrieveUniqueEmailAddresses(ctx, customerIDValue)
+	if err != nil {
+		return customer.Export{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return customer.BuildExportFrom(eventStream, uniqueEmailAddresses), nil
+}
+
+func (h *CustomerQueryHandler) CustomerEventHistory(
+	ctx context.Context,
+	customerID string,
+	fromVersion uint,
+	maxEvents uint,
+) (customer.EventHistoryPage, error) {
+
+	var err error
+	var customerIDValue value.CustomerID
+	wrapWithMsg := "customerQueryHandler.CustomerEventHistory"
+
+	if customerIDValue, err = value.BuildCustomerID(customerID); err != nil {
+		return customer.EventHistoryPage{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	historyRange, err := customer.BuildEventHistoryRange(fromVersion, maxEvents)
+	if err != nil {
+		return customer.EventHistoryPage{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	// one more event than requested tells if there is a next page
+	eventStream, err := h.retrieveCustomerEventStreamRange(
+		ctx,
+		customerIDValue,
+		historyRange.FromVersion,
+		historyRange.MaxEvents+1,
+	)
+
+	if err != nil {
+		return customer.EventHistoryPage{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return customer.BuildEventHistoryPageFrom(historyRange, eventStream), nil
+}
+
+// WatchCustomer pushes the events which are stored already and then each new one as soon as it is appended.
+// Events appended by other instances of the service are not notified, they are found every watchPollInterval.
+// It only ends when the ctx is done, which is no failure, or when retrieving or pushing the events fails.
+func (h *CustomerQueryHandler) WatchCustomer(
+	ctx context.Context,
+	customerID string,
+	afterVersion uint,
+	push func(event customer.HistoricEvent) error,
+) error {
+
+	var err error
+	var customerIDValue value.CustomerID
+	var page customer.EventHistoryPage
+	wrapWithMsg := "customerQueryHandler.WatchCustomer"
+
+	if customerIDValue, err = value.BuildCustomerID(customerID); err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	// watch before reading, so that no event which is appended meanwhile can be missed
+	appended := h.watchCustomerEventStream(ctx, customerIDValue)
+
+	poll := time.NewTicker(h.watchPollInterval)
+	defer poll.Stop()
+
+	for {
+		page, err = h.CustomerEventHistory(ctx, customerID, afterVersion+1, customer.MaxEventHistoryPageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		for _, event := range page.Events {
+			if err = push(event); err != nil {
+				return errors.Wrap(err, wrapWithMsg)
+			}
+
+			afterVersion = event.StreamVersion
+		}
+
+		if page.NextFromVersion != 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-appended:
+		case <-poll.C:
+		}
+	}
+}
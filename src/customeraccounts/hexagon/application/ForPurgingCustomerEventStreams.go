@@ -1,5 +1,9 @@
 package application
 
-import "github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+import (
+	"context"
 
-type ForPurgingCustomerEventStreams func(id value.CustomerID) error
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+type ForPurgingCustomerEventStreams func(ctx context.Context, id value.CustomerID) error
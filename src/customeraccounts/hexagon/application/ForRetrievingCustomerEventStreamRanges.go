@@ -0,0 +1,17 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// ForRetrievingCustomerEventStreamRanges must not start with a snapshot, like ForRetrievingFullCustomerEventStreams.
+// The range is empty if the stream exists but has no events from fromVersion on.
+type ForRetrievingCustomerEventStreamRanges func(
+	ctx context.Context,
+	id value.CustomerID,
+	fromVersion uint,
+	maxEvents uint,
+) (es.EventStream, error)
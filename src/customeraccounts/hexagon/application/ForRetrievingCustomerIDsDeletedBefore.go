@@ -0,0 +1,10 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+type ForRetrievingCustomerIDsDeletedBefore func(ctx context.Context, deletedBefore time.Time) ([]value.CustomerID, error)
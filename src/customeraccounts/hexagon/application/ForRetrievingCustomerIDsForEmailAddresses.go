@@ -0,0 +1,9 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+type ForRetrievingCustomerIDsForEmailAddresses func(ctx context.Context, emailAddress value.EmailAddress) (value.CustomerID, error)
@@ -0,0 +1,10 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type ForRetrievingCustomerIDsForIdempotencyKeys func(ctx context.Context, idempotencyKey es.IdempotencyKey) (value.CustomerID, error)
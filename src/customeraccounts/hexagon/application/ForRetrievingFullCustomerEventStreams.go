@@ -0,0 +1,11 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// ForRetrievingFullCustomerEventStreams must not start with a snapshot, unlike ForRetrievingCustomerEventStreams.
+type ForRetrievingFullCustomerEventStreams func(ctx context.Context, id value.CustomerID) (es.EventStream, error)
@@ -0,0 +1,10 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+type ForRetrievingProjectedCustomerViews func(ctx context.Context, id value.CustomerID) (customer.View, error)
@@ -0,0 +1,9 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+type ForRetrievingUniqueCustomerEmailAddresses func(ctx context.Context, id value.CustomerID) ([]string, error)
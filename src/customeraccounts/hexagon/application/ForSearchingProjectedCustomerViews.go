@@ -0,0 +1,11 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+)
+
+// ForSearchingProjectedCustomerViews returns the matching Views after the cursor of the ViewSearch in its sort order,
+// at most one more than the PageSize, so that it's known if there is a next page.
+type ForSearchingProjectedCustomerViews func(ctx context.Context, search customer.ViewSearch) ([]customer.View, error)
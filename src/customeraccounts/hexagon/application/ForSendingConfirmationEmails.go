@@ -0,0 +1,7 @@
+package application
+
+import (
+	"context"
+)
+
+type ForSendingConfirmationEmails func(ctx context.Context, confirmationEmail ConfirmationEmail) error
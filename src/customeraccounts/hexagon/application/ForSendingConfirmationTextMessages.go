@@ -0,0 +1,7 @@
+package application
+
+import (
+	"context"
+)
+
+type ForSendingConfirmationTextMessages func(ctx context.Context, confirmationTextMessage ConfirmationTextMessage) error
@@ -1,7 +1,9 @@
 package application
 
 import (
+	"context"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 )
 
-type ForStartingCustomerEventStreams func(customerRegistered domain.CustomerRegistered) error
+type ForStartingCustomerEventStreams func(ctx context.Context, customerRegistered domain.CustomerRegistered) error
@@ -0,0 +1,10 @@
+package application
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+)
+
+// ForWatchingCustomerEventStreams notifies via the returned channel when events were appended, until the ctx is done.
+type ForWatchingCustomerEventStreams func(ctx context.Context, id value.CustomerID) <-chan struct{}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type AddCustomerAddress struct {
+	customerID      value.CustomerID
+	addressKind     value.AddressKind
+	address         value.Address
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildAddCustomerAddress(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	address value.Address,
+	expectedVersion uint,
+) AddCustomerAddress {
+
+	command := AddCustomerAddress{
+		customerID:      customerID,
+		addressKind:     addressKind,
+		address:         address,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command AddCustomerAddress) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command AddCustomerAddress) AddressKind() value.AddressKind {
+	return command.addressKind
+}
+
+func (command AddCustomerAddress) Address() value.Address {
+	return command.address
+}
+
+func (command AddCustomerAddress) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command AddCustomerAddress) MessageID() es.MessageID {
+	return command.messageID
+}
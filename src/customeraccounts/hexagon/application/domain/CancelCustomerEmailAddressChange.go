@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CancelCustomerEmailAddressChange struct {
+	customerID      value.CustomerID
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildCancelCustomerEmailAddressChange(
+	customerID value.CustomerID,
+	expectedVersion uint,
+) CancelCustomerEmailAddressChange {
+
+	command := CancelCustomerEmailAddressChange{
+		customerID:      customerID,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command CancelCustomerEmailAddressChange) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command CancelCustomerEmailAddressChange) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command CancelCustomerEmailAddressChange) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type ChangeCustomerAddress struct {
+	customerID      value.CustomerID
+	addressKind     value.AddressKind
+	address         value.Address
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildChangeCustomerAddress(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	address value.Address,
+	expectedVersion uint,
+) ChangeCustomerAddress {
+
+	command := ChangeCustomerAddress{
+		customerID:      customerID,
+		addressKind:     addressKind,
+		address:         address,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command ChangeCustomerAddress) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command ChangeCustomerAddress) AddressKind() value.AddressKind {
+	return command.addressKind
+}
+
+func (command ChangeCustomerAddress) Address() value.Address {
+	return command.address
+}
+
+func (command ChangeCustomerAddress) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command ChangeCustomerAddress) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -6,20 +6,23 @@ import (
 )
 
 type ChangeCustomerEmailAddress struct {
-	customerID   value.CustomerID
-	emailAddress value.UnconfirmedEmailAddress
-	messageID    es.MessageID
+	customerID      value.CustomerID
+	emailAddress    value.UnconfirmedEmailAddress
+	expectedVersion uint
+	messageID       es.MessageID
 }
 
 func BuildChangeCustomerEmailAddress(
 	customerID value.CustomerID,
 	emailAddress value.UnconfirmedEmailAddress,
+	expectedVersion uint,
 ) ChangeCustomerEmailAddress {
 
 	changeEmailAddress := ChangeCustomerEmailAddress{
-		customerID:   customerID,
-		emailAddress: emailAddress,
-		messageID:    es.GenerateMessageID(),
+		customerID:      customerID,
+		emailAddress:    emailAddress,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
 	}
 
 	return changeEmailAddress
@@ -33,6 +36,10 @@ func (command ChangeCustomerEmailAddress) EmailAddress() value.UnconfirmedEmailA
 	return command.emailAddress
 }
 
+func (command ChangeCustomerEmailAddress) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
 func (command ChangeCustomerEmailAddress) MessageID() es.MessageID {
 	return command.messageID
 }
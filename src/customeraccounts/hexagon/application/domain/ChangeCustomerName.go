@@ -6,20 +6,23 @@ import (
 )
 
 type ChangeCustomerName struct {
-	customerID value.CustomerID
-	personName value.PersonName
-	messageID  es.MessageID
+	customerID      value.CustomerID
+	personName      value.PersonName
+	expectedVersion uint
+	messageID       es.MessageID
 }
 
 func BuildChangeCustomerName(
 	customerID value.CustomerID,
 	personName value.PersonName,
+	expectedVersion uint,
 ) ChangeCustomerName {
 
 	command := ChangeCustomerName{
-		customerID: customerID,
-		personName: personName,
-		messageID:  es.GenerateMessageID(),
+		customerID:      customerID,
+		personName:      personName,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
 	}
 
 	return command
@@ -33,6 +36,10 @@ func (command ChangeCustomerName) PersonName() value.PersonName {
 	return command.personName
 }
 
+func (command ChangeCustomerName) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
 func (command ChangeCustomerName) MessageID() es.MessageID {
 	return command.messageID
 }
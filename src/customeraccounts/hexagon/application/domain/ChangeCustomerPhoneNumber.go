@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type ChangeCustomerPhoneNumber struct {
+	customerID      value.CustomerID
+	phoneNumber     value.UnconfirmedPhoneNumber
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildChangeCustomerPhoneNumber(
+	customerID value.CustomerID,
+	phoneNumber value.UnconfirmedPhoneNumber,
+	expectedVersion uint,
+) ChangeCustomerPhoneNumber {
+
+	command := ChangeCustomerPhoneNumber{
+		customerID:      customerID,
+		phoneNumber:     phoneNumber,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command ChangeCustomerPhoneNumber) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command ChangeCustomerPhoneNumber) PhoneNumber() value.UnconfirmedPhoneNumber {
+	return command.phoneNumber
+}
+
+func (command ChangeCustomerPhoneNumber) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command ChangeCustomerPhoneNumber) MessageID() es.MessageID {
+	return command.messageID
+}
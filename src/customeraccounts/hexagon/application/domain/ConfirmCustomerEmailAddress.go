@@ -1,25 +1,39 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 )
 
 type ConfirmCustomerEmailAddress struct {
-	customerID       value.CustomerID
-	confirmationHash value.ConfirmationHash
-	messageID        es.MessageID
+	customerID          value.CustomerID
+	confirmationHash    value.ConfirmationHash
+	confirmationHashTTL time.Duration
+	maxFailedAttempts   uint
+	lockoutDuration     time.Duration
+	expectedVersion     uint
+	messageID           es.MessageID
 }
 
 func BuildConfirmCustomerEmailAddress(
 	customerID value.CustomerID,
 	confirmationHash value.ConfirmationHash,
+	confirmationHashTTL time.Duration,
+	maxFailedAttempts uint,
+	lockoutDuration time.Duration,
+	expectedVersion uint,
 ) ConfirmCustomerEmailAddress {
 
 	command := ConfirmCustomerEmailAddress{
-		customerID:       customerID,
-		confirmationHash: confirmationHash,
-		messageID:        es.GenerateMessageID(),
+		customerID:          customerID,
+		confirmationHash:    confirmationHash,
+		confirmationHashTTL: confirmationHashTTL,
+		maxFailedAttempts:   maxFailedAttempts,
+		lockoutDuration:     lockoutDuration,
+		expectedVersion:     expectedVersion,
+		messageID:           es.GenerateMessageID(),
 	}
 
 	return command
@@ -33,6 +47,22 @@ func (command ConfirmCustomerEmailAddress) ConfirmationHash() value.Confirmation
 	return command.confirmationHash
 }
 
+func (command ConfirmCustomerEmailAddress) ConfirmationHashTTL() time.Duration {
+	return command.confirmationHashTTL
+}
+
+func (command ConfirmCustomerEmailAddress) MaxFailedAttempts() uint {
+	return command.maxFailedAttempts
+}
+
+func (command ConfirmCustomerEmailAddress) LockoutDuration() time.Duration {
+	return command.lockoutDuration
+}
+
+func (command ConfirmCustomerEmailAddress) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
 func (command ConfirmCustomerEmailAddress) MessageID() es.MessageID {
 	return command.messageID
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type ConfirmCustomerPhoneNumber struct {
+	customerID          value.CustomerID
+	confirmationCode    value.ConfirmationCode
+	confirmationCodeTTL time.Duration
+	maxFailedAttempts   uint
+	lockoutDuration     time.Duration
+	expectedVersion     uint
+	messageID           es.MessageID
+}
+
+func BuildConfirmCustomerPhoneNumber(
+	customerID value.CustomerID,
+	confirmationCode value.ConfirmationCode,
+	confirmationCodeTTL time.Duration,
+	maxFailedAttempts uint,
+	lockoutDuration time.Duration,
+	expectedVersion uint,
+) ConfirmCustomerPhoneNumber {
+
+	command := ConfirmCustomerPhoneNumber{
+		customerID:          customerID,
+		confirmationCode:    confirmationCode,
+		confirmationCodeTTL: confirmationCodeTTL,
+		maxFailedAttempts:   maxFailedAttempts,
+		lockoutDuration:     lockoutDuration,
+		expectedVersion:     expectedVersion,
+		messageID:           es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command ConfirmCustomerPhoneNumber) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command ConfirmCustomerPhoneNumber) ConfirmationCode() value.ConfirmationCode {
+	return command.confirmationCode
+}
+
+func (command ConfirmCustomerPhoneNumber) ConfirmationCodeTTL() time.Duration {
+	return command.confirmationCodeTTL
+}
+
+func (command ConfirmCustomerPhoneNumber) MaxFailedAttempts() uint {
+	return command.maxFailedAttempts
+}
+
+func (command ConfirmCustomerPhoneNumber) LockoutDuration() time.Duration {
+	return command.lockoutDuration
+}
+
+func (command ConfirmCustomerPhoneNumber) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command ConfirmCustomerPhoneNumber) MessageID() es.MessageID {
+	return command.messageID
+}
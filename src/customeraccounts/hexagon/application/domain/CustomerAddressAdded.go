@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerAddressAdded struct {
+	customerID  value.CustomerID
+	addressKind value.AddressKind
+	address     value.Address
+	meta        es.EventMeta
+}
+
+func BuildCustomerAddressAdded(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	address value.Address,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerAddressAdded {
+
+	event := CustomerAddressAdded{
+		customerID:  customerID,
+		addressKind: addressKind,
+		address:     address,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerAddressAdded(
+	customerID string,
+	addressKind string,
+	street string,
+	postalCode string,
+	city string,
+	countryCode string,
+	meta es.EventMeta,
+) CustomerAddressAdded {
+
+	event := CustomerAddressAdded{
+		customerID:  value.RebuildCustomerID(customerID),
+		addressKind: value.RebuildAddressKind(addressKind),
+		address:     value.RebuildAddress(street, postalCode, city, countryCode),
+		meta:        meta,
+	}
+
+	return event
+}
+
+func (event CustomerAddressAdded) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerAddressAdded) AddressKind() value.AddressKind {
+	return event.addressKind
+}
+
+func (event CustomerAddressAdded) Address() value.Address {
+	return event.address
+}
+
+func (event CustomerAddressAdded) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerAddressAdded) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerAddressAdded) FailureReason() error {
+	return nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerAddressChanged struct {
+	customerID  value.CustomerID
+	addressKind value.AddressKind
+	address     value.Address
+	meta        es.EventMeta
+}
+
+func BuildCustomerAddressChanged(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	address value.Address,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerAddressChanged {
+
+	event := CustomerAddressChanged{
+		customerID:  customerID,
+		addressKind: addressKind,
+		address:     address,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerAddressChanged(
+	customerID string,
+	addressKind string,
+	street string,
+	postalCode string,
+	city string,
+	countryCode string,
+	meta es.EventMeta,
+) CustomerAddressChanged {
+
+	event := CustomerAddressChanged{
+		customerID:  value.RebuildCustomerID(customerID),
+		addressKind: value.RebuildAddressKind(addressKind),
+		address:     value.RebuildAddress(street, postalCode, city, countryCode),
+		meta:        meta,
+	}
+
+	return event
+}
+
+func (event CustomerAddressChanged) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerAddressChanged) AddressKind() value.AddressKind {
+	return event.addressKind
+}
+
+func (event CustomerAddressChanged) Address() value.Address {
+	return event.address
+}
+
+func (event CustomerAddressChanged) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerAddressChanged) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerAddressChanged) FailureReason() error {
+	return nil
+}
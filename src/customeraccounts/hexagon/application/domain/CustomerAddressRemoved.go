@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerAddressRemoved struct {
+	customerID  value.CustomerID
+	addressKind value.AddressKind
+	meta        es.EventMeta
+}
+
+func BuildCustomerAddressRemoved(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerAddressRemoved {
+
+	event := CustomerAddressRemoved{
+		customerID:  customerID,
+		addressKind: addressKind,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerAddressRemoved(
+	customerID string,
+	addressKind string,
+	meta es.EventMeta,
+) CustomerAddressRemoved {
+
+	event := CustomerAddressRemoved{
+		customerID:  value.RebuildCustomerID(customerID),
+		addressKind: value.RebuildAddressKind(addressKind),
+		meta:        meta,
+	}
+
+	return event
+}
+
+func (event CustomerAddressRemoved) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerAddressRemoved) AddressKind() value.AddressKind {
+	return event.addressKind
+}
+
+func (event CustomerAddressRemoved) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerAddressRemoved) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerAddressRemoved) FailureReason() error {
+	return nil
+}
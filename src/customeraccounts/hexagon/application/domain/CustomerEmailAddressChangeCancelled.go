@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerEmailAddressChangeCancelled discards the pending emailAddress, which is the one recorded in this event.
+type CustomerEmailAddressChangeCancelled struct {
+	customerID   value.CustomerID
+	emailAddress value.UnconfirmedEmailAddress
+	meta         es.EventMeta
+}
+
+func BuildCustomerEmailAddressChangeCancelled(
+	customerID value.CustomerID,
+	emailAddress value.UnconfirmedEmailAddress,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerEmailAddressChangeCancelled {
+
+	event := CustomerEmailAddressChangeCancelled{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerEmailAddressChangeCancelled(
+	customerID string,
+	emailAddress string,
+	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
+	meta es.EventMeta,
+) CustomerEmailAddressChangeCancelled {
+
+	event := CustomerEmailAddressChangeCancelled{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash, confirmationHashCreatedAt),
+		meta:         meta,
+	}
+
+	return event
+}
+
+func (event CustomerEmailAddressChangeCancelled) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerEmailAddressChangeCancelled) EmailAddress() value.UnconfirmedEmailAddress {
+	return event.emailAddress
+}
+
+func (event CustomerEmailAddressChangeCancelled) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerEmailAddressChangeCancelled) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerEmailAddressChangeCancelled) FailureReason() error {
+	return nil
+}
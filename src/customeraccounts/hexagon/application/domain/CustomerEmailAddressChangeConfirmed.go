@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerEmailAddressChangeConfirmed replaces the previously active emailAddress with the confirmed pending one.
+type CustomerEmailAddressChangeConfirmed struct {
+	customerID   value.CustomerID
+	emailAddress value.ConfirmedEmailAddress
+	meta         es.EventMeta
+}
+
+func BuildCustomerEmailAddressChangeConfirmed(
+	customerID value.CustomerID,
+	emailAddress value.ConfirmedEmailAddress,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerEmailAddressChangeConfirmed {
+
+	event := CustomerEmailAddressChangeConfirmed{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerEmailAddressChangeConfirmed(
+	customerID string,
+	emailAddress string,
+	meta es.EventMeta,
+) CustomerEmailAddressChangeConfirmed {
+
+	event := CustomerEmailAddressChangeConfirmed{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: value.ConfirmedEmailAddress(emailAddress),
+		meta:         meta,
+	}
+
+	return event
+}
+
+func (event CustomerEmailAddressChangeConfirmed) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerEmailAddressChangeConfirmed) EmailAddress() value.ConfirmedEmailAddress {
+	return event.emailAddress
+}
+
+func (event CustomerEmailAddressChangeConfirmed) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerEmailAddressChangeConfirmed) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerEmailAddressChangeConfirmed) FailureReason() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerEmailAddressChangeRequested records a pending emailAddress, the confirmed emailAddress stays active until
+// the pending one is confirmed. It replaces a previously pending emailAddress when its confirmation is resent.
+type CustomerEmailAddressChangeRequested struct {
+	customerID   value.CustomerID
+	emailAddress value.UnconfirmedEmailAddress
+	meta         es.EventMeta
+}
+
+func BuildCustomerEmailAddressChangeRequested(
+	customerID value.CustomerID,
+	emailAddress value.UnconfirmedEmailAddress,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerEmailAddressChangeRequested {
+
+	event := CustomerEmailAddressChangeRequested{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerEmailAddressChangeRequested(
+	customerID string,
+	emailAddress string,
+	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
+	meta es.EventMeta,
+) CustomerEmailAddressChangeRequested {
+
+	event := CustomerEmailAddressChangeRequested{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash, confirmationHashCreatedAt),
+		meta:         meta,
+	}
+
+	return event
+}
+
+func (event CustomerEmailAddressChangeRequested) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerEmailAddressChangeRequested) EmailAddress() value.UnconfirmedEmailAddress {
+	return event.emailAddress
+}
+
+func (event CustomerEmailAddressChangeRequested) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerEmailAddressChangeRequested) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerEmailAddressChangeRequested) FailureReason() error {
+	return nil
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 )
@@ -32,12 +34,13 @@ func RebuildCustomerEmailAddressChanged(
 	customerID string,
 	emailAddress string,
 	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
 	meta es.EventMeta,
 ) CustomerEmailAddressChanged {
 
 	event := CustomerEmailAddressChanged{
 		customerID:   value.RebuildCustomerID(customerID),
-		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash),
+		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash, confirmationHashCreatedAt),
 		meta:         meta,
 	}
 
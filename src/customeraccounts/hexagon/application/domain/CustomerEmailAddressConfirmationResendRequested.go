@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerEmailAddressConfirmationResendRequested struct {
+	customerID   value.CustomerID
+	emailAddress value.UnconfirmedEmailAddress
+	meta         es.EventMeta
+}
+
+func BuildCustomerEmailAddressConfirmationResendRequested(
+	customerID value.CustomerID,
+	emailAddress value.UnconfirmedEmailAddress,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerEmailAddressConfirmationResendRequested {
+
+	event := CustomerEmailAddressConfirmationResendRequested{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerEmailAddressConfirmationResendRequested(
+	customerID string,
+	emailAddress string,
+	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
+	meta es.EventMeta,
+) CustomerEmailAddressConfirmationResendRequested {
+
+	event := CustomerEmailAddressConfirmationResendRequested{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash, confirmationHashCreatedAt),
+		meta:         meta,
+	}
+
+	return event
+}
+
+func (event CustomerEmailAddressConfirmationResendRequested) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+// EmailAddress carries the regenerated ConfirmationHash, which replaces the previous one.
+func (event CustomerEmailAddressConfirmationResendRequested) EmailAddress() value.UnconfirmedEmailAddress {
+	return event.emailAddress
+}
+
+func (event CustomerEmailAddressConfirmationResendRequested) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerEmailAddressConfirmationResendRequested) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerEmailAddressConfirmationResendRequested) FailureReason() error {
+	return nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerPersonalDataErased marks that the personal data of the Customer must not be used any more. The infrastructure
+// deletes the key it was encrypted with, so that it can't be read from the recorded events any more (crypto-shredding).
+type CustomerPersonalDataErased struct {
+	customerID value.CustomerID
+	meta       es.EventMeta
+}
+
+func BuildCustomerPersonalDataErased(
+	customerID value.CustomerID,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerPersonalDataErased {
+
+	event := CustomerPersonalDataErased{
+		customerID: customerID,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerPersonalDataErased(
+	customerID string,
+	meta es.EventMeta,
+) CustomerPersonalDataErased {
+
+	event := CustomerPersonalDataErased{
+		customerID: value.RebuildCustomerID(customerID),
+		meta:       meta,
+	}
+
+	return event
+}
+
+func (event CustomerPersonalDataErased) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerPersonalDataErased) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerPersonalDataErased) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerPersonalDataErased) FailureReason() error {
+	return nil
+}
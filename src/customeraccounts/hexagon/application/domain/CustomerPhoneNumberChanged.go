@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerPhoneNumberChanged struct {
+	customerID  value.CustomerID
+	phoneNumber value.UnconfirmedPhoneNumber
+	meta        es.EventMeta
+}
+
+func BuildCustomerPhoneNumberChanged(
+	customerID value.CustomerID,
+	phoneNumber value.UnconfirmedPhoneNumber,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerPhoneNumberChanged {
+
+	event := CustomerPhoneNumberChanged{
+		customerID:  customerID,
+		phoneNumber: phoneNumber,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerPhoneNumberChanged(
+	customerID string,
+	phoneNumber string,
+	confirmationCode string,
+	confirmationCodeCreatedAt time.Time,
+	meta es.EventMeta,
+) CustomerPhoneNumberChanged {
+
+	event := CustomerPhoneNumberChanged{
+		customerID:  value.RebuildCustomerID(customerID),
+		phoneNumber: value.RebuildUnconfirmedPhoneNumber(phoneNumber, confirmationCode, confirmationCodeCreatedAt),
+		meta:        meta,
+	}
+
+	return event
+}
+
+func (event CustomerPhoneNumberChanged) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerPhoneNumberChanged) PhoneNumber() value.UnconfirmedPhoneNumber {
+	return event.phoneNumber
+}
+
+func (event CustomerPhoneNumberChanged) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerPhoneNumberChanged) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerPhoneNumberChanged) FailureReason() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+type CustomerPhoneNumberConfirmationFailed struct {
+	customerID       value.CustomerID
+	confirmationCode value.ConfirmationCode
+	reason           error
+	meta             es.EventMeta
+}
+
+func BuildCustomerPhoneNumberConfirmationFailed(
+	customerID value.CustomerID,
+	confirmationCode value.ConfirmationCode,
+	reason error,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerPhoneNumberConfirmationFailed {
+
+	event := CustomerPhoneNumberConfirmationFailed{
+		customerID:       customerID,
+		confirmationCode: confirmationCode,
+		reason:           reason,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerPhoneNumberConfirmationFailed(
+	customerID string,
+	confirmationCode string,
+	reason string,
+	meta es.EventMeta,
+) CustomerPhoneNumberConfirmationFailed {
+
+	event := CustomerPhoneNumberConfirmationFailed{
+		customerID:       value.RebuildCustomerID(customerID),
+		confirmationCode: value.RebuildConfirmationCode(confirmationCode),
+		reason:           errors.Mark(errors.New(reason), shared.ErrDomainConstraintsViolation),
+		meta:             meta,
+	}
+
+	return event
+}
+
+func (event CustomerPhoneNumberConfirmationFailed) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerPhoneNumberConfirmationFailed) ConfirmationCode() value.ConfirmationCode {
+	return event.confirmationCode
+}
+
+func (event CustomerPhoneNumberConfirmationFailed) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerPhoneNumberConfirmationFailed) IsFailureEvent() bool {
+	return true
+}
+
+func (event CustomerPhoneNumberConfirmationFailed) FailureReason() error {
+	return event.reason
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerPhoneNumberConfirmed struct {
+	customerID  value.CustomerID
+	phoneNumber value.ConfirmedPhoneNumber
+	meta        es.EventMeta
+}
+
+func BuildCustomerPhoneNumberConfirmed(
+	customerID value.CustomerID,
+	phoneNumber value.ConfirmedPhoneNumber,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerPhoneNumberConfirmed {
+
+	event := CustomerPhoneNumberConfirmed{
+		customerID:  customerID,
+		phoneNumber: phoneNumber,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerPhoneNumberConfirmed(
+	customerID string,
+	phoneNumber string,
+	meta es.EventMeta,
+) CustomerPhoneNumberConfirmed {
+
+	event := CustomerPhoneNumberConfirmed{
+		customerID:  value.RebuildCustomerID(customerID),
+		phoneNumber: value.RebuildConfirmedPhoneNumber(phoneNumber),
+		meta:        meta,
+	}
+
+	return event
+}
+
+func (event CustomerPhoneNumberConfirmed) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerPhoneNumberConfirmed) PhoneNumber() value.ConfirmedPhoneNumber {
+	return event.phoneNumber
+}
+
+func (event CustomerPhoneNumberConfirmed) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerPhoneNumberConfirmed) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerPhoneNumberConfirmed) FailureReason() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerReactivated struct {
+	customerID value.CustomerID
+	meta       es.EventMeta
+}
+
+func BuildCustomerReactivated(
+	customerID value.CustomerID,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerReactivated {
+
+	event := CustomerReactivated{
+		customerID: customerID,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerReactivated(
+	customerID string,
+	meta es.EventMeta,
+) CustomerReactivated {
+
+	event := CustomerReactivated{
+		customerID: value.RebuildCustomerID(customerID),
+		meta:       meta,
+	}
+
+	return event
+}
+
+func (event CustomerReactivated) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerReactivated) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerReactivated) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerReactivated) FailureReason() error {
+	return nil
+}
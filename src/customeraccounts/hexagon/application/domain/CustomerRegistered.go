@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 )
@@ -35,6 +37,7 @@ func RebuildCustomerRegistered(
 	customerID string,
 	emailAddress string,
 	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
 	givenName string,
 	familyName string,
 	meta es.EventMeta,
@@ -42,7 +45,7 @@ func RebuildCustomerRegistered(
 
 	event := CustomerRegistered{
 		customerID:   value.RebuildCustomerID(customerID),
-		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash),
+		emailAddress: value.RebuildUnconfirmedEmailAddress(emailAddress, confirmationHash, confirmationHashCreatedAt),
 		personName:   value.RebuildPersonName(givenName, familyName),
 		meta:         meta,
 	}
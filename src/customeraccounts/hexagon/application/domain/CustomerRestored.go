@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerRestored undoes CustomerDeleted. It contains the emailAddress, because it has to be reclaimed.
+type CustomerRestored struct {
+	customerID   value.CustomerID
+	emailAddress value.EmailAddress
+	meta         es.EventMeta
+}
+
+func BuildCustomerRestored(
+	customerID value.CustomerID,
+	emailAddress value.EmailAddress,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerRestored {
+
+	event := CustomerRestored{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerRestored(
+	customerID string,
+	emailAddress string,
+	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
+	isEmailAddressConfirmed bool,
+	meta es.EventMeta,
+) CustomerRestored {
+
+	var rebuiltEmailAddress value.EmailAddress = value.RebuildUnconfirmedEmailAddress(
+		emailAddress,
+		confirmationHash,
+		confirmationHashCreatedAt,
+	)
+
+	if isEmailAddressConfirmed {
+		rebuiltEmailAddress = value.RebuildConfirmedEmailAddress(emailAddress)
+	}
+
+	event := CustomerRestored{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: rebuiltEmailAddress,
+		meta:         meta,
+	}
+
+	return event
+}
+
+func (event CustomerRestored) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerRestored) EmailAddress() value.EmailAddress {
+	return event.emailAddress
+}
+
+func (event CustomerRestored) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerRestored) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerRestored) FailureReason() error {
+	return nil
+}
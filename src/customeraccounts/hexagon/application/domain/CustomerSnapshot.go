@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// CustomerSnapshot is not a real domain event, it's the folded state of a Customer at a certain stream version.
+// It is put at the head of an EventStream, so that only the events after its stream version have to be replayed.
+type CustomerSnapshot struct {
+	customerID   value.CustomerID
+	emailAddress value.EmailAddress
+	personName   value.PersonName
+	registeredAt time.Time
+	isDeleted    bool
+	deletedAt    time.Time
+	meta         es.EventMeta
+
+	isPersonalDataErased bool
+
+	isSuspended      bool
+	suspensionReason value.SuspensionReason
+
+	pendingEmailAddress        *value.UnconfirmedEmailAddress
+	recentConfirmationFailures []time.Time
+
+	addresses map[value.AddressKind]value.Address
+
+	phoneNumber                           value.PhoneNumber
+	recentPhoneNumberConfirmationFailures []time.Time
+}
+
+func BuildCustomerSnapshot(
+	customerID value.CustomerID,
+	emailAddress value.EmailAddress,
+	personName value.PersonName,
+	registeredAt time.Time,
+	isDeleted bool,
+	deletedAt time.Time,
+	isPersonalDataErased bool,
+	isSuspended bool,
+	suspensionReason value.SuspensionReason,
+	pendingEmailAddress *value.UnconfirmedEmailAddress,
+	recentConfirmationFailures []time.Time,
+	addresses map[value.AddressKind]value.Address,
+	phoneNumber value.PhoneNumber,
+	recentPhoneNumberConfirmationFailures []time.Time,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerSnapshot {
+
+	snapshot := CustomerSnapshot{
+		customerID:   customerID,
+		emailAddress: emailAddress,
+		personName:   personName,
+		registeredAt: registeredAt,
+		isDeleted:    isDeleted,
+		deletedAt:    deletedAt,
+
+		isPersonalDataErased: isPersonalDataErased,
+
+		isSuspended:      isSuspended,
+		suspensionReason: suspensionReason,
+
+		pendingEmailAddress:        pendingEmailAddress,
+		recentConfirmationFailures: recentConfirmationFailures,
+
+		addresses: addresses,
+
+		phoneNumber:                           phoneNumber,
+		recentPhoneNumberConfirmationFailures: recentPhoneNumberConfirmationFailures,
+	}
+
+	snapshot.meta = es.BuildEventMeta(snapshot, causationID, streamVersion)
+
+	return snapshot
+}
+
+func RebuildCustomerSnapshot(
+	customerID string,
+	emailAddress string,
+	confirmationHash string,
+	confirmationHashCreatedAt time.Time,
+	isEmailAddressConfirmed bool,
+	givenName string,
+	familyName string,
+	registeredAt time.Time,
+	isDeleted bool,
+	deletedAt time.Time,
+	isPersonalDataErased bool,
+	isSuspended bool,
+	suspensionReason string,
+	pendingEmailAddress string,
+	pendingConfirmationHash string,
+	pendingConfirmationHashCreatedAt time.Time,
+	recentConfirmationFailures []time.Time,
+	addresses map[value.AddressKind]value.Address,
+	phoneNumber string,
+	phoneNumberConfirmationCode string,
+	phoneNumberConfirmationCodeCreatedAt time.Time,
+	isPhoneNumberConfirmed bool,
+	recentPhoneNumberConfirmationFailures []time.Time,
+	meta es.EventMeta,
+) CustomerSnapshot {
+
+	var rebuiltEmailAddress value.EmailAddress = value.RebuildUnconfirmedEmailAddress(
+		emailAddress,
+		confirmationHash,
+		confirmationHashCreatedAt,
+	)
+
+	if isEmailAddressConfirmed {
+		rebuiltEmailAddress = value.RebuildConfirmedEmailAddress(emailAddress)
+	}
+
+	var rebuiltPendingEmailAddress *value.UnconfirmedEmailAddress
+
+	if pendingEmailAddress != "" {
+		rebuilt := value.RebuildUnconfirmedEmailAddress(
+			pendingEmailAddress,
+			pendingConfirmationHash,
+			pendingConfirmationHashCreatedAt,
+		)
+
+		rebuiltPendingEmailAddress = &rebuilt
+	}
+
+	var rebuiltPhoneNumber value.PhoneNumber
+
+	switch {
+	case phoneNumber == "":
+		// the Customer has no PhoneNumber
+	case isPhoneNumberConfirmed:
+		rebuiltPhoneNumber = value.RebuildConfirmedPhoneNumber(phoneNumber)
+	default:
+		rebuiltPhoneNumber = value.RebuildUnconfirmedPhoneNumber(
+			phoneNumber,
+			phoneNumberConfirmationCode,
+			phoneNumberConfirmationCodeCreatedAt,
+		)
+	}
+
+	snapshot := CustomerSnapshot{
+		customerID:   value.RebuildCustomerID(customerID),
+		emailAddress: rebuiltEmailAddress,
+		personName:   value.RebuildPersonName(givenName, familyName),
+		registeredAt: registeredAt,
+		isDeleted:    isDeleted,
+		deletedAt:    deletedAt,
+		meta:         meta,
+
+		isPersonalDataErased: isPersonalDataErased,
+
+		isSuspended:      isSuspended,
+		suspensionReason: value.RebuildSuspensionReason(suspensionReason),
+
+		pendingEmailAddress:        rebuiltPendingEmailAddress,
+		recentConfirmationFailures: recentConfirmationFailures,
+
+		addresses: addresses,
+
+		phoneNumber:                           rebuiltPhoneNumber,
+		recentPhoneNumberConfirmationFailures: recentPhoneNumberConfirmationFailures,
+	}
+
+	return snapshot
+}
+
+func (snapshot CustomerSnapshot) CustomerID() value.CustomerID {
+	return snapshot.customerID
+}
+
+func (snapshot CustomerSnapshot) EmailAddress() value.EmailAddress {
+	return snapshot.emailAddress
+}
+
+func (snapshot CustomerSnapshot) PersonName() value.PersonName {
+	return snapshot.personName
+}
+
+func (snapshot CustomerSnapshot) RegisteredAt() time.Time {
+	return snapshot.registeredAt
+}
+
+func (snapshot CustomerSnapshot) IsDeleted() bool {
+	return snapshot.isDeleted
+}
+
+// DeletedAt is the zero time if the Customer is not deleted.
+func (snapshot CustomerSnapshot) DeletedAt() time.Time {
+	return snapshot.deletedAt
+}
+
+func (snapshot CustomerSnapshot) IsPersonalDataErased() bool {
+	return snapshot.isPersonalDataErased
+}
+
+func (snapshot CustomerSnapshot) IsSuspended() bool {
+	return snapshot.isSuspended
+}
+
+// SuspensionReason is empty if the Customer is not suspended.
+func (snapshot CustomerSnapshot) SuspensionReason() value.SuspensionReason {
+	return snapshot.suspensionReason
+}
+
+// PendingEmailAddress is nil if no change of the emailAddress is waiting for its confirmation.
+func (snapshot CustomerSnapshot) PendingEmailAddress() *value.UnconfirmedEmailAddress {
+	return snapshot.pendingEmailAddress
+}
+
+// RecentConfirmationFailures are the points in time of the failed attempts to confirm the current ConfirmationHash.
+func (snapshot CustomerSnapshot) RecentConfirmationFailures() []time.Time {
+	return snapshot.recentConfirmationFailures
+}
+
+// Addresses is nil if the Customer has no Address.
+func (snapshot CustomerSnapshot) Addresses() map[value.AddressKind]value.Address {
+	return snapshot.addresses
+}
+
+// PhoneNumber is nil if the Customer has no PhoneNumber.
+func (snapshot CustomerSnapshot) PhoneNumber() value.PhoneNumber {
+	return snapshot.phoneNumber
+}
+
+func (snapshot CustomerSnapshot) RecentPhoneNumberConfirmationFailures() []time.Time {
+	return snapshot.recentPhoneNumberConfirmationFailures
+}
+
+func (snapshot CustomerSnapshot) Meta() es.EventMeta {
+	return snapshot.meta
+}
+
+func (snapshot CustomerSnapshot) IsFailureEvent() bool {
+	return false
+}
+
+func (snapshot CustomerSnapshot) FailureReason() error {
+	return nil
+}
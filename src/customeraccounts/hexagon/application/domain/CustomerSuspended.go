@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type CustomerSuspended struct {
+	customerID value.CustomerID
+	reason     value.SuspensionReason
+	meta       es.EventMeta
+}
+
+func BuildCustomerSuspended(
+	customerID value.CustomerID,
+	reason value.SuspensionReason,
+	causationID es.MessageID,
+	streamVersion uint,
+) CustomerSuspended {
+
+	event := CustomerSuspended{
+		customerID: customerID,
+		reason:     reason,
+	}
+
+	event.meta = es.BuildEventMeta(event, causationID, streamVersion)
+
+	return event
+}
+
+func RebuildCustomerSuspended(
+	customerID string,
+	reason string,
+	meta es.EventMeta,
+) CustomerSuspended {
+
+	event := CustomerSuspended{
+		customerID: value.RebuildCustomerID(customerID),
+		reason:     value.RebuildSuspensionReason(reason),
+		meta:       meta,
+	}
+
+	return event
+}
+
+func (event CustomerSuspended) CustomerID() value.CustomerID {
+	return event.customerID
+}
+
+func (event CustomerSuspended) Reason() value.SuspensionReason {
+	return event.reason
+}
+
+func (event CustomerSuspended) Meta() es.EventMeta {
+	return event.meta
+}
+
+func (event CustomerSuspended) IsFailureEvent() bool {
+	return false
+}
+
+func (event CustomerSuspended) FailureReason() error {
+	return nil
+}
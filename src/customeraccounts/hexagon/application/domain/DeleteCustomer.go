@@ -6,14 +6,16 @@ import (
 )
 
 type DeleteCustomer struct {
-	customerID value.CustomerID
-	messageID  es.MessageID
+	customerID      value.CustomerID
+	expectedVersion uint
+	messageID       es.MessageID
 }
 
-func BuildDeleteCustomer(customerID value.CustomerID) DeleteCustomer {
+func BuildDeleteCustomer(customerID value.CustomerID, expectedVersion uint) DeleteCustomer {
 	command := DeleteCustomer{
-		customerID: customerID,
-		messageID:  es.GenerateMessageID(),
+		customerID:      customerID,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
 	}
 
 	return command
@@ -23,6 +25,10 @@ func (command DeleteCustomer) CustomerID() value.CustomerID {
 	return command.customerID
 }
 
+func (command DeleteCustomer) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
 func (command DeleteCustomer) MessageID() es.MessageID {
 	return command.messageID
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type EraseCustomerPersonalData struct {
+	customerID      value.CustomerID
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildEraseCustomerPersonalData(customerID value.CustomerID, expectedVersion uint) EraseCustomerPersonalData {
+	command := EraseCustomerPersonalData{
+		customerID:      customerID,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command EraseCustomerPersonalData) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command EraseCustomerPersonalData) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command EraseCustomerPersonalData) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type ReactivateCustomer struct {
+	customerID      value.CustomerID
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildReactivateCustomer(customerID value.CustomerID, expectedVersion uint) ReactivateCustomer {
+	command := ReactivateCustomer{
+		customerID:      customerID,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command ReactivateCustomer) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command ReactivateCustomer) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command ReactivateCustomer) MessageID() es.MessageID {
+	return command.messageID
+}
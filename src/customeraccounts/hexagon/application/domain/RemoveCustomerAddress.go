@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type RemoveCustomerAddress struct {
+	customerID      value.CustomerID
+	addressKind     value.AddressKind
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildRemoveCustomerAddress(
+	customerID value.CustomerID,
+	addressKind value.AddressKind,
+	expectedVersion uint,
+) RemoveCustomerAddress {
+
+	command := RemoveCustomerAddress{
+		customerID:      customerID,
+		addressKind:     addressKind,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command RemoveCustomerAddress) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command RemoveCustomerAddress) AddressKind() value.AddressKind {
+	return command.addressKind
+}
+
+func (command RemoveCustomerAddress) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command RemoveCustomerAddress) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type RequestCustomerEmailAddressConfirmationResend struct {
+	customerID        value.CustomerID
+	minResendInterval time.Duration
+	expectedVersion   uint
+	messageID         es.MessageID
+}
+
+func BuildRequestCustomerEmailAddressConfirmationResend(
+	customerID value.CustomerID,
+	minResendInterval time.Duration,
+	expectedVersion uint,
+) RequestCustomerEmailAddressConfirmationResend {
+
+	command := RequestCustomerEmailAddressConfirmationResend{
+		customerID:        customerID,
+		minResendInterval: minResendInterval,
+		expectedVersion:   expectedVersion,
+		messageID:         es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command RequestCustomerEmailAddressConfirmationResend) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command RequestCustomerEmailAddressConfirmationResend) MinResendInterval() time.Duration {
+	return command.minResendInterval
+}
+
+func (command RequestCustomerEmailAddressConfirmationResend) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command RequestCustomerEmailAddressConfirmationResend) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type RestoreCustomer struct {
+	customerID      value.CustomerID
+	gracePeriod     time.Duration
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildRestoreCustomer(
+	customerID value.CustomerID,
+	gracePeriod time.Duration,
+	expectedVersion uint,
+) RestoreCustomer {
+
+	command := RestoreCustomer{
+		customerID:      customerID,
+		gracePeriod:     gracePeriod,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command RestoreCustomer) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command RestoreCustomer) GracePeriod() time.Duration {
+	return command.gracePeriod
+}
+
+func (command RestoreCustomer) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command RestoreCustomer) MessageID() es.MessageID {
+	return command.messageID
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type SuspendCustomer struct {
+	customerID      value.CustomerID
+	reason          value.SuspensionReason
+	expectedVersion uint
+	messageID       es.MessageID
+}
+
+func BuildSuspendCustomer(
+	customerID value.CustomerID,
+	reason value.SuspensionReason,
+	expectedVersion uint,
+) SuspendCustomer {
+
+	command := SuspendCustomer{
+		customerID:      customerID,
+		reason:          reason,
+		expectedVersion: expectedVersion,
+		messageID:       es.GenerateMessageID(),
+	}
+
+	return command
+}
+
+func (command SuspendCustomer) CustomerID() value.CustomerID {
+	return command.customerID
+}
+
+func (command SuspendCustomer) Reason() value.SuspensionReason {
+	return command.reason
+}
+
+func (command SuspendCustomer) ExpectedVersion() uint {
+	return command.expectedVersion
+}
+
+func (command SuspendCustomer) MessageID() es.MessageID {
+	return command.messageID
+}
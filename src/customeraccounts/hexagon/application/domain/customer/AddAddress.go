@@ -0,0 +1,44 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func AddAddress(eventStream es.EventStream, command domain.AddCustomerAddress) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "addCustomerAddress")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "addCustomerAddress")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "addCustomerAddress")
+	}
+
+	if existingAddress, found := customer.addresses[command.AddressKind()]; found {
+		if existingAddress.Equals(command.Address()) {
+			return nil, nil
+		}
+
+		err := errors.Newf("customer already has a %s address, it must be changed instead", command.AddressKind())
+
+		return nil, shared.MarkAndWrapError(err, shared.ErrDuplicate, "addCustomerAddress")
+	}
+
+	event := domain.BuildCustomerAddressAdded(
+		command.CustomerID(),
+		command.AddressKind(),
+		command.Address(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
@@ -0,0 +1,214 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddAddress(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		address, err := value.BuildAddress("Main Street 1", "10115", "Berlin", "DE")
+		So(err, ShouldBeNil)
+		otherAddress, err := value.BuildAddress("Side Street 2", "80331", "Munich", "DE")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildAddCustomerAddress(customerID, value.AddressKindBilling, address, 0)
+		commandWithOtherAddress := domain.BuildAddCustomerAddress(customerID, value.AddressKindBilling, otherAddress, 0)
+		commandWithOutdatedVersion := domain.BuildAddCustomerAddress(customerID, value.AddressKindBilling, address, 1)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		billingAddressAdded := domain.BuildCustomerAddressAdded(
+			customerID,
+			value.AddressKindBilling,
+			address,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		shippingAddressAdded := domain.BuildCustomerAddressAdded(
+			customerID,
+			value.AddressKindShipping,
+			address,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Add a billing address to a Customer", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When AddCustomerAddress", func() {
+					recordedEvents, err = customer.AddAddress(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerAddressAdded", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerAddressAdded)
+						So(ok, ShouldBeTrue)
+						So(event, ShouldNotBeNil)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.AddressKind().Equals(value.AddressKindBilling), ShouldBeTrue)
+						So(event.Address().Equals(address), ShouldBeTrue)
+						So(event.IsFailureEvent(), ShouldBeFalse)
+						So(event.FailureReason(), ShouldBeNil)
+						So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+						So(event.Meta().MessageID(), ShouldNotBeEmpty)
+						So(event.Meta().StreamVersion(), ShouldEqual, 2)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Add a billing address to a Customer who has a shipping address", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the shipping address", func() {
+					eventStream = append(eventStream, shippingAddressAdded)
+
+					Convey("When AddCustomerAddress", func() {
+						recordedEvents, err = customer.AddAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerAddressAdded", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerAddressAdded)
+							So(ok, ShouldBeTrue)
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to add the same billing address again", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("When AddCustomerAddress", func() {
+						recordedEvents, err = customer.AddAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to add another billing address", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("When AddCustomerAddress with another address", func() {
+						_, err = customer.AddAddress(eventStream, commandWithOtherAddress)
+
+						Convey("Then it should report a duplicate", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to add an address when the account was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When AddCustomerAddress", func() {
+						_, err = customer.AddAddress(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 6: Try to add an address when the account is suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When AddCustomerAddress", func() {
+						_, err = customer.AddAddress(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 7: Try to add an address when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the shipping address", func() {
+					eventStream = append(eventStream, shippingAddressAdded)
+
+					Convey("When AddCustomerAddress with the expected version 1", func() {
+						_, err = customer.AddAddress(eventStream, commandWithOutdatedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
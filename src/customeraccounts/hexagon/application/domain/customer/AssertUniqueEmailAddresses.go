@@ -10,14 +10,17 @@ const (
 	ShouldAddUniqueEmailAddress = iota
 	ShouldReplaceUniqueEmailAddress
 	ShouldRemoveUniqueEmailAddress
+	ShouldReserveUniqueEmailAddress // in addition to the active one, until a pending change is confirmed or cancelled
+	ShouldReleaseUniqueEmailAddress
 )
 
 type ForBuildingUniqueEmailAddressAssertions func(recordedEvents ...es.DomainEvent) UniqueEmailAddressAssertions
 
 type UniqueEmailAddressAssertion struct {
-	desiredAction     int
-	customerID        value.CustomerID
-	emailAddressToAdd value.UnconfirmedEmailAddress
+	desiredAction         int
+	customerID            value.CustomerID
+	emailAddressToAdd     value.EmailAddress
+	emailAddressToRelease value.EmailAddress
 }
 
 type UniqueEmailAddressAssertions []UniqueEmailAddressAssertion
@@ -30,10 +33,14 @@ func (spec UniqueEmailAddressAssertion) CustomerID() value.CustomerID {
 	return spec.customerID
 }
 
-func (spec UniqueEmailAddressAssertion) EmailAddressToAdd() value.UnconfirmedEmailAddress {
+func (spec UniqueEmailAddressAssertion) EmailAddressToAdd() value.EmailAddress {
 	return spec.emailAddressToAdd
 }
 
+func (spec UniqueEmailAddressAssertion) EmailAddressToRelease() value.EmailAddress {
+	return spec.emailAddressToRelease
+}
+
 func BuildUniqueEmailAddressAssertions(recordedEvents ...es.DomainEvent) UniqueEmailAddressAssertions {
 	var specifications UniqueEmailAddressAssertions
 
@@ -57,6 +64,42 @@ func BuildUniqueEmailAddressAssertions(recordedEvents ...es.DomainEvent) UniqueE
 					emailAddressToAdd: actualEvent.EmailAddress(),
 				},
 			)
+		case domain.CustomerEmailAddressChangeRequested:
+			specifications = append(
+				specifications,
+				UniqueEmailAddressAssertion{
+					desiredAction:     ShouldReserveUniqueEmailAddress,
+					customerID:        actualEvent.CustomerID(),
+					emailAddressToAdd: actualEvent.EmailAddress(),
+				},
+			)
+		case domain.CustomerEmailAddressChangeCancelled:
+			specifications = append(
+				specifications,
+				UniqueEmailAddressAssertion{
+					desiredAction:         ShouldReleaseUniqueEmailAddress,
+					customerID:            actualEvent.CustomerID(),
+					emailAddressToRelease: actualEvent.EmailAddress(),
+				},
+			)
+		case domain.CustomerEmailAddressChangeConfirmed:
+			specifications = append(
+				specifications,
+				UniqueEmailAddressAssertion{
+					desiredAction:     ShouldReplaceUniqueEmailAddress,
+					customerID:        actualEvent.CustomerID(),
+					emailAddressToAdd: actualEvent.EmailAddress(),
+				},
+			)
+		case domain.CustomerRestored:
+			specifications = append(
+				specifications,
+				UniqueEmailAddressAssertion{
+					desiredAction:     ShouldAddUniqueEmailAddress,
+					customerID:        actualEvent.CustomerID(),
+					emailAddressToAdd: actualEvent.EmailAddress(),
+				},
+			)
 		case domain.CustomerDeleted:
 			specifications = append(
 				specifications,
@@ -65,6 +108,14 @@ func BuildUniqueEmailAddressAssertions(recordedEvents ...es.DomainEvent) UniqueE
 					customerID:    actualEvent.CustomerID(),
 				},
 			)
+		case domain.CustomerPersonalDataErased:
+			specifications = append(
+				specifications,
+				UniqueEmailAddressAssertion{
+					desiredAction: ShouldRemoveUniqueEmailAddress,
+					customerID:    actualEvent.CustomerID(),
+				},
+			)
 		}
 	}
 
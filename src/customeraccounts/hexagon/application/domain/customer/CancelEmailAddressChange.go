@@ -0,0 +1,40 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func CancelEmailAddressChange(
+	eventStream es.EventStream,
+	command domain.CancelCustomerEmailAddressChange,
+) (es.RecordedEvents, error) {
+
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "cancelEmailAddressChange")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "cancelEmailAddressChange")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "cancelEmailAddressChange")
+	}
+
+	if customer.pendingEmailAddress == nil {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerEmailAddressChangeCancelled(
+		command.CustomerID(),
+		*customer.pendingEmailAddress,
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
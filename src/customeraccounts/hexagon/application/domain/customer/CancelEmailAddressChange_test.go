@@ -0,0 +1,137 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCancelEmailAddressChange(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		pendingEmailAddress, err := value.BuildUnconfirmedEmailAddress("latoya@ball.net")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildCancelCustomerEmailAddressChange(customerID, 0)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+		So(err, ShouldBeNil)
+
+		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
+			customerID,
+			confirmedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerEmailAddressChangeRequested := domain.BuildCustomerEmailAddressChangeRequested(
+			customerID,
+			pendingEmailAddress,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Cancel a pending change of a Customer's emailAddress", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("and CustomerEmailAddressChangeRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+						Convey("When CancelCustomerEmailAddressChange", func() {
+							recordedEvents, err = customer.CancelEmailAddressChange(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressChangeCancelled", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								event, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeCancelled)
+								So(ok, ShouldBeTrue)
+								So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+								So(event.EmailAddress().Equals(pendingEmailAddress), ShouldBeTrue)
+								So(event.IsFailureEvent(), ShouldBeFalse)
+								So(event.FailureReason(), ShouldBeNil)
+								So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+								So(event.Meta().MessageID(), ShouldNotBeEmpty)
+								So(event.Meta().StreamVersion(), ShouldEqual, 4)
+
+								Convey("and the pending emailAddress should be gone", func() {
+									view := customer.BuildViewFrom(append(eventStream, event))
+									So(view.EmailAddress, ShouldEqual, confirmedEmailAddress.String())
+									So(view.PendingEmailAddress, ShouldBeEmpty)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Try to cancel a change of a Customer's emailAddress when none is pending", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("When CancelCustomerEmailAddressChange", func() {
+						recordedEvents, err = customer.CancelEmailAddressChange(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to cancel a change of a Customer's emailAddress when the account was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When CancelCustomerEmailAddressChange", func() {
+						_, err = customer.CancelEmailAddressChange(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func ChangeAddress(eventStream es.EventStream, command domain.ChangeCustomerAddress) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "changeCustomerAddress")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "changeCustomerAddress")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "changeCustomerAddress")
+	}
+
+	existingAddress, found := customer.addresses[command.AddressKind()]
+	if !found {
+		err := errors.Newf("customer has no %s address, it must be added instead", command.AddressKind())
+
+		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, "changeCustomerAddress")
+	}
+
+	if existingAddress.Equals(command.Address()) {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerAddressChanged(
+		command.CustomerID(),
+		command.AddressKind(),
+		command.Address(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
@@ -0,0 +1,193 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChangeAddress(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		address, err := value.BuildAddress("Main Street 1", "10115", "Berlin", "DE")
+		So(err, ShouldBeNil)
+		changedAddress, err := value.BuildAddress("Side Street 2", "80331", "Munich", "DE")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildChangeCustomerAddress(customerID, value.AddressKindBilling, changedAddress, 0)
+		commandWithOriginalAddress := domain.BuildChangeCustomerAddress(customerID, value.AddressKindBilling, address, 0)
+		commandWithOutdatedVersion := domain.BuildChangeCustomerAddress(customerID, value.AddressKindBilling, changedAddress, 1)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		billingAddressAdded := domain.BuildCustomerAddressAdded(
+			customerID,
+			value.AddressKindBilling,
+			address,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		billingAddressRemoved := domain.BuildCustomerAddressRemoved(
+			customerID,
+			value.AddressKindBilling,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			3,
+		)
+
+		Convey("\nSCENARIO 1: Change a Customer's billing address", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("When ChangeCustomerAddress", func() {
+						recordedEvents, err = customer.ChangeAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerAddressChanged", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerAddressChanged)
+							So(ok, ShouldBeTrue)
+							So(event, ShouldNotBeNil)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.AddressKind().Equals(value.AddressKindBilling), ShouldBeTrue)
+							So(event.Address().Equals(changedAddress), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().MessageID(), ShouldNotBeEmpty)
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Try to change a Customer's billing address to the value it already has", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("When ChangeCustomerAddress", func() {
+						recordedEvents, err = customer.ChangeAddress(eventStream, commandWithOriginalAddress)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to change a billing address which was never added", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When ChangeCustomerAddress", func() {
+					_, err = customer.ChangeAddress(eventStream, command)
+
+					Convey("Then it should report an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to change a billing address which was removed", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("and CustomerAddressRemoved for the billing address", func() {
+						eventStream = append(eventStream, billingAddressRemoved)
+
+						Convey("When ChangeCustomerAddress", func() {
+							_, err = customer.ChangeAddress(eventStream, command)
+
+							Convey("Then it should report an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to change an address when the account is suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("and CustomerSuspended", func() {
+						eventStream = append(eventStream, customerSuspended)
+
+						Convey("When ChangeCustomerAddress", func() {
+							_, err = customer.ChangeAddress(eventStream, command)
+
+							Convey("Then it should report an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 6: Try to change an address when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the billing address", func() {
+					eventStream = append(eventStream, billingAddressAdded)
+
+					Convey("When ChangeCustomerAddress with the expected version 1", func() {
+						_, err = customer.ChangeAddress(eventStream, commandWithOutdatedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -2,10 +2,14 @@ package customer
 
 import (
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 	"github.com/cockroachdb/errors"
 )
 
+// ChangeEmailAddress replaces an unconfirmed emailAddress right away, because there is nothing to lose.
+// A confirmed emailAddress stays active and the new one is only pending until it is confirmed,
+// so that a typo can't lock the Customer out of her working emailAddress.
 func ChangeEmailAddress(eventStream es.EventStream, command domain.ChangeCustomerEmailAddress) (es.RecordedEvents, error) {
 	customer := buildCurrentStateFrom(eventStream)
 
@@ -13,16 +17,76 @@ func ChangeEmailAddress(eventStream es.EventStream, command domain.ChangeCustome
 		return nil, errors.Wrap(err, "changeEmailAddress")
 	}
 
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "changeEmailAddress")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "changeEmailAddress")
+	}
+
+	switch customer.emailAddress.(type) {
+	case value.ConfirmedEmailAddress:
+		return requestEmailAddressChange(customer, command), nil
+	case value.UnconfirmedEmailAddress:
+		if customer.emailAddress.Equals(command.EmailAddress()) {
+			return nil, nil
+		}
+
+		event := domain.BuildCustomerEmailAddressChanged(
+			command.CustomerID(),
+			command.EmailAddress(),
+			command.MessageID(),
+			customer.currentStreamVersion+1,
+		)
+
+		return es.RecordedEvents{event}, nil
+	default:
+		// until Go has "union types" we need to use an interface and this case could exist - we don't want to hide it
+		panic("ChangeEmailAddress(): emailAddress is neither UnconfirmedEmailAddress nor ConfirmedEmailAddress")
+	}
+}
+
+// requestEmailAddressChange cancels a different pending emailAddress first. Changing back to the active
+// emailAddress only cancels the pending one.
+func requestEmailAddressChange(customer currentState, command domain.ChangeCustomerEmailAddress) es.RecordedEvents {
+	var recordedEvents es.RecordedEvents
+
+	streamVersion := customer.currentStreamVersion
+
+	if customer.pendingEmailAddress != nil {
+		if customer.pendingEmailAddress.Equals(command.EmailAddress()) {
+			return nil
+		}
+
+		streamVersion++
+
+		recordedEvents = append(
+			recordedEvents,
+			domain.BuildCustomerEmailAddressChangeCancelled(
+				command.CustomerID(),
+				*customer.pendingEmailAddress,
+				command.MessageID(),
+				streamVersion,
+			),
+		)
+	}
+
 	if customer.emailAddress.Equals(command.EmailAddress()) {
-		return nil, nil
+		return recordedEvents
 	}
 
-	event := domain.BuildCustomerEmailAddressChanged(
-		command.CustomerID(),
-		command.EmailAddress(),
-		command.MessageID(),
-		customer.currentStreamVersion+1,
+	streamVersion++
+
+	recordedEvents = append(
+		recordedEvents,
+		domain.BuildCustomerEmailAddressChangeRequested(
+			command.CustomerID(),
+			command.EmailAddress(),
+			command.MessageID(),
+			streamVersion,
+		),
 	)
 
-	return es.RecordedEvents{event}, nil
+	return recordedEvents
 }
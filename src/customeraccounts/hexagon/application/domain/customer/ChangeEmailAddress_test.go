@@ -28,11 +28,13 @@ func TestChangeEmailAddress(t *testing.T) {
 		command := domain.BuildChangeCustomerEmailAddress(
 			customerID,
 			changedEmailAddress,
+			0,
 		)
 
 		commandWithOriginalEmailAddress := domain.BuildChangeCustomerEmailAddress(
 			customerID,
 			emailAddress,
+			0,
 		)
 
 		customerRegistered := domain.BuildCustomerRegistered(
@@ -56,6 +58,26 @@ func TestChangeEmailAddress(t *testing.T) {
 			2,
 		)
 
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+		So(err, ShouldBeNil)
+
+		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
+			customerID,
+			confirmedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		otherEmailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.net")
+		So(err, ShouldBeNil)
+
+		customerEmailAddressChangeRequested := domain.BuildCustomerEmailAddressChangeRequested(
+			customerID,
+			otherEmailAddress,
+			es.GenerateMessageID(),
+			3,
+		)
+
 		Convey("\nSCENARIO 1: Change a Customer's emailAddress", func() {
 			Convey("Given CustomerRegistered", func() {
 				eventStream := es.EventStream{customerRegistered}
@@ -134,5 +156,93 @@ func TestChangeEmailAddress(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("\nSCENARIO 5: Change a Customer's confirmed emailAddress", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("When ChangeCustomerEmailAddress", func() {
+						recordedEvents, err = customer.ChangeEmailAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerEmailAddressChangeRequested", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeRequested)
+							So(ok, ShouldBeTrue)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.EmailAddress().Equals(changedEmailAddress), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+
+							Convey("and the confirmed emailAddress should stay active", func() {
+								view := customer.BuildViewFrom(append(eventStream, event))
+								So(view.EmailAddress, ShouldEqual, confirmedEmailAddress.String())
+								So(view.IsEmailAddressConfirmed, ShouldBeTrue)
+								So(view.PendingEmailAddress, ShouldEqual, changedEmailAddress.String())
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 6: Change a Customer's confirmed emailAddress while another change is pending", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("and CustomerEmailAddressChangeRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+						Convey("When ChangeCustomerEmailAddress", func() {
+							recordedEvents, err = customer.ChangeEmailAddress(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressChangeCancelled and CustomerEmailAddressChangeRequested", func() {
+								So(recordedEvents, ShouldHaveLength, 2)
+								cancelled, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeCancelled)
+								So(ok, ShouldBeTrue)
+								So(cancelled.EmailAddress().Equals(otherEmailAddress), ShouldBeTrue)
+								So(cancelled.Meta().StreamVersion(), ShouldEqual, 4)
+								requested, ok := recordedEvents[1].(domain.CustomerEmailAddressChangeRequested)
+								So(ok, ShouldBeTrue)
+								So(requested.EmailAddress().Equals(changedEmailAddress), ShouldBeTrue)
+								So(requested.Meta().StreamVersion(), ShouldEqual, 5)
+							})
+						})
+
+						Convey("When ChangeCustomerEmailAddress to the pending emailAddress", func() {
+							recordedEvents, err = customer.ChangeEmailAddress(
+								eventStream,
+								domain.BuildChangeCustomerEmailAddress(customerID, otherEmailAddress, 0),
+							)
+							So(err, ShouldBeNil)
+
+							Convey("Then no event", func() {
+								So(recordedEvents, ShouldBeEmpty)
+							})
+						})
+
+						Convey("When ChangeCustomerEmailAddress back to the confirmed emailAddress", func() {
+							recordedEvents, err = customer.ChangeEmailAddress(eventStream, commandWithOriginalEmailAddress)
+							So(err, ShouldBeNil)
+
+							Convey("Then only CustomerEmailAddressChangeCancelled", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								_, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeCancelled)
+								So(ok, ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
 	})
 }
@@ -13,6 +13,14 @@ func ChangeName(eventStream es.EventStream, command domain.ChangeCustomerName) (
 		return nil, errors.Wrap(err, "changeCustomerName")
 	}
 
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "changeCustomerName")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "changeCustomerName")
+	}
+
 	if customer.personName.Equals(command.PersonName()) {
 		return nil, nil
 	}
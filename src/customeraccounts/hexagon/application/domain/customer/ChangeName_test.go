@@ -25,8 +25,9 @@ func TestChangeName(t *testing.T) {
 		changedPersonName, err := value.BuildPersonName("Latoya", "Ball")
 		So(err, ShouldBeNil)
 
-		command := domain.BuildChangeCustomerName(customerID, changedPersonName)
-		commandWithOriginalName := domain.BuildChangeCustomerName(customerID, personName)
+		command := domain.BuildChangeCustomerName(customerID, changedPersonName, 0)
+		commandWithOriginalName := domain.BuildChangeCustomerName(customerID, personName, 0)
+		commandWithExpectedVersion := domain.BuildChangeCustomerName(customerID, changedPersonName, 1)
 
 		customerRegistered := domain.BuildCustomerRegistered(
 			customerID,
@@ -36,6 +37,13 @@ func TestChangeName(t *testing.T) {
 			1,
 		)
 
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			2,
+		)
+
 		customerDeleted := domain.BuildCustomerDeleted(
 			customerID,
 			es.GenerateMessageID(),
@@ -129,5 +137,66 @@ func TestChangeName(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("\nSCENARIO 5: Change a Customer's name with the expected version", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When ChangeCustomerName with the expected version 1", func() {
+					recordedEvents, err = customer.ChangeName(eventStream, commandWithExpectedVersion)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerNameChanged", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						So(recordedEvents[0].Meta().StreamVersion(), ShouldEqual, 2)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 6: Try to change a Customer's name when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerNameChanged", func() {
+					nameChanged := domain.BuildCustomerNameChanged(
+						customerID,
+						personName,
+						es.GenerateMessageID(),
+						2,
+					)
+
+					eventStream = append(eventStream, nameChanged)
+
+					Convey("When ChangeCustomerName with the expected version 1", func() {
+						_, err := customer.ChangeName(eventStream, commandWithExpectedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 7: Try to change a Customer's name when the account is suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When ChangeCustomerName", func() {
+						_, err = customer.ChangeName(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,38 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// ChangePhoneNumber replaces the phoneNumber right away, also a confirmed one, because nobody logs in with it.
+// The new phoneNumber is unconfirmed until the Customer confirms it with the ConfirmationCode she receives.
+func ChangePhoneNumber(eventStream es.EventStream, command domain.ChangeCustomerPhoneNumber) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "changePhoneNumber")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "changePhoneNumber")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "changePhoneNumber")
+	}
+
+	if customer.phoneNumber != nil && customer.phoneNumber.Equals(command.PhoneNumber()) {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerPhoneNumberChanged(
+		command.CustomerID(),
+		command.PhoneNumber(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
@@ -0,0 +1,182 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChangePhoneNumber(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("+49 30 12345678")
+		So(err, ShouldBeNil)
+		changedPhoneNumber, err := value.BuildUnconfirmedPhoneNumber("+44 20 79460958")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildChangeCustomerPhoneNumber(customerID, phoneNumber, 0)
+		commandWithChangedPhoneNumber := domain.BuildChangeCustomerPhoneNumber(customerID, changedPhoneNumber, 0)
+		commandWithOutdatedVersion := domain.BuildChangeCustomerPhoneNumber(customerID, phoneNumber, 1)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		phoneNumberChanged := domain.BuildCustomerPhoneNumberChanged(
+			customerID,
+			phoneNumber,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		phoneNumberConfirmed := domain.BuildCustomerPhoneNumberConfirmed(
+			customerID,
+			value.RebuildConfirmedPhoneNumber(phoneNumber.String()),
+			es.GenerateMessageID(),
+			3,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: A Customer without a phone number adds one", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When ChangeCustomerPhoneNumber", func() {
+					recordedEvents, err = customer.ChangePhoneNumber(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerPhoneNumberChanged", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerPhoneNumberChanged)
+						So(ok, ShouldBeTrue)
+						So(event, ShouldNotBeNil)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.PhoneNumber().Equals(phoneNumber), ShouldBeTrue)
+						So(event.PhoneNumber().ConfirmationCode().Equals(phoneNumber.ConfirmationCode()), ShouldBeTrue)
+						So(event.IsFailureEvent(), ShouldBeFalse)
+						So(event.FailureReason(), ShouldBeNil)
+						So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+						So(event.Meta().MessageID(), ShouldNotBeEmpty)
+						So(event.Meta().StreamVersion(), ShouldEqual, 2)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: A Customer changes her confirmed phone number", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("and CustomerPhoneNumberConfirmed", func() {
+						eventStream = append(eventStream, phoneNumberConfirmed)
+
+						Convey("When ChangeCustomerPhoneNumber with another phone number", func() {
+							recordedEvents, err = customer.ChangePhoneNumber(eventStream, commandWithChangedPhoneNumber)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerPhoneNumberChanged", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								event, ok := recordedEvents[0].(domain.CustomerPhoneNumberChanged)
+								So(ok, ShouldBeTrue)
+								So(event.PhoneNumber().Equals(changedPhoneNumber), ShouldBeTrue)
+								So(event.Meta().StreamVersion(), ShouldEqual, 4)
+							})
+						})
+
+						Convey("When ChangeCustomerPhoneNumber with the same phone number", func() {
+							recordedEvents, err = customer.ChangePhoneNumber(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then no event", func() {
+								So(recordedEvents, ShouldBeEmpty)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to change a Customer's phone number to the unconfirmed one she already has", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("When ChangeCustomerPhoneNumber", func() {
+						recordedEvents, err = customer.ChangePhoneNumber(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to change a Customer's phone number when the account is suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When ChangeCustomerPhoneNumber", func() {
+						_, err = customer.ChangePhoneNumber(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to change a Customer's phone number when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("When ChangeCustomerPhoneNumber with the expected version 1", func() {
+						_, err = customer.ChangePhoneNumber(eventStream, commandWithOutdatedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
+// ConfirmEmailAddress confirms the pending emailAddress, if there is one, otherwise the unconfirmed active one.
 func ConfirmEmailAddress(eventStream es.EventStream, command domain.ConfirmCustomerEmailAddress) (es.RecordedEvents, error) {
 	customer := buildCurrentStateFrom(eventStream)
 
@@ -14,22 +15,55 @@ func ConfirmEmailAddress(eventStream es.EventStream, command domain.ConfirmCusto
 		return nil, errors.Wrap(err, "confirmEmailAddress")
 	}
 
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "confirmEmailAddress")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "confirmEmailAddress")
+	}
+
 	switch actualEmailAddress := customer.emailAddress.(type) {
 	case value.ConfirmedEmailAddress:
-		return nil, nil
+		if customer.pendingEmailAddress == nil {
+			return nil, nil
+		}
+
+		if err := assertConfirmationNotLockedOut(customer, command.MaxFailedAttempts(), command.LockoutDuration()); err != nil {
+			return nil, errors.Wrap(err, "confirmEmailAddress")
+		}
+
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(
+			*customer.pendingEmailAddress,
+			command.ConfirmationHash(),
+			command.ConfirmationHashTTL(),
+		)
+
+		if err != nil {
+			return confirmationFailed(customer, command, err), nil
+		}
+
+		return es.RecordedEvents{
+			domain.BuildCustomerEmailAddressChangeConfirmed(
+				command.CustomerID(),
+				confirmedEmailAddress,
+				command.MessageID(),
+				customer.currentStreamVersion+1,
+			),
+		}, nil
 	case value.UnconfirmedEmailAddress:
-		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(actualEmailAddress, command.ConfirmationHash())
+		if err := assertConfirmationNotLockedOut(customer, command.MaxFailedAttempts(), command.LockoutDuration()); err != nil {
+			return nil, errors.Wrap(err, "confirmEmailAddress")
+		}
+
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(
+			actualEmailAddress,
+			command.ConfirmationHash(),
+			command.ConfirmationHashTTL(),
+		)
 
 		if err != nil {
-			return es.RecordedEvents{
-				domain.BuildCustomerEmailAddressConfirmationFailed(
-					command.CustomerID(),
-					command.ConfirmationHash(),
-					err,
-					command.MessageID(),
-					customer.currentStreamVersion+1,
-				),
-			}, nil
+			return confirmationFailed(customer, command, err), nil
 		}
 
 		return es.RecordedEvents{
@@ -45,3 +79,20 @@ func ConfirmEmailAddress(eventStream es.EventStream, command domain.ConfirmCusto
 		panic("ConfirmEmailAddress(): emailAddress is neither UnconfirmedEmailAddress nor ConfirmedEmailAddress")
 	}
 }
+
+func confirmationFailed(
+	customer currentState,
+	command domain.ConfirmCustomerEmailAddress,
+	reason error,
+) es.RecordedEvents {
+
+	return es.RecordedEvents{
+		domain.BuildCustomerEmailAddressConfirmationFailed(
+			command.CustomerID(),
+			command.ConfirmationHash(),
+			reason,
+			command.MessageID(),
+			customer.currentStreamVersion+1,
+		),
+	}
+}
@@ -2,6 +2,7 @@ package customer_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
@@ -24,8 +25,9 @@ func TestConfirmEmailAddress(t *testing.T) {
 		personName, err := value.BuildPersonName("Kevin", "Ball")
 		So(err, ShouldBeNil)
 
-		command := domain.BuildConfirmCustomerEmailAddress(customerID, emailAddress.ConfirmationHash())
-		commandWithInvalidHash := domain.BuildConfirmCustomerEmailAddress(customerID, invalidConfirmationHash)
+		confirmationHashTTL := time.Hour
+		command := domain.BuildConfirmCustomerEmailAddress(customerID, emailAddress.ConfirmationHash(), confirmationHashTTL, 0, 0, 0)
+		commandWithInvalidHash := domain.BuildConfirmCustomerEmailAddress(customerID, invalidConfirmationHash, confirmationHashTTL, 0, 0, 0)
 
 		customerRegistered := domain.BuildCustomerRegistered(
 			customerID,
@@ -35,7 +37,7 @@ func TestConfirmEmailAddress(t *testing.T) {
 			1,
 		)
 
-		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash())
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
 		So(err, ShouldBeNil)
 
 		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
@@ -51,6 +53,30 @@ func TestConfirmEmailAddress(t *testing.T) {
 			2,
 		)
 
+		customerRegisteredWithExpiredHash := domain.BuildCustomerRegistered(
+			customerID,
+			value.RebuildUnconfirmedEmailAddress(
+				emailAddress.String(),
+				emailAddress.ConfirmationHash().String(),
+				time.Now().Add(-confirmationHashTTL-time.Minute),
+			),
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerRegisteredWithoutHashCreationTime := domain.BuildCustomerRegistered(
+			customerID,
+			value.RebuildUnconfirmedEmailAddress(
+				emailAddress.String(),
+				emailAddress.ConfirmationHash().String(),
+				time.Time{},
+			),
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
 		Convey("\nSCENARIO 1: ConfirmEmailAddress a Customer's emailAddress with the right confirmationHash", func() {
 			Convey("Given CustomerRegistered", func() {
 				eventStream := es.EventStream{customerRegistered}
@@ -153,6 +179,45 @@ func TestConfirmEmailAddress(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("\nSCENARIO 7: ConfirmEmailAddress a Customer's emailAddress with an expired confirmationHash", func() {
+			Convey("Given CustomerRegistered with a confirmationHash older than its TTL", func() {
+				eventStream := es.EventStream{customerRegisteredWithExpiredHash}
+
+				Convey("When ConfirmCustomerEmailAddress", func() {
+					recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerEmailAddressConfirmationFailed", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmationFailed)
+						So(ok, ShouldBeTrue)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.IsFailureEvent(), ShouldBeTrue)
+						So(event.FailureReason(), ShouldBeError)
+						So(event.FailureReason().Error(), ShouldContainSubstring, "expired")
+						So(event.Meta().StreamVersion(), ShouldEqual, 2)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 8: ConfirmEmailAddress a Customer's emailAddress with a confirmationHash recorded without creation time", func() {
+			Convey("Given CustomerRegistered without a confirmationHash creation time", func() {
+				eventStream := es.EventStream{customerRegisteredWithoutHashCreationTime}
+
+				Convey("When ConfirmCustomerEmailAddress", func() {
+					recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerEmailAddressConfirmed", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						_, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmed)
+						So(ok, ShouldBeTrue)
+					})
+				})
+			})
+		})
 	})
 }
 
@@ -169,7 +234,7 @@ func TestConfirmEmailAddressAfterItWasChanged(t *testing.T) {
 		personName, err := value.BuildPersonName("Kevin", "Ball")
 		So(err, ShouldBeNil)
 
-		command := domain.BuildConfirmCustomerEmailAddress(customerID, changedEmailAddress.ConfirmationHash())
+		command := domain.BuildConfirmCustomerEmailAddress(customerID, changedEmailAddress.ConfirmationHash(), time.Hour, 0, 0, 0)
 
 		customerRegistered := domain.BuildCustomerRegistered(
 			customerID,
@@ -179,7 +244,7 @@ func TestConfirmEmailAddressAfterItWasChanged(t *testing.T) {
 			1,
 		)
 
-		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash())
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
 		So(err, ShouldBeNil)
 
 		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
@@ -229,3 +294,236 @@ func TestConfirmEmailAddressAfterItWasChanged(t *testing.T) {
 		})
 	})
 }
+
+func TestConfirmPendingEmailAddress(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		pendingEmailAddress, err := value.BuildUnconfirmedEmailAddress("latoya@ball.net")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildConfirmCustomerEmailAddress(customerID, pendingEmailAddress.ConfirmationHash(), time.Hour, 0, 0, 0)
+		commandWithActiveHash := domain.BuildConfirmCustomerEmailAddress(customerID, emailAddress.ConfirmationHash(), time.Hour, 0, 0, 0)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+		So(err, ShouldBeNil)
+
+		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
+			customerID,
+			confirmedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerEmailAddressChangeRequested := domain.BuildCustomerEmailAddressChangeRequested(
+			customerID,
+			pendingEmailAddress,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		Convey("\nSCENARIO 1: ConfirmEmailAddress a Customer's pending emailAddress with the right confirmationHash", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("and CustomerEmailAddressChangeRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+						Convey("When ConfirmCustomerEmailAddress", func() {
+							recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressChangeConfirmed", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								event, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeConfirmed)
+								So(ok, ShouldBeTrue)
+								So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+								So(event.EmailAddress().Equals(pendingEmailAddress), ShouldBeTrue)
+								So(event.IsFailureEvent(), ShouldBeFalse)
+								So(event.FailureReason(), ShouldBeNil)
+								So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+								So(event.Meta().StreamVersion(), ShouldEqual, 4)
+
+								Convey("and the pending emailAddress should be the active one", func() {
+									view := customer.BuildViewFrom(append(eventStream, event))
+									So(view.EmailAddress, ShouldEqual, pendingEmailAddress.String())
+									So(view.IsEmailAddressConfirmed, ShouldBeTrue)
+									So(view.PendingEmailAddress, ShouldBeEmpty)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: ConfirmEmailAddress a Customer's pending emailAddress with the hash of the active emailAddress", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("and CustomerEmailAddressChangeRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+						Convey("When ConfirmCustomerEmailAddress", func() {
+							recordedEvents, err = customer.ConfirmEmailAddress(eventStream, commandWithActiveHash)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressConfirmationFailed", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								event, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmationFailed)
+								So(ok, ShouldBeTrue)
+								So(event.IsFailureEvent(), ShouldBeTrue)
+
+								Convey("and the confirmed emailAddress should stay active", func() {
+									view := customer.BuildViewFrom(append(eventStream, event))
+									So(view.EmailAddress, ShouldEqual, confirmedEmailAddress.String())
+									So(view.PendingEmailAddress, ShouldEqual, pendingEmailAddress.String())
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestConfirmEmailAddressLockout(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		maxFailedAttempts := uint(2)
+		lockoutDuration := 15 * time.Minute
+
+		command := domain.BuildConfirmCustomerEmailAddress(
+			customerID,
+			emailAddress.ConfirmationHash(),
+			0,
+			maxFailedAttempts,
+			lockoutDuration,
+			0,
+		)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		failedAt := func(occurredAt time.Time, streamVersion uint) domain.CustomerEmailAddressConfirmationFailed {
+			return domain.RebuildCustomerEmailAddressConfirmationFailed(
+				customerID.String(),
+				"invalid_hash",
+				"wrong confirmation hash supplied",
+				es.RebuildEventMeta(
+					"CustomerEmailAddressConfirmationFailed",
+					occurredAt.Format(time.RFC3339Nano),
+					es.GenerateMessageID().String(),
+					es.GenerateMessageID().String(),
+					streamVersion,
+				),
+			)
+		}
+
+		customerEmailAddressConfirmationResendRequested := domain.BuildCustomerEmailAddressConfirmationResendRequested(
+			customerID,
+			emailAddress,
+			es.GenerateMessageID(),
+			4,
+		)
+
+		Convey("\nSCENARIO 1: Try to confirm a Customer's emailAddress after too many failed attempts", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmationFailed twice within the lockout duration", func() {
+					eventStream = append(eventStream, failedAt(time.Now().Add(-time.Minute), 2), failedAt(time.Now(), 3))
+
+					Convey("When ConfirmCustomerEmailAddress with the right confirmationHash", func() {
+						recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+
+					Convey("and when a snapshot was taken", func() {
+						eventStream = es.EventStream{customer.BuildSnapshotFrom(eventStream)}
+
+						Convey("When ConfirmCustomerEmailAddress with the right confirmationHash", func() {
+							_, err = customer.ConfirmEmailAddress(eventStream, command)
+
+							Convey("Then it should still report an error", func() {
+								So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							})
+						})
+					})
+
+					Convey("and CustomerEmailAddressConfirmationResendRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressConfirmationResendRequested)
+
+						Convey("When ConfirmCustomerEmailAddress with the new confirmationHash", func() {
+							recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressConfirmed", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								_, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmed)
+								So(ok, ShouldBeTrue)
+							})
+						})
+					})
+				})
+
+				Convey("and CustomerEmailAddressConfirmationFailed twice, but longer ago than the lockout duration", func() {
+					eventStream = append(
+						eventStream,
+						failedAt(time.Now().Add(-lockoutDuration-time.Minute), 2),
+						failedAt(time.Now().Add(-lockoutDuration-time.Second), 3),
+					)
+
+					Convey("When ConfirmCustomerEmailAddress with the right confirmationHash", func() {
+						recordedEvents, err = customer.ConfirmEmailAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerEmailAddressConfirmed", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							_, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmed)
+							So(ok, ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
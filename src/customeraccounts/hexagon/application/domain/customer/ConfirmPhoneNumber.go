@@ -0,0 +1,68 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func ConfirmPhoneNumber(eventStream es.EventStream, command domain.ConfirmCustomerPhoneNumber) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "confirmPhoneNumber")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "confirmPhoneNumber")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "confirmPhoneNumber")
+	}
+
+	switch actualPhoneNumber := customer.phoneNumber.(type) {
+	case nil:
+		err := errors.New("customer has no phone number")
+
+		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, "confirmPhoneNumber")
+	case value.ConfirmedPhoneNumber:
+		return nil, nil
+	case value.UnconfirmedPhoneNumber:
+		if err := assertPhoneNumberConfirmationNotLockedOut(customer, command.MaxFailedAttempts(), command.LockoutDuration()); err != nil {
+			return nil, errors.Wrap(err, "confirmPhoneNumber")
+		}
+
+		confirmedPhoneNumber, err := value.ConfirmPhoneNumberWithCode(
+			actualPhoneNumber,
+			command.ConfirmationCode(),
+			command.ConfirmationCodeTTL(),
+		)
+
+		if err != nil {
+			return es.RecordedEvents{
+				domain.BuildCustomerPhoneNumberConfirmationFailed(
+					command.CustomerID(),
+					command.ConfirmationCode(),
+					err,
+					command.MessageID(),
+					customer.currentStreamVersion+1,
+				),
+			}, nil
+		}
+
+		return es.RecordedEvents{
+			domain.BuildCustomerPhoneNumberConfirmed(
+				command.CustomerID(),
+				confirmedPhoneNumber,
+				command.MessageID(),
+				customer.currentStreamVersion+1,
+			),
+		}, nil
+	default:
+		// until Go has "union types" we need to use an interface and this case could exist - we don't want to hide it
+		panic("ConfirmPhoneNumber(): phoneNumber is neither UnconfirmedPhoneNumber nor ConfirmedPhoneNumber")
+	}
+}
@@ -0,0 +1,249 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfirmPhoneNumber(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		phoneNumber := value.RebuildUnconfirmedPhoneNumber("+493012345678", "123456", time.Now().UTC())
+		wrongConfirmationCode := value.RebuildConfirmationCode("654321")
+
+		confirmationCodeTTL := 15 * time.Minute
+		maxFailedAttempts := uint(3)
+		lockoutDuration := time.Hour
+
+		command := domain.BuildConfirmCustomerPhoneNumber(
+			customerID,
+			phoneNumber.ConfirmationCode(),
+			confirmationCodeTTL,
+			maxFailedAttempts,
+			lockoutDuration,
+			0,
+		)
+
+		commandWithWrongCode := domain.BuildConfirmCustomerPhoneNumber(
+			customerID,
+			wrongConfirmationCode,
+			confirmationCodeTTL,
+			maxFailedAttempts,
+			lockoutDuration,
+			0,
+		)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		phoneNumberChanged := domain.BuildCustomerPhoneNumberChanged(
+			customerID,
+			phoneNumber,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		phoneNumberChangedWithExpiredCode := domain.BuildCustomerPhoneNumberChanged(
+			customerID,
+			value.RebuildUnconfirmedPhoneNumber(
+				phoneNumber.String(),
+				phoneNumber.ConfirmationCode().String(),
+				time.Now().Add(-confirmationCodeTTL-time.Minute).UTC(),
+			),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		phoneNumberConfirmed := domain.BuildCustomerPhoneNumberConfirmed(
+			customerID,
+			value.RebuildConfirmedPhoneNumber(phoneNumber.String()),
+			es.GenerateMessageID(),
+			3,
+		)
+
+		Convey("\nSCENARIO 1: Confirm a Customer's phone number with the right confirmation code", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("When ConfirmCustomerPhoneNumber", func() {
+						recordedEvents, err = customer.ConfirmPhoneNumber(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerPhoneNumberConfirmed", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerPhoneNumberConfirmed)
+							So(ok, ShouldBeTrue)
+							So(event, ShouldNotBeNil)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.PhoneNumber().Equals(phoneNumber), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().MessageID(), ShouldNotBeEmpty)
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Confirm a Customer's phone number with a wrong confirmation code", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("When ConfirmCustomerPhoneNumber with a wrong confirmation code", func() {
+						recordedEvents, err = customer.ConfirmPhoneNumber(eventStream, commandWithWrongCode)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerPhoneNumberConfirmationFailed", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerPhoneNumberConfirmationFailed)
+							So(ok, ShouldBeTrue)
+							So(event.ConfirmationCode().Equals(wrongConfirmationCode), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeTrue)
+							So(errors.Is(event.FailureReason(), shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Confirm a Customer's phone number with an expired confirmation code", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged with a confirmation code which expired", func() {
+					eventStream = append(eventStream, phoneNumberChangedWithExpiredCode)
+
+					Convey("When ConfirmCustomerPhoneNumber", func() {
+						recordedEvents, err = customer.ConfirmPhoneNumber(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerPhoneNumberConfirmationFailed", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							_, ok := recordedEvents[0].(domain.CustomerPhoneNumberConfirmationFailed)
+							So(ok, ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to confirm a Customer's phone number again", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("and CustomerPhoneNumberConfirmed", func() {
+						eventStream = append(eventStream, phoneNumberConfirmed)
+
+						Convey("When ConfirmCustomerPhoneNumber", func() {
+							recordedEvents, err = customer.ConfirmPhoneNumber(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then no event", func() {
+								So(recordedEvents, ShouldBeEmpty)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to confirm the phone number of a Customer who has none", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When ConfirmCustomerPhoneNumber", func() {
+					_, err = customer.ConfirmPhoneNumber(eventStream, command)
+
+					Convey("Then it should report an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 6: Try to confirm a Customer's phone number after too many failed attempts", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPhoneNumberChanged", func() {
+					eventStream = append(eventStream, phoneNumberChanged)
+
+					Convey("and 3 times CustomerPhoneNumberConfirmationFailed", func() {
+						for streamVersion := uint(3); streamVersion <= 5; streamVersion++ {
+							eventStream = append(
+								eventStream,
+								domain.BuildCustomerPhoneNumberConfirmationFailed(
+									customerID,
+									wrongConfirmationCode,
+									errors.New("wrong confirmation code supplied"),
+									es.GenerateMessageID(),
+									streamVersion,
+								),
+							)
+						}
+
+						Convey("When ConfirmCustomerPhoneNumber with the right confirmation code", func() {
+							_, err = customer.ConfirmPhoneNumber(eventStream, command)
+
+							Convey("Then it should report an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+							})
+						})
+
+						Convey("and when the phone number was changed meanwhile", func() {
+							eventStream = append(
+								eventStream,
+								domain.BuildCustomerPhoneNumberChanged(customerID, phoneNumber, es.GenerateMessageID(), 6),
+							)
+
+							Convey("When ConfirmCustomerPhoneNumber with the right confirmation code", func() {
+								recordedEvents, err = customer.ConfirmPhoneNumber(eventStream, command)
+								So(err, ShouldBeNil)
+
+								Convey("Then CustomerPhoneNumberConfirmed", func() {
+									So(recordedEvents, ShouldHaveLength, 1)
+									_, ok := recordedEvents[0].(domain.CustomerPhoneNumberConfirmed)
+									So(ok, ShouldBeTrue)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -3,13 +3,23 @@ package customer
 import (
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
 )
 
-func Delete(eventStream es.EventStream, command domain.DeleteCustomer) es.RecordedEvents {
+// Delete is rejected while the Customer is suspended, so the support team has to reactivate her first.
+func Delete(eventStream es.EventStream, command domain.DeleteCustomer) (es.RecordedEvents, error) {
 	customer := buildCurrentStateFrom(eventStream)
 
 	if err := assertNotDeleted(customer); err != nil {
-		return nil
+		return nil, nil
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "deleteCustomer")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "deleteCustomer")
 	}
 
 	event := domain.BuildCustomerDeleted(
@@ -18,5 +28,5 @@ func Delete(eventStream es.EventStream, command domain.DeleteCustomer) es.Record
 		customer.currentStreamVersion+1,
 	)
 
-	return es.RecordedEvents{event}
+	return es.RecordedEvents{event}, nil
 }
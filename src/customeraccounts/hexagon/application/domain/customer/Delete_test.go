@@ -6,7 +6,9 @@ import (
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -21,7 +23,8 @@ func TestDelete(t *testing.T) {
 		personName, err := value.BuildPersonName("Kevin", "Ball")
 		So(err, ShouldBeNil)
 
-		command := domain.BuildDeleteCustomer(customerID)
+		command := domain.BuildDeleteCustomer(customerID, 0)
+		commandWithOutdatedVersion := domain.BuildDeleteCustomer(customerID, 2)
 
 		customerRegistered := domain.BuildCustomerRegistered(
 			customerID,
@@ -31,6 +34,13 @@ func TestDelete(t *testing.T) {
 			1,
 		)
 
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			2,
+		)
+
 		customerDeleted := domain.BuildCustomerDeleted(
 			customerID,
 			es.GenerateMessageID(),
@@ -42,7 +52,8 @@ func TestDelete(t *testing.T) {
 				eventStream := es.EventStream{customerRegistered}
 
 				Convey("When DeleteCustomer", func() {
-					recordedEvents = customer.Delete(eventStream, command)
+					recordedEvents, err = customer.Delete(eventStream, command)
+					So(err, ShouldBeNil)
 
 					Convey("Then CustomerDeleted", func() {
 						So(recordedEvents, ShouldHaveLength, 1)
@@ -68,7 +79,8 @@ func TestDelete(t *testing.T) {
 					eventStream = append(eventStream, customerDeleted)
 
 					Convey("When DeleteCustomer", func() {
-						recordedEvents = customer.Delete(eventStream, command)
+						recordedEvents, err = customer.Delete(eventStream, command)
+						So(err, ShouldBeNil)
 
 						Convey("Then no Event", func() {
 							So(recordedEvents, ShouldBeEmpty)
@@ -77,5 +89,39 @@ func TestDelete(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("\nSCENARIO 3: Try to delete a Customer's account when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When DeleteCustomer with the expected version 2", func() {
+					_, err = customer.Delete(eventStream, commandWithOutdatedVersion)
+
+					Convey("Then it should report a version mismatch", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to delete a Customer's account when it is suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When DeleteCustomer", func() {
+						_, err = customer.Delete(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,29 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// ErasePersonalData also deletes the Customer, because there is nothing left to work with.
+// It's possible while the Customer is suspended or deleted, so that a request for erasure can always be fulfilled.
+func ErasePersonalData(eventStream es.EventStream, command domain.EraseCustomerPersonalData) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if customer.isPersonalDataErased {
+		return nil, nil
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "eraseCustomerPersonalData")
+	}
+
+	event := domain.BuildCustomerPersonalDataErased(
+		command.CustomerID(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
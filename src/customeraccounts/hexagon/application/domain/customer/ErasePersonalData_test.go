@@ -0,0 +1,158 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErasePersonalData(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildEraseCustomerPersonalData(customerID, 0)
+		commandWithOutdatedVersion := domain.BuildEraseCustomerPersonalData(customerID, 2)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			value.RebuildSuspensionReason("suspicion of fraud"),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerPersonalDataErased := domain.BuildCustomerPersonalDataErased(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Erase a Customer's personal data", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When EraseCustomerPersonalData", func() {
+					recordedEvents, err = customer.ErasePersonalData(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerPersonalDataErased", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerPersonalDataErased)
+						So(ok, ShouldBeTrue)
+						So(event, ShouldNotBeNil)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.IsFailureEvent(), ShouldBeFalse)
+						So(event.FailureReason(), ShouldBeNil)
+						So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+						So(event.Meta().MessageID(), ShouldNotBeEmpty)
+						So(event.Meta().StreamVersion(), ShouldEqual, uint(2))
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Erase the personal data of a deleted Customer", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When EraseCustomerPersonalData", func() {
+						recordedEvents, err = customer.ErasePersonalData(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerPersonalDataErased", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerPersonalDataErased)
+							So(ok, ShouldBeTrue)
+							So(event.Meta().StreamVersion(), ShouldEqual, uint(3))
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Erase the personal data of a suspended Customer", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When EraseCustomerPersonalData", func() {
+						recordedEvents, err = customer.ErasePersonalData(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerPersonalDataErased", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerPersonalDataErased)
+							So(ok, ShouldBeTrue)
+							So(event.Meta().StreamVersion(), ShouldEqual, uint(3))
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to erase a Customer's personal data again", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerPersonalDataErased", func() {
+					eventStream = append(eventStream, customerPersonalDataErased)
+
+					Convey("When EraseCustomerPersonalData", func() {
+						recordedEvents, err = customer.ErasePersonalData(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then no Event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to erase a Customer's personal data when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When EraseCustomerPersonalData with the expected version 2", func() {
+					_, err = customer.ErasePersonalData(eventStream, commandWithOutdatedVersion)
+
+					Convey("Then it should report a version mismatch", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,224 @@
+package customer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	DefaultEventHistoryPageSize = 100
+	MaxEventHistoryPageSize     = 1000
+
+	redactedMarker = "***"
+)
+
+type EventHistoryRange struct {
+	FromVersion uint
+	MaxEvents   uint
+}
+
+// EventHistoryPage has no NextFromVersion if it is the last page.
+type EventHistoryPage struct {
+	Events          []HistoricEvent
+	NextFromVersion uint
+}
+
+type HistoricEvent struct {
+	Name          string
+	StreamVersion uint
+	OccurredAt    string
+	MessageID     string
+	CausationID   string
+	IsFailure     bool
+	Payload       map[string]string
+}
+
+// BuildEventHistoryRange starts with the first event if fromVersion is 0 and uses the default page size if maxEvents is 0.
+func BuildEventHistoryRange(fromVersion uint, maxEvents uint) (EventHistoryRange, error) {
+	if maxEvents > MaxEventHistoryPageSize {
+		err := errors.Newf("maxEvents must not be greater than %d", MaxEventHistoryPageSize)
+		return EventHistoryRange{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "BuildEventHistoryRange")
+	}
+
+	if fromVersion == 0 {
+		fromVersion = 1
+	}
+
+	if maxEvents == 0 {
+		maxEvents = DefaultEventHistoryPageSize
+	}
+
+	return EventHistoryRange{FromVersion: fromVersion, MaxEvents: maxEvents}, nil
+}
+
+// BuildEventHistoryPageFrom expects one more event than MaxEvents if there is a next page, it starts the next page.
+func BuildEventHistoryPageFrom(historyRange EventHistoryRange, eventStream es.EventStream) EventHistoryPage {
+	page := EventHistoryPage{}
+
+	if uint(len(eventStream)) > historyRange.MaxEvents {
+		page.NextFromVersion = eventStream[historyRange.MaxEvents].Meta().StreamVersion()
+		eventStream = eventStream[:historyRange.MaxEvents]
+	}
+
+	page.Events = make([]HistoricEvent, 0, len(eventStream))
+
+	for _, event := range eventStream {
+		page.Events = append(
+			page.Events,
+			HistoricEvent{
+				Name:          event.Meta().EventName(),
+				StreamVersion: event.Meta().StreamVersion(),
+				OccurredAt:    event.Meta().OccurredAt(),
+				MessageID:     event.Meta().MessageID(),
+				CausationID:   event.Meta().CausationID(),
+				IsFailure:     event.IsFailureEvent(),
+				Payload:       redactedPayloadOf(event),
+			},
+		)
+	}
+
+	return page
+}
+
+// redactedPayloadOf only reveals as much personal data as support needs to recognize it, and no secrets at all,
+// so the confirmation hashes and codes are left out.
+func redactedPayloadOf(event es.DomainEvent) map[string]string {
+	switch actualEvent := event.(type) {
+	case domain.CustomerRegistered:
+		return map[string]string{
+			"customerID":       actualEvent.CustomerID().String(),
+			"emailAddress":     redactEmailAddress(actualEvent.EmailAddress().String()),
+			"personGivenName":  redactText(actualEvent.PersonName().GivenName()),
+			"personFamilyName": redactText(actualEvent.PersonName().FamilyName()),
+		}
+	case domain.CustomerEmailAddressConfirmed:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressConfirmationFailed:
+		return map[string]string{
+			"customerID": actualEvent.CustomerID().String(),
+			"reason":     actualEvent.FailureReason().Error(),
+		}
+	case domain.CustomerEmailAddressConfirmationResendRequested:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChanged:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeRequested:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeCancelled:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeConfirmed:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerNameChanged:
+		return map[string]string{
+			"customerID":       actualEvent.CustomerID().String(),
+			"personGivenName":  redactText(actualEvent.PersonName().GivenName()),
+			"personFamilyName": redactText(actualEvent.PersonName().FamilyName()),
+		}
+	case domain.CustomerPhoneNumberChanged:
+		return redactedPhoneNumberPayload(actualEvent.CustomerID().String(), actualEvent.PhoneNumber().String())
+	case domain.CustomerPhoneNumberConfirmed:
+		return redactedPhoneNumberPayload(actualEvent.CustomerID().String(), actualEvent.PhoneNumber().String())
+	case domain.CustomerPhoneNumberConfirmationFailed:
+		return map[string]string{
+			"customerID": actualEvent.CustomerID().String(),
+			"reason":     actualEvent.FailureReason().Error(),
+		}
+	case domain.CustomerAddressAdded:
+		return map[string]string{
+			"customerID":  actualEvent.CustomerID().String(),
+			"addressKind": actualEvent.AddressKind().String(),
+			"street":      redactText(actualEvent.Address().Street()),
+			"postalCode":  redactText(actualEvent.Address().PostalCode()),
+			"city":        redactText(actualEvent.Address().City()),
+			"countryCode": actualEvent.Address().CountryCode(),
+		}
+	case domain.CustomerAddressChanged:
+		return map[string]string{
+			"customerID":  actualEvent.CustomerID().String(),
+			"addressKind": actualEvent.AddressKind().String(),
+			"street":      redactText(actualEvent.Address().Street()),
+			"postalCode":  redactText(actualEvent.Address().PostalCode()),
+			"city":        redactText(actualEvent.Address().City()),
+			"countryCode": actualEvent.Address().CountryCode(),
+		}
+	case domain.CustomerAddressRemoved:
+		return map[string]string{
+			"customerID":  actualEvent.CustomerID().String(),
+			"addressKind": actualEvent.AddressKind().String(),
+		}
+	case domain.CustomerSuspended:
+		return map[string]string{
+			"customerID": actualEvent.CustomerID().String(),
+			"reason":     actualEvent.Reason().String(),
+		}
+	case domain.CustomerReactivated:
+		return map[string]string{"customerID": actualEvent.CustomerID().String()}
+	case domain.CustomerDeleted:
+		return map[string]string{"customerID": actualEvent.CustomerID().String()}
+	case domain.CustomerRestored:
+		return redactedEmailAddressPayload(actualEvent.CustomerID().String(), actualEvent.EmailAddress().String())
+	case domain.CustomerPersonalDataErased:
+		return map[string]string{"customerID": actualEvent.CustomerID().String()}
+	default:
+		// until Go has "sum types" we need to use an interface (Event) and this case could exist - we don't want to hide it
+		panic("redactedPayloadOf(event): unknown event " + event.Meta().EventName())
+	}
+}
+
+func redactedEmailAddressPayload(customerID string, emailAddress string) map[string]string {
+	return map[string]string{
+		"customerID":   customerID,
+		"emailAddress": redactEmailAddress(emailAddress),
+	}
+}
+
+func redactedPhoneNumberPayload(customerID string, phoneNumber string) map[string]string {
+	return map[string]string{
+		"customerID":  customerID,
+		"phoneNumber": redactPhoneNumber(phoneNumber),
+	}
+}
+
+// redactText keeps the first character, e.g. "Fiona" becomes "F***".
+func redactText(text string) string {
+	if isPlaceholder(text) {
+		return text
+	}
+
+	firstRune, _ := utf8.DecodeRuneInString(text)
+
+	return string(firstRune) + redactedMarker
+}
+
+// redactEmailAddress keeps the first character and the domain, e.g. "fiona@gallagher.net" becomes "f***@gallagher.net".
+func redactEmailAddress(emailAddress string) string {
+	if isPlaceholder(emailAddress) {
+		return emailAddress
+	}
+
+	at := strings.LastIndex(emailAddress, "@")
+	if at < 0 {
+		return redactText(emailAddress)
+	}
+
+	return redactText(emailAddress[:at]) + emailAddress[at:]
+}
+
+// redactPhoneNumber keeps the last two digits, e.g. "+4915112345678" becomes "***78".
+func redactPhoneNumber(phoneNumber string) string {
+	if isPlaceholder(phoneNumber) || len(phoneNumber) < 2 {
+		return phoneNumber
+	}
+
+	return redactedMarker + phoneNumber[len(phoneNumber)-2:]
+}
+
+// isPlaceholder detects empty values and placeholders like "[erased]" which don't contain any personal data.
+func isPlaceholder(text string) bool {
+	return text == "" || (strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"))
+}
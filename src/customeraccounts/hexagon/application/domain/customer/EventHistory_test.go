@@ -0,0 +1,153 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildEventHistoryRange(t *testing.T) {
+	Convey("When an EventHistoryRange is built without fromVersion and maxEvents", t, func() {
+		historyRange, err := customer.BuildEventHistoryRange(0, 0)
+		So(err, ShouldBeNil)
+
+		Convey("Then it should start with the first Event, with the default page size", func() {
+			So(historyRange.FromVersion, ShouldEqual, 1)
+			So(historyRange.MaxEvents, ShouldEqual, customer.DefaultEventHistoryPageSize)
+		})
+	})
+
+	Convey("When an EventHistoryRange is built with too many Events", t, func() {
+		_, err := customer.BuildEventHistoryRange(1, customer.MaxEventHistoryPageSize+1)
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldBeError)
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+}
+
+func TestBuildEventHistoryPageFrom(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("+4915112345678")
+		So(err, ShouldBeNil)
+		address, err := value.BuildAddress("Main Street 1", "10115", "Berlin", "DE")
+		So(err, ShouldBeNil)
+
+		causationID := es.GenerateMessageID()
+
+		customerRegistered := domain.BuildCustomerRegistered(customerID, emailAddress, personName, causationID, 1)
+
+		confirmationFailed := domain.BuildCustomerEmailAddressConfirmationFailed(
+			customerID,
+			value.GenerateConfirmationHash(emailAddress.String()),
+			errors.New("wrong confirmation hash supplied"),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		phoneNumberChanged := domain.BuildCustomerPhoneNumberChanged(customerID, phoneNumber, es.GenerateMessageID(), 3)
+
+		addressAdded := domain.BuildCustomerAddressAdded(
+			customerID,
+			value.AddressKindBilling,
+			address,
+			es.GenerateMessageID(),
+			4,
+		)
+
+		eventStream := es.EventStream{customerRegistered, confirmationFailed, phoneNumberChanged, addressAdded}
+
+		Convey("When an EventHistoryPage is built from all Events", func() {
+			historyRange, err := customer.BuildEventHistoryRange(1, 10)
+			So(err, ShouldBeNil)
+
+			page := customer.BuildEventHistoryPageFrom(historyRange, eventStream)
+
+			Convey("Then it should contain all Events with their metadata and be the last page", func() {
+				So(page.Events, ShouldHaveLength, 4)
+				So(page.NextFromVersion, ShouldBeZeroValue)
+
+				So(page.Events[0].Name, ShouldEqual, "CustomerRegistered")
+				So(page.Events[0].StreamVersion, ShouldEqual, 1)
+				So(page.Events[0].OccurredAt, ShouldEqual, customerRegistered.Meta().OccurredAt())
+				So(page.Events[0].MessageID, ShouldEqual, customerRegistered.Meta().MessageID())
+				So(page.Events[0].CausationID, ShouldEqual, causationID.String())
+				So(page.Events[0].IsFailure, ShouldBeFalse)
+				So(page.Events[1].IsFailure, ShouldBeTrue)
+			})
+
+			Convey("and their payload should be redacted", func() {
+				So(page.Events[0].Payload, ShouldResemble, map[string]string{
+					"customerID":       customerID.String(),
+					"emailAddress":     "k***@ball.com",
+					"personGivenName":  "K***",
+					"personFamilyName": "B***",
+				})
+
+				So(page.Events[1].Payload, ShouldResemble, map[string]string{
+					"customerID": customerID.String(),
+					"reason":     "wrong confirmation hash supplied",
+				})
+
+				So(page.Events[2].Payload, ShouldResemble, map[string]string{
+					"customerID":  customerID.String(),
+					"phoneNumber": "***78",
+				})
+
+				So(page.Events[3].Payload, ShouldResemble, map[string]string{
+					"customerID":  customerID.String(),
+					"addressKind": "billing",
+					"street":      "M***",
+					"postalCode":  "1***",
+					"city":        "B***",
+					"countryCode": "DE",
+				})
+			})
+		})
+
+		Convey("When an EventHistoryPage is built from one Event more than maxEvents", func() {
+			historyRange, err := customer.BuildEventHistoryRange(2, 2)
+			So(err, ShouldBeNil)
+
+			page := customer.BuildEventHistoryPageFrom(historyRange, eventStream[1:])
+
+			Convey("Then it should only contain maxEvents and continue with the remaining Event", func() {
+				So(page.Events, ShouldHaveLength, 2)
+				So(page.Events[0].StreamVersion, ShouldEqual, 2)
+				So(page.Events[1].StreamVersion, ShouldEqual, 3)
+				So(page.NextFromVersion, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When an EventHistoryPage is built from personal data which was erased", func() {
+			erasedName := domain.RebuildCustomerNameChanged(
+				customerID.String(),
+				"[erased]",
+				"[erased]",
+				es.RebuildEventMeta("CustomerNameChanged", "", "", "", 5),
+			)
+
+			historyRange, err := customer.BuildEventHistoryRange(5, 1)
+			So(err, ShouldBeNil)
+
+			page := customer.BuildEventHistoryPageFrom(historyRange, es.EventStream{erasedName})
+
+			Convey("Then the placeholder should be kept", func() {
+				So(page.Events[0].Payload["personGivenName"], ShouldEqual, "[erased]")
+				So(page.Events[0].Payload["personFamilyName"], ShouldEqual, "[erased]")
+			})
+		})
+	})
+}
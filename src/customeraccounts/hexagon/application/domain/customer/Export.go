@@ -0,0 +1,117 @@
+package customer
+
+import (
+	"fmt"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+type Export struct {
+	View                 View
+	Events               []ExportedEvent
+	UniqueEmailAddresses []string
+}
+
+type ExportedEvent struct {
+	Name          string
+	Description   string
+	OccurredAt    string
+	StreamVersion uint
+	IsFailure     bool
+}
+
+// BuildExportFrom needs the full EventStream, it can't describe the history which is hidden behind a snapshot.
+// The View is also exported for deleted Customers, because their data is still stored until it is purged.
+func BuildExportFrom(eventStream es.EventStream, uniqueEmailAddresses []string) Export {
+	export := Export{
+		View:                 BuildViewFrom(eventStream),
+		Events:               make([]ExportedEvent, 0, len(eventStream)),
+		UniqueEmailAddresses: uniqueEmailAddresses,
+	}
+
+	for _, event := range eventStream {
+		export.Events = append(
+			export.Events,
+			ExportedEvent{
+				Name:          event.Meta().EventName(),
+				Description:   describe(event),
+				OccurredAt:    event.Meta().OccurredAt(),
+				StreamVersion: event.Meta().StreamVersion(),
+				IsFailure:     event.IsFailureEvent(),
+			},
+		)
+	}
+
+	return export
+}
+
+func describe(event es.DomainEvent) string {
+	switch actualEvent := event.(type) {
+	case domain.CustomerRegistered:
+		return fmt.Sprintf(
+			"Registered as %s %s with the email address %s",
+			actualEvent.PersonName().GivenName(),
+			actualEvent.PersonName().FamilyName(),
+			actualEvent.EmailAddress().String(),
+		)
+	case domain.CustomerEmailAddressConfirmed:
+		return fmt.Sprintf("Confirmed the email address %s", actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressConfirmationFailed:
+		return fmt.Sprintf("Failed to confirm the email address: %s", actualEvent.FailureReason().Error())
+	case domain.CustomerEmailAddressConfirmationResendRequested:
+		return fmt.Sprintf("Requested a new confirmation email for %s", actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChanged:
+		return fmt.Sprintf("Changed the email address to %s", actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeRequested:
+		return fmt.Sprintf("Requested to change the email address to %s", actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeCancelled:
+		return fmt.Sprintf("Cancelled the change of the email address to %s", actualEvent.EmailAddress().String())
+	case domain.CustomerEmailAddressChangeConfirmed:
+		return fmt.Sprintf("Confirmed the change of the email address to %s", actualEvent.EmailAddress().String())
+	case domain.CustomerNameChanged:
+		return fmt.Sprintf(
+			"Changed the name to %s %s",
+			actualEvent.PersonName().GivenName(),
+			actualEvent.PersonName().FamilyName(),
+		)
+	case domain.CustomerPhoneNumberChanged:
+		return fmt.Sprintf("Changed the phone number to %s", actualEvent.PhoneNumber().String())
+	case domain.CustomerPhoneNumberConfirmed:
+		return fmt.Sprintf("Confirmed the phone number %s", actualEvent.PhoneNumber().String())
+	case domain.CustomerPhoneNumberConfirmationFailed:
+		return fmt.Sprintf("Failed to confirm the phone number: %s", actualEvent.FailureReason().Error())
+	case domain.CustomerAddressAdded:
+		return fmt.Sprintf(
+			"Added the %s address %s",
+			actualEvent.AddressKind().String(),
+			describeAddress(actualEvent.Address()),
+		)
+	case domain.CustomerAddressChanged:
+		return fmt.Sprintf(
+			"Changed the %s address to %s",
+			actualEvent.AddressKind().String(),
+			describeAddress(actualEvent.Address()),
+		)
+	case domain.CustomerAddressRemoved:
+		return fmt.Sprintf("Removed the %s address", actualEvent.AddressKind().String())
+	case domain.CustomerSuspended:
+		return fmt.Sprintf("The account was suspended: %s", actualEvent.Reason().String())
+	case domain.CustomerReactivated:
+		return "The account was reactivated"
+	case domain.CustomerDeleted:
+		return "The account was deleted"
+	case domain.CustomerRestored:
+		return "The account was restored"
+	case domain.CustomerPersonalDataErased:
+		return "The personal data was erased and the account was deleted"
+	default:
+		// until Go has "sum types" we need to use an interface (Event) and this case could exist - we don't want to hide it
+		panic("describe(event): unknown event " + event.Meta().EventName())
+	}
+}
+
+func describeAddress(address value.Address) string {
+	return fmt.Sprintf("%s, %s %s, %s", address.Street(), address.PostalCode(), address.City(), address.CountryCode())
+}
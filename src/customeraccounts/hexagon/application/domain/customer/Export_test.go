@@ -0,0 +1,81 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildExportFrom(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		changedPersonName, err := value.BuildPersonName("Latoya", "Ball")
+		So(err, ShouldBeNil)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		confirmationFailed := domain.BuildCustomerEmailAddressConfirmationFailed(
+			customerID,
+			value.GenerateConfirmationHash(emailAddress.String()),
+			errors.New("wrong confirmation hash supplied"),
+			es.GenerateMessageID(),
+			2,
+		)
+
+		nameChanged := domain.BuildCustomerNameChanged(
+			customerID,
+			changedPersonName,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		uniqueEmailAddresses := []string{emailAddress.Canonical()}
+
+		Convey("Given CustomerRegistered, CustomerEmailAddressConfirmationFailed and CustomerNameChanged", func() {
+			eventStream := es.EventStream{customerRegistered, confirmationFailed, nameChanged}
+
+			Convey("When the Export is built", func() {
+				export := customer.BuildExportFrom(eventStream, uniqueEmailAddresses)
+
+				Convey("Then it should contain the current View", func() {
+					So(export.View, ShouldResemble, customer.BuildViewFrom(eventStream))
+					So(export.View.GivenName, ShouldEqual, changedPersonName.GivenName())
+				})
+
+				Convey("and it should contain all Events, including failed confirmation attempts", func() {
+					So(export.Events, ShouldHaveLength, 3)
+
+					for i, event := range eventStream {
+						So(export.Events[i].Name, ShouldEqual, event.Meta().EventName())
+						So(export.Events[i].OccurredAt, ShouldEqual, event.Meta().OccurredAt())
+						So(export.Events[i].StreamVersion, ShouldEqual, event.Meta().StreamVersion())
+						So(export.Events[i].IsFailure, ShouldEqual, event.IsFailureEvent())
+					}
+
+					So(export.Events[0].Description, ShouldEqual, "Registered as Kevin Ball with the email address kevin@ball.com")
+					So(export.Events[1].Description, ShouldEqual, "Failed to confirm the email address: wrong confirmation hash supplied")
+					So(export.Events[2].Description, ShouldEqual, "Changed the name to Latoya Ball")
+				})
+
+				Convey("and it should contain the unique email addresses", func() {
+					So(export.UniqueEmailAddresses, ShouldResemble, uniqueEmailAddresses)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func Reactivate(eventStream es.EventStream, command domain.ReactivateCustomer) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "reactivateCustomer")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "reactivateCustomer")
+	}
+
+	if !customer.isSuspended {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerReactivated(
+		command.CustomerID(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
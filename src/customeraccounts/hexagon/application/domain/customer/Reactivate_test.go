@@ -0,0 +1,126 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReactivate(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		changedPersonName, err := value.BuildPersonName("Latoya", "Ball")
+		So(err, ShouldBeNil)
+		reason, err := value.BuildSuspensionReason("suspicion of fraud")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildReactivateCustomer(customerID, 0)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			reason,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Reactivate a suspended Customer's account", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When ReactivateCustomer", func() {
+						recordedEvents, err = customer.Reactivate(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerReactivated", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerReactivated)
+							So(ok, ShouldBeTrue)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().MessageID(), ShouldNotBeEmpty)
+							So(event.Meta().StreamVersion(), ShouldEqual, uint(3))
+
+							Convey("and the Customer should accept commands again", func() {
+								eventStream = append(eventStream, event)
+								So(customer.BuildViewFrom(eventStream).Status, ShouldEqual, customer.StatusActive)
+
+								recordedEvents, err = customer.ChangeName(
+									eventStream,
+									domain.BuildChangeCustomerName(customerID, changedPersonName, 0),
+								)
+								So(err, ShouldBeNil)
+								So(recordedEvents, ShouldHaveLength, 1)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Try to reactivate a Customer's account which is not suspended", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When ReactivateCustomer", func() {
+					recordedEvents, err = customer.Reactivate(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then no event", func() {
+						So(recordedEvents, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to reactivate a Customer's account when it was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When ReactivateCustomer", func() {
+						_, err = customer.Reactivate(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+func RemoveAddress(eventStream es.EventStream, command domain.RemoveCustomerAddress) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "removeCustomerAddress")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "removeCustomerAddress")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "removeCustomerAddress")
+	}
+
+	if _, found := customer.addresses[command.AddressKind()]; !found {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerAddressRemoved(
+		command.CustomerID(),
+		command.AddressKind(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
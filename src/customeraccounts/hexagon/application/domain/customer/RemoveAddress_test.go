@@ -0,0 +1,139 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRemoveAddress(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		address, err := value.BuildAddress("Main Street 1", "10115", "Berlin", "DE")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildRemoveCustomerAddress(customerID, value.AddressKindShipping, 0)
+		commandWithOutdatedVersion := domain.BuildRemoveCustomerAddress(customerID, value.AddressKindShipping, 1)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		shippingAddressAdded := domain.BuildCustomerAddressAdded(
+			customerID,
+			value.AddressKindShipping,
+			address,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		Convey("\nSCENARIO 1: Remove a Customer's shipping address", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the shipping address", func() {
+					eventStream = append(eventStream, shippingAddressAdded)
+
+					Convey("When RemoveCustomerAddress", func() {
+						recordedEvents, err = customer.RemoveAddress(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerAddressRemoved", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerAddressRemoved)
+							So(ok, ShouldBeTrue)
+							So(event, ShouldNotBeNil)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.AddressKind().Equals(value.AddressKindShipping), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().MessageID(), ShouldNotBeEmpty)
+							So(event.Meta().StreamVersion(), ShouldEqual, 3)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Try to remove a shipping address which was never added", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When RemoveCustomerAddress", func() {
+					recordedEvents, err = customer.RemoveAddress(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then no event", func() {
+						So(recordedEvents, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to remove an address when the account was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the shipping address", func() {
+					eventStream = append(eventStream, shippingAddressAdded)
+
+					Convey("and CustomerDeleted", func() {
+						eventStream = append(eventStream, customerDeleted)
+
+						Convey("When RemoveCustomerAddress", func() {
+							_, err = customer.RemoveAddress(eventStream, command)
+
+							Convey("Then it should report an error", func() {
+								So(err, ShouldBeError)
+								So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to remove an address when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerAddressAdded for the shipping address", func() {
+					eventStream = append(eventStream, shippingAddressAdded)
+
+					Convey("When RemoveCustomerAddress with the expected version 1", func() {
+						_, err = customer.RemoveAddress(eventStream, commandWithOutdatedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// RequestEmailAddressConfirmationResend regenerates the ConfirmationHash, but not more often than the command's
+// MinResendInterval allows, measured from the creation of the current hash.
+// If a change of the emailAddress is pending, the hash of the pending emailAddress is regenerated.
+func RequestEmailAddressConfirmationResend(
+	eventStream es.EventStream,
+	command domain.RequestCustomerEmailAddressConfirmationResend,
+) (es.RecordedEvents, error) {
+
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "requestEmailAddressConfirmationResend")
+	}
+
+	if err := assertNotSuspended(customer); err != nil {
+		return nil, errors.Wrap(err, "requestEmailAddressConfirmationResend")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "requestEmailAddressConfirmationResend")
+	}
+
+	switch actualEmailAddress := customer.emailAddress.(type) {
+	case value.ConfirmedEmailAddress:
+		if customer.pendingEmailAddress == nil {
+			return nil, nil
+		}
+
+		if err := assertResendNotTooEarly(*customer.pendingEmailAddress, command.MinResendInterval()); err != nil {
+			return nil, err
+		}
+
+		return es.RecordedEvents{
+			domain.BuildCustomerEmailAddressChangeRequested(
+				command.CustomerID(),
+				customer.pendingEmailAddress.WithRegeneratedConfirmationHash(),
+				command.MessageID(),
+				customer.currentStreamVersion+1,
+			),
+		}, nil
+	case value.UnconfirmedEmailAddress:
+		if err := assertResendNotTooEarly(actualEmailAddress, command.MinResendInterval()); err != nil {
+			return nil, err
+		}
+
+		return es.RecordedEvents{
+			domain.BuildCustomerEmailAddressConfirmationResendRequested(
+				command.CustomerID(),
+				actualEmailAddress.WithRegeneratedConfirmationHash(),
+				command.MessageID(),
+				customer.currentStreamVersion+1,
+			),
+		}, nil
+	default:
+		// until Go has "union types" we need to use an interface and this case could exist - we don't want to hide it
+		panic("RequestEmailAddressConfirmationResend(): emailAddress is neither UnconfirmedEmailAddress nor ConfirmedEmailAddress")
+	}
+}
+
+func assertResendNotTooEarly(emailAddress value.UnconfirmedEmailAddress, minResendInterval time.Duration) error {
+	if waitFor := minResendInterval - time.Since(emailAddress.ConfirmationHashCreatedAt()); waitFor > 0 {
+		err := errors.Newf("confirmation email was sent recently, retry in [%s]", waitFor.Round(time.Second))
+		return shared.MarkAndWrapError(err, shared.ErrRateLimited, "requestEmailAddressConfirmationResend")
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestEmailAddressConfirmationResend(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		minResendInterval := time.Minute
+
+		emailAddress := value.RebuildUnconfirmedEmailAddress(
+			"kevin@ball.com",
+			value.GenerateConfirmationHash("kevin@ball.com").String(),
+			time.Now().Add(-minResendInterval-time.Second),
+		)
+
+		recentEmailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildRequestCustomerEmailAddressConfirmationResend(customerID, minResendInterval, 0)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerRegisteredRecently := domain.BuildCustomerRegistered(
+			customerID,
+			recentEmailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+		So(err, ShouldBeNil)
+
+		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
+			customerID,
+			confirmedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		pendingEmailAddress := value.RebuildUnconfirmedEmailAddress(
+			"kevin@ball.net",
+			value.GenerateConfirmationHash("kevin@ball.net").String(),
+			time.Now().Add(-minResendInterval-time.Second),
+		)
+
+		customerEmailAddressChangeRequested := domain.BuildCustomerEmailAddressChangeRequested(
+			customerID,
+			pendingEmailAddress,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Request a resend of the confirmation email", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When RequestCustomerEmailAddressConfirmationResend", func() {
+					recordedEvents, err = customer.RequestEmailAddressConfirmationResend(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerEmailAddressConfirmationResendRequested with a new confirmationHash", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmationResendRequested)
+						So(ok, ShouldBeTrue)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.EmailAddress().Equals(emailAddress), ShouldBeTrue)
+						So(event.EmailAddress().ConfirmationHash().Equals(emailAddress.ConfirmationHash()), ShouldBeFalse)
+						So(event.IsFailureEvent(), ShouldBeFalse)
+						So(event.FailureReason(), ShouldBeNil)
+						So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+						So(event.Meta().MessageID(), ShouldNotBeEmpty)
+						So(event.Meta().StreamVersion(), ShouldEqual, 2)
+
+						Convey("and the previous confirmationHash should be invalid", func() {
+							eventStream = append(eventStream, event)
+							confirmWithOldHash := domain.BuildConfirmCustomerEmailAddress(customerID, emailAddress.ConfirmationHash(), 0, 0, 0, 0)
+
+							recordedEvents, err = customer.ConfirmEmailAddress(eventStream, confirmWithOldHash)
+							So(err, ShouldBeNil)
+							So(recordedEvents, ShouldHaveLength, 1)
+							_, ok := recordedEvents[0].(domain.CustomerEmailAddressConfirmationFailed)
+							So(ok, ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Request a resend of the confirmation email too early", func() {
+			Convey("Given CustomerRegistered just now", func() {
+				eventStream := es.EventStream{customerRegisteredRecently}
+
+				Convey("When RequestCustomerEmailAddressConfirmationResend", func() {
+					_, err = customer.RequestEmailAddressConfirmationResend(eventStream, command)
+
+					Convey("Then it should report an error", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrRateLimited), ShouldBeTrue)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Request a resend of the confirmation email when the emailAddress is already confirmed", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("When RequestCustomerEmailAddressConfirmationResend", func() {
+						recordedEvents, err = customer.RequestEmailAddressConfirmationResend(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to request a resend of the confirmation email when the account was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("Given CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When RequestCustomerEmailAddressConfirmationResend", func() {
+						_, err = customer.RequestEmailAddressConfirmationResend(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Request a resend of the confirmation email for a pending emailAddress", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("and CustomerEmailAddressChangeRequested", func() {
+						eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+						Convey("When RequestCustomerEmailAddressConfirmationResend", func() {
+							recordedEvents, err = customer.RequestEmailAddressConfirmationResend(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerEmailAddressChangeRequested with a new confirmationHash", func() {
+								So(recordedEvents, ShouldHaveLength, 1)
+								event, ok := recordedEvents[0].(domain.CustomerEmailAddressChangeRequested)
+								So(ok, ShouldBeTrue)
+								So(event.EmailAddress().Equals(pendingEmailAddress), ShouldBeTrue)
+								So(event.EmailAddress().ConfirmationHash().Equals(pendingEmailAddress.ConfirmationHash()), ShouldBeFalse)
+								So(event.Meta().StreamVersion(), ShouldEqual, 4)
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// Restore undoes Delete, but only within the command's GracePeriod after the Customer was deleted and only if her
+// personal data was not erased.
+// The emailAddress was released by Delete, so it has to be reclaimed, which fails if another Customer took it meanwhile.
+// A pending change of the emailAddress is cancelled, because its reservation was released as well.
+func Restore(eventStream es.EventStream, command domain.RestoreCustomer) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if !customer.isDeleted {
+		return nil, nil
+	}
+
+	if err := assertPersonalDataNotErased(customer); err != nil {
+		return nil, errors.Wrap(err, "restoreCustomer")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "restoreCustomer")
+	}
+
+	if err := assertGracePeriodNotOver(customer, command.GracePeriod()); err != nil {
+		return nil, errors.Wrap(err, "restoreCustomer")
+	}
+
+	recordedEvents := es.RecordedEvents{
+		domain.BuildCustomerRestored(
+			command.CustomerID(),
+			customer.emailAddress,
+			command.MessageID(),
+			customer.currentStreamVersion+1,
+		),
+	}
+
+	if customer.pendingEmailAddress != nil {
+		recordedEvents = append(
+			recordedEvents,
+			domain.BuildCustomerEmailAddressChangeCancelled(
+				command.CustomerID(),
+				*customer.pendingEmailAddress,
+				command.MessageID(),
+				customer.currentStreamVersion+2,
+			),
+		)
+	}
+
+	return recordedEvents, nil
+}
+
+func assertPersonalDataNotErased(currentState currentState) error {
+	if currentState.isPersonalDataErased {
+		return errors.Mark(errors.New("customer was deleted and its personal data was erased"), shared.ErrNotFound)
+	}
+
+	return nil
+}
+
+func assertGracePeriodNotOver(currentState currentState, gracePeriod time.Duration) error {
+	if time.Since(currentState.deletedAt) > gracePeriod {
+		err := errors.Newf("customer was deleted and the grace period of [%s] for restoring it is over", gracePeriod)
+		return errors.Mark(err, shared.ErrNotFound)
+	}
+
+	return nil
+}
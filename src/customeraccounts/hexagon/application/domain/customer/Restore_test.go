@@ -0,0 +1,179 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRestore(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		requestedEmailAddress, err := value.BuildUnconfirmedEmailAddress("latoya@ball.net")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		gracePeriod := 24 * time.Hour
+
+		command := domain.BuildRestoreCustomer(customerID, gracePeriod, 0)
+		commandWithOutdatedVersion := domain.BuildRestoreCustomer(customerID, gracePeriod, 1)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerEmailAddressChangeRequested := domain.BuildCustomerEmailAddressChangeRequested(
+			customerID,
+			requestedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeletedLongAgo := domain.RebuildCustomerDeleted(
+			customerID.String(),
+			es.RebuildEventMeta(
+				customerDeleted.Meta().EventName(),
+				time.Now().Add(-2*gracePeriod).Format(time.RFC3339Nano),
+				es.GenerateMessageID().String(),
+				es.GenerateMessageID().String(),
+				2,
+			),
+		)
+
+		Convey("\nSCENARIO 1: Restore a deleted Customer's account within the grace period", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When RestoreCustomer", func() {
+						recordedEvents, err = customer.Restore(eventStream, command)
+						So(err, ShouldBeNil)
+
+						Convey("Then CustomerRestored", func() {
+							So(recordedEvents, ShouldHaveLength, 1)
+							event, ok := recordedEvents[0].(domain.CustomerRestored)
+							So(ok, ShouldBeTrue)
+							So(event, ShouldNotBeNil)
+							So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(event.EmailAddress().Equals(emailAddress), ShouldBeTrue)
+							So(event.IsFailureEvent(), ShouldBeFalse)
+							So(event.FailureReason(), ShouldBeNil)
+							So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+							So(event.Meta().MessageID(), ShouldNotBeEmpty)
+							So(event.Meta().StreamVersion(), ShouldEqual, uint(3))
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Restore a deleted Customer's account with a pending change of the emailAddress", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressChangeRequested", func() {
+					eventStream = append(eventStream, customerEmailAddressChangeRequested)
+
+					Convey("and CustomerDeleted", func() {
+						eventStream = append(eventStream, domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 3))
+
+						Convey("When RestoreCustomer", func() {
+							recordedEvents, err = customer.Restore(eventStream, command)
+							So(err, ShouldBeNil)
+
+							Convey("Then CustomerRestored and CustomerEmailAddressChangeCancelled", func() {
+								So(recordedEvents, ShouldHaveLength, 2)
+								restored, ok := recordedEvents[0].(domain.CustomerRestored)
+								So(ok, ShouldBeTrue)
+								So(restored.EmailAddress().Equals(emailAddress), ShouldBeTrue)
+								So(restored.Meta().StreamVersion(), ShouldEqual, uint(4))
+								cancelled, ok := recordedEvents[1].(domain.CustomerEmailAddressChangeCancelled)
+								So(ok, ShouldBeTrue)
+								So(cancelled.EmailAddress().Equals(requestedEmailAddress), ShouldBeTrue)
+								So(cancelled.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+								So(cancelled.Meta().StreamVersion(), ShouldEqual, uint(5))
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to restore a Customer's account which is not deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When RestoreCustomer", func() {
+					recordedEvents, err = customer.Restore(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then no Event", func() {
+						So(recordedEvents, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to restore a deleted Customer's account after the grace period", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted before the grace period", func() {
+					eventStream = append(eventStream, customerDeletedLongAgo)
+
+					Convey("When RestoreCustomer", func() {
+						_, err = customer.Restore(eventStream, command)
+
+						Convey("Then it should report that the Customer was not found", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 5: Try to restore a deleted Customer's account when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When RestoreCustomer with the expected version 1", func() {
+						_, err = customer.Restore(eventStream, commandWithOutdatedVersion)
+
+						Convey("Then it should report a version mismatch", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+// SnapshotFormatVersion must be increased whenever buildCurrentStateFrom() or CustomerSnapshot change,
+// so that existing snapshots are discarded and rebuilt from the full EventStream.
+const SnapshotFormatVersion = 10
+
+type ForBuildingSnapshots func(eventStream es.EventStream) domain.CustomerSnapshot
+
+func BuildSnapshotFrom(eventStream es.EventStream) domain.CustomerSnapshot {
+	customer := buildCurrentStateFrom(eventStream)
+	lastEvent := eventStream[len(eventStream)-1]
+
+	snapshot := domain.BuildCustomerSnapshot(
+		customer.id,
+		customer.emailAddress,
+		customer.personName,
+		customer.registeredAt,
+		customer.isDeleted,
+		customer.deletedAt,
+		customer.isPersonalDataErased,
+		customer.isSuspended,
+		customer.suspensionReason,
+		customer.pendingEmailAddress,
+		customer.confirmationFailures,
+		customer.addresses,
+		customer.phoneNumber,
+		customer.phoneNumberConfirmationFailures,
+		es.RebuildMessageID(lastEvent.Meta().MessageID()),
+		customer.currentStreamVersion,
+	)
+
+	return snapshot
+}
@@ -0,0 +1,81 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildSnapshotFrom(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		changedPersonName, err := value.BuildPersonName("Kevin Peter", "Ball")
+		So(err, ShouldBeNil)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerEmailAddressConfirmed := domain.BuildCustomerEmailAddressConfirmed(
+			customerID,
+			confirmedEmailAddress,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerNameChanged := domain.BuildCustomerNameChanged(
+			customerID,
+			changedPersonName,
+			es.GenerateMessageID(),
+			3,
+		)
+
+		Convey("\nSCENARIO: Build a snapshot and continue from it", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerEmailAddressConfirmed", func() {
+					eventStream = append(eventStream, customerEmailAddressConfirmed)
+
+					Convey("When a snapshot is built", func() {
+						snapshot := customer.BuildSnapshotFrom(eventStream)
+
+						Convey("Then it should contain the current state", func() {
+							So(snapshot.CustomerID().Equals(customerID), ShouldBeTrue)
+							So(snapshot.EmailAddress().Equals(confirmedEmailAddress), ShouldBeTrue)
+							So(snapshot.PersonName().Equals(personName), ShouldBeTrue)
+							So(snapshot.RegisteredAt().Format(time.RFC3339Nano), ShouldEqual, customerRegistered.Meta().OccurredAt())
+							So(snapshot.IsDeleted(), ShouldBeFalse)
+							So(snapshot.Meta().StreamVersion(), ShouldEqual, uint(2))
+							So(snapshot.Meta().CausationID(), ShouldEqual, customerEmailAddressConfirmed.Meta().MessageID())
+						})
+
+						Convey("and when CustomerNameChanged is replayed after the snapshot", func() {
+							fromSnapshot := es.EventStream{snapshot, customerNameChanged}
+							fromAllEvents := append(eventStream, customerNameChanged)
+
+							Convey("Then the View should be the same as from the full EventStream", func() {
+								So(customer.BuildViewFrom(fromSnapshot), ShouldResemble, customer.BuildViewFrom(fromAllEvents))
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
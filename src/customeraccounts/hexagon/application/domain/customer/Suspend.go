@@ -0,0 +1,34 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// Suspend blocks the Customer temporarily, e.g. on suspicion of fraud, until she is reactivated.
+// Suspending an already suspended Customer keeps the original reason.
+func Suspend(eventStream es.EventStream, command domain.SuspendCustomer) (es.RecordedEvents, error) {
+	customer := buildCurrentStateFrom(eventStream)
+
+	if err := assertNotDeleted(customer); err != nil {
+		return nil, errors.Wrap(err, "suspendCustomer")
+	}
+
+	if err := assertExpectedVersion(customer, command.ExpectedVersion()); err != nil {
+		return nil, errors.Wrap(err, "suspendCustomer")
+	}
+
+	if customer.isSuspended {
+		return nil, nil
+	}
+
+	event := domain.BuildCustomerSuspended(
+		command.CustomerID(),
+		command.Reason(),
+		command.MessageID(),
+		customer.currentStreamVersion+1,
+	)
+
+	return es.RecordedEvents{event}, nil
+}
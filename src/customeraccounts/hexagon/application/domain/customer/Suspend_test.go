@@ -0,0 +1,137 @@
+package customer_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSuspend(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var err error
+		var recordedEvents es.RecordedEvents
+
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		reason, err := value.BuildSuspensionReason("suspicion of fraud")
+		So(err, ShouldBeNil)
+		otherReason, err := value.BuildSuspensionReason("chargeback")
+		So(err, ShouldBeNil)
+
+		command := domain.BuildSuspendCustomer(customerID, reason, 0)
+		commandWithOtherReason := domain.BuildSuspendCustomer(customerID, otherReason, 0)
+		commandWithOutdatedVersion := domain.BuildSuspendCustomer(customerID, reason, 2)
+
+		customerRegistered := domain.BuildCustomerRegistered(
+			customerID,
+			emailAddress,
+			personName,
+			es.GenerateMessageID(),
+			1,
+		)
+
+		customerSuspended := domain.BuildCustomerSuspended(
+			customerID,
+			reason,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		customerDeleted := domain.BuildCustomerDeleted(
+			customerID,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("\nSCENARIO 1: Suspend a Customer's account", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When SuspendCustomer", func() {
+					recordedEvents, err = customer.Suspend(eventStream, command)
+					So(err, ShouldBeNil)
+
+					Convey("Then CustomerSuspended", func() {
+						So(recordedEvents, ShouldHaveLength, 1)
+						event, ok := recordedEvents[0].(domain.CustomerSuspended)
+						So(ok, ShouldBeTrue)
+						So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+						So(event.Reason().Equals(reason), ShouldBeTrue)
+						So(event.IsFailureEvent(), ShouldBeFalse)
+						So(event.FailureReason(), ShouldBeNil)
+						So(event.Meta().CausationID(), ShouldEqual, command.MessageID().String())
+						So(event.Meta().MessageID(), ShouldNotBeEmpty)
+						So(event.Meta().StreamVersion(), ShouldEqual, uint(2))
+
+						Convey("and the View should show the Customer as suspended", func() {
+							view := customer.BuildViewFrom(append(eventStream, event))
+							So(view.Status, ShouldEqual, customer.StatusSuspended)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 2: Try to suspend a Customer's account again", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerSuspended", func() {
+					eventStream = append(eventStream, customerSuspended)
+
+					Convey("When SuspendCustomer with another reason", func() {
+						recordedEvents, err = customer.Suspend(eventStream, commandWithOtherReason)
+						So(err, ShouldBeNil)
+
+						Convey("Then no event", func() {
+							So(recordedEvents, ShouldBeEmpty)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 3: Try to suspend a Customer's account when it was deleted", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("and CustomerDeleted", func() {
+					eventStream = append(eventStream, customerDeleted)
+
+					Convey("When SuspendCustomer", func() {
+						_, err = customer.Suspend(eventStream, command)
+
+						Convey("Then it should report an error", func() {
+							So(err, ShouldBeError)
+							So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("\nSCENARIO 4: Try to suspend a Customer's account when the expected version is outdated", func() {
+			Convey("Given CustomerRegistered", func() {
+				eventStream := es.EventStream{customerRegistered}
+
+				Convey("When SuspendCustomer with the expected version 2", func() {
+					_, err = customer.Suspend(eventStream, commandWithOutdatedVersion)
+
+					Convey("Then it should report a version mismatch", func() {
+						So(err, ShouldBeError)
+						So(errors.Is(err, shared.ErrVersionMismatch), ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
@@ -1,20 +1,52 @@
 package customer
 
 import (
+	"sort"
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 )
 
+const (
+	StatusActive    = "active"
+	StatusSuspended = "suspended"
+	StatusDeleted   = "deleted"
+)
+
 type View struct {
 	ID                      string
 	EmailAddress            string
 	IsEmailAddressConfirmed bool
+	PendingEmailAddress     string
 	GivenName               string
 	FamilyName              string
 	IsDeleted               bool
+	Status                  string
+	Addresses               []AddressView
+	PhoneNumber             string
+	IsPhoneNumberConfirmed  bool
+	RegisteredAt            time.Time
 	Version                 uint
 }
 
+type AddressView struct {
+	Kind        string
+	Street      string
+	PostalCode  string
+	City        string
+	CountryCode string
+}
+
+// CanonicalEmailAddress is the form which identifies the email address, it is empty once the personal data is erased.
+func (view View) CanonicalEmailAddress() string {
+	if view.EmailAddress == "" {
+		return ""
+	}
+
+	return value.RebuildConfirmedEmailAddress(view.EmailAddress).Canonical()
+}
+
 func BuildViewFrom(eventStream es.EventStream) View {
 	customer := buildCurrentStateFrom(eventStream)
 
@@ -24,9 +56,50 @@ func BuildViewFrom(eventStream es.EventStream) View {
 		GivenName:    customer.personName.GivenName(),
 		FamilyName:   customer.personName.FamilyName(),
 		IsDeleted:    customer.isDeleted,
+		Status:       StatusActive,
+		RegisteredAt: customer.registeredAt,
 		Version:      customer.currentStreamVersion,
 	}
 
+	switch {
+	case customer.isDeleted:
+		customerView.Status = StatusDeleted
+	case customer.isSuspended:
+		customerView.Status = StatusSuspended
+	}
+
+	if customer.isPersonalDataErased {
+		customerView.EmailAddress = ""
+		customerView.GivenName = ""
+		customerView.FamilyName = ""
+	}
+
+	for kind, address := range customer.addresses {
+		customerView.Addresses = append(
+			customerView.Addresses,
+			AddressView{
+				Kind:        kind.String(),
+				Street:      address.Street(),
+				PostalCode:  address.PostalCode(),
+				City:        address.City(),
+				CountryCode: address.CountryCode(),
+			},
+		)
+	}
+
+	sort.Slice(customerView.Addresses, func(i, j int) bool {
+		return customerView.Addresses[i].Kind < customerView.Addresses[j].Kind
+	})
+
+	if customer.phoneNumber != nil {
+		customerView.PhoneNumber = customer.phoneNumber.String()
+		_, customerView.IsPhoneNumberConfirmed = customer.phoneNumber.(value.ConfirmedPhoneNumber)
+	}
+
+	if customer.pendingEmailAddress != nil {
+		customerView.PendingEmailAddress = customer.pendingEmailAddress.String()
+	}
+
 	switch customer.emailAddress.(type) {
 	case value.ConfirmedEmailAddress:
 		customerView.IsEmailAddressConfirmed = true
@@ -0,0 +1,65 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// ViewAsOf selects the Events which are folded into a historic View,
+// either up to and including a StreamVersion or up to and including a point in time.
+type ViewAsOf struct {
+	Version uint
+	Time    time.Time
+}
+
+// BuildViewAsOf needs exactly one of asOfVersion or asOfTime, which is an RFC3339 timestamp or a plain date.
+func BuildViewAsOf(asOfVersion uint, asOfTime string) (ViewAsOf, error) {
+	wrapWithMsg := "BuildViewAsOf"
+
+	asOf := ViewAsOf{Version: asOfVersion}
+
+	var err error
+
+	if asOf.Time, err = parseTimestampOrDate(asOfTime, "asOfTime"); err != nil {
+		return ViewAsOf{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	if (asOf.Version == 0) == asOf.Time.IsZero() {
+		err := errors.New("exactly one of asOfVersion or asOfTime must be supplied")
+		return ViewAsOf{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	return asOf, nil
+}
+
+func (asOf ViewAsOf) Includes(event es.DomainEvent) bool {
+	if asOf.Version != 0 {
+		return event.Meta().StreamVersion() <= asOf.Version
+	}
+
+	return !occurredAt(event).After(asOf.Time)
+}
+
+// BuildViewAsOfFrom needs the full EventStream, because a snapshot could already contain later changes.
+// It fails if the Customer was not registered yet at that point.
+func BuildViewAsOfFrom(eventStream es.EventStream, asOf ViewAsOf) (View, error) {
+	var eventStreamSoFar es.EventStream
+
+	for _, event := range eventStream {
+		if !asOf.Includes(event) {
+			break
+		}
+
+		eventStreamSoFar = append(eventStreamSoFar, event)
+	}
+
+	if len(eventStreamSoFar) == 0 {
+		err := errors.New("customer was not registered yet")
+		return View{}, shared.MarkAndWrapError(err, shared.ErrNotFound, "BuildViewAsOfFrom")
+	}
+
+	return BuildViewFrom(eventStreamSoFar), nil
+}
@@ -0,0 +1,124 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildViewAsOf(t *testing.T) {
+	invalidInput := map[string]struct {
+		asOfVersion uint
+		asOfTime    string
+	}{
+		"neither asOfVersion nor asOfTime": {},
+		"both asOfVersion and asOfTime":    {asOfVersion: 1, asOfTime: "2021-03-01"},
+		"an invalid asOfTime":              {asOfTime: "last march"},
+	}
+
+	for description, input := range invalidInput {
+		input := input
+
+		Convey("When a ViewAsOf is built with "+description, t, func() {
+			_, err := customer.BuildViewAsOf(input.asOfVersion, input.asOfTime)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+		})
+	}
+}
+
+func TestBuildViewAsOfFrom(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		customerID := value.GenerateCustomerID()
+		registeredAt := time.Date(2021, 2, 14, 10, 0, 0, 0, time.UTC)
+		changedAt := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+		deletedAt := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+
+		customerRegistered := domain.RebuildCustomerRegistered(
+			customerID.String(),
+			"fiona@gallagher.net",
+			"some_confirmation_hash",
+			registeredAt,
+			"Fiona",
+			"Gallagher",
+			es.RebuildEventMeta("CustomerRegistered", registeredAt.Format(time.RFC3339Nano), "", "", 1),
+		)
+
+		emailAddressChanged := domain.RebuildCustomerEmailAddressChanged(
+			customerID.String(),
+			"fiona@lishman.net",
+			"some_confirmation_hash",
+			changedAt,
+			es.RebuildEventMeta("CustomerEmailAddressChanged", changedAt.Format(time.RFC3339Nano), "", "", 2),
+		)
+
+		customerDeleted := domain.RebuildCustomerDeleted(
+			customerID.String(),
+			es.RebuildEventMeta("CustomerDeleted", deletedAt.Format(time.RFC3339Nano), "", "", 3),
+		)
+
+		eventStream := es.EventStream{customerRegistered, emailAddressChanged, customerDeleted}
+
+		Convey("When the View is built as of a version", func() {
+			asOf, err := customer.BuildViewAsOf(1, "")
+			So(err, ShouldBeNil)
+
+			view, err := customer.BuildViewAsOfFrom(eventStream, asOf)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should only contain the Events up to and including that version", func() {
+				So(view.EmailAddress, ShouldEqual, "fiona@gallagher.net")
+				So(view.Version, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the View is built as of the exact time of an Event", func() {
+			asOf, err := customer.BuildViewAsOf(0, changedAt.Format(time.RFC3339))
+			So(err, ShouldBeNil)
+
+			view, err := customer.BuildViewAsOfFrom(eventStream, asOf)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should contain that Event", func() {
+				So(view.EmailAddress, ShouldEqual, "fiona@lishman.net")
+				So(view.Version, ShouldEqual, 2)
+				So(view.IsDeleted, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the View is built as of a date", func() {
+			asOf, err := customer.BuildViewAsOf(0, "2021-03-01")
+			So(err, ShouldBeNil)
+
+			view, err := customer.BuildViewAsOfFrom(eventStream, asOf)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should contain the Events up to the start of that day", func() {
+				So(view.EmailAddress, ShouldEqual, "fiona@gallagher.net")
+				So(view.Version, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the View is built as of a time before the registration", func() {
+			asOf, err := customer.BuildViewAsOf(0, "2021-02-01")
+			So(err, ShouldBeNil)
+
+			_, err = customer.BuildViewAsOfFrom(eventStream, asOf)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,283 @@
+package customer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	SortByRegisteredAt = "registeredAt"
+	SortByEmailAddress = "emailAddress"
+	SortByFamilyName   = "familyName"
+
+	ConfirmationStatusConfirmed   = "confirmed"
+	ConfirmationStatusUnconfirmed = "unconfirmed"
+
+	DefaultViewPageSize = 20
+	MaxViewPageSize     = 100
+)
+
+// sortableTimeFormat has a fixed width, so that the formatted times sort the same way as the times themselves.
+const sortableTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// ViewSearchCriteria is the raw input to search (or list, if no filter is set) Customer Views.
+// RegisteredFrom (inclusive) and RegisteredBefore (exclusive) are RFC3339 timestamps or plain dates.
+// The Cursor is the NextCursor of the previous ViewPage, it only works with the same sorting.
+type ViewSearchCriteria struct {
+	EmailAddress       string
+	NameFragment       string
+	ConfirmationStatus string
+	RegisteredFrom     string
+	RegisteredBefore   string
+	SortBy             string
+	SortDescending     bool
+	PageSize           uint32
+	Cursor             string
+}
+
+// ViewSearch is the validated form of ViewSearchCriteria.
+// Deleted Customers are never found, same as CustomerViewByID does not find them.
+type ViewSearch struct {
+	EmailAddress     string // normalized the same way as when a Customer registers, it matches by the canonical form
+	NameFragment     string
+	IsEmailConfirmed *bool // nil if Customers should be found regardless of their confirmation status
+	RegisteredFrom   time.Time
+	RegisteredBefore time.Time
+	SortBy           string
+	SortDescending   bool
+	PageSize         int
+	After            *ViewCursor // nil for the first page
+}
+
+// ViewCursor points to the last View of a ViewPage, by its SortValue and, to break ties, by its ID.
+type ViewCursor struct {
+	SortBy    string `json:"sortBy"`
+	SortValue string `json:"sortValue"`
+	ID        string `json:"id"`
+}
+
+// ViewPage has no NextCursor if it is the last page.
+type ViewPage struct {
+	Views      []View
+	NextCursor string
+}
+
+func BuildViewSearch(criteria ViewSearchCriteria) (ViewSearch, error) {
+	wrapWithMsg := "BuildViewSearch"
+
+	search := ViewSearch{
+		NameFragment:   strings.TrimSpace(criteria.NameFragment),
+		SortBy:         criteria.SortBy,
+		SortDescending: criteria.SortDescending,
+		PageSize:       int(criteria.PageSize),
+	}
+
+	if emailAddress := strings.TrimSpace(criteria.EmailAddress); emailAddress != "" {
+		normalized, err := value.BuildUnconfirmedEmailAddress(emailAddress)
+		if err != nil {
+			return ViewSearch{}, errors.Wrap(err, wrapWithMsg)
+		}
+
+		search.EmailAddress = normalized.String()
+	}
+
+	switch criteria.ConfirmationStatus {
+	case "":
+		// no filter
+	case ConfirmationStatusConfirmed, ConfirmationStatusUnconfirmed:
+		isEmailConfirmed := criteria.ConfirmationStatus == ConfirmationStatusConfirmed
+		search.IsEmailConfirmed = &isEmailConfirmed
+	default:
+		err := errors.Newf("unknown confirmationStatus [%s]", criteria.ConfirmationStatus)
+		return ViewSearch{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	var err error
+
+	if search.RegisteredFrom, err = parseTimestampOrDate(criteria.RegisteredFrom, "registeredFrom"); err != nil {
+		return ViewSearch{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	if search.RegisteredBefore, err = parseTimestampOrDate(criteria.RegisteredBefore, "registeredBefore"); err != nil {
+		return ViewSearch{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	switch search.SortBy {
+	case "":
+		search.SortBy = SortByRegisteredAt
+	case SortByRegisteredAt, SortByEmailAddress, SortByFamilyName:
+		// valid
+	default:
+		err := errors.Newf("unknown sortBy [%s]", criteria.SortBy)
+		return ViewSearch{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	switch {
+	case search.PageSize == 0:
+		search.PageSize = DefaultViewPageSize
+	case search.PageSize > MaxViewPageSize:
+		err := errors.Newf("pageSize must not be greater than %d", MaxViewPageSize)
+		return ViewSearch{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	if criteria.Cursor != "" {
+		cursor, err := decodeViewCursor(criteria.Cursor)
+		if err != nil || cursor.SortBy != search.SortBy {
+			err := errors.New("cursor is invalid or does not belong to this sorting")
+			return ViewSearch{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+		}
+
+		search.After = &cursor
+	}
+
+	return search, nil
+}
+
+// Matches tells if the View satisfies all filters of the ViewSearch, it ignores the cursor.
+func (search ViewSearch) Matches(view View) bool {
+	if view.IsDeleted {
+		return false
+	}
+
+	if search.EmailAddress != "" && view.CanonicalEmailAddress() != value.RebuildConfirmedEmailAddress(search.EmailAddress).Canonical() {
+		return false
+	}
+
+	if search.NameFragment != "" {
+		name := strings.ToLower(view.GivenName + " " + view.FamilyName)
+
+		if !strings.Contains(name, strings.ToLower(search.NameFragment)) {
+			return false
+		}
+	}
+
+	if search.IsEmailConfirmed != nil && view.IsEmailAddressConfirmed != *search.IsEmailConfirmed {
+		return false
+	}
+
+	if !search.RegisteredFrom.IsZero() && view.RegisteredAt.Before(search.RegisteredFrom) {
+		return false
+	}
+
+	if !search.RegisteredBefore.IsZero() && !view.RegisteredAt.Before(search.RegisteredBefore) {
+		return false
+	}
+
+	return true
+}
+
+// SortsBefore tells if View a comes before View b in the sort order of the ViewSearch.
+func (search ViewSearch) SortsBefore(a, b View) bool {
+	return search.isBefore(search.SortValueOf(a), a.ID, search.SortValueOf(b), b.ID)
+}
+
+// IsAfterCursor is always true for the first page.
+func (search ViewSearch) IsAfterCursor(view View) bool {
+	if search.After == nil {
+		return true
+	}
+
+	return search.isBefore(search.After.SortValue, search.After.ID, search.SortValueOf(view), view.ID)
+}
+
+// SortValueOf returns the value of the View which the ViewSearch sorts by, registeredAt in sortable form.
+func (search ViewSearch) SortValueOf(view View) string {
+	switch search.SortBy {
+	case SortByEmailAddress:
+		return view.EmailAddress
+	case SortByFamilyName:
+		return view.FamilyName
+	default:
+		return FormatSortableTime(view.RegisteredAt)
+	}
+}
+
+func (search ViewSearch) isBefore(sortValueA, idA, sortValueB, idB string) bool {
+	if sortValueA == sortValueB {
+		sortValueA, sortValueB = idA, idB
+	}
+
+	if search.SortDescending {
+		return sortValueA > sortValueB
+	}
+
+	return sortValueA < sortValueB
+}
+
+// BuildViewPageFrom expects the matching Views after the cursor in sort order, one more than the PageSize if there
+// is a next page.
+func BuildViewPageFrom(search ViewSearch, views []View) ViewPage {
+	if len(views) <= search.PageSize {
+		return ViewPage{Views: views}
+	}
+
+	views = views[:search.PageSize]
+	lastView := views[len(views)-1]
+
+	cursor := ViewCursor{
+		SortBy:    search.SortBy,
+		SortValue: search.SortValueOf(lastView),
+		ID:        lastView.ID,
+	}
+
+	return ViewPage{Views: views, NextCursor: cursor.encode()}
+}
+
+func FormatSortableTime(t time.Time) string {
+	return t.UTC().Format(sortableTimeFormat)
+}
+
+func ParseSortableTime(input string) (time.Time, error) {
+	return time.Parse(sortableTimeFormat, input)
+}
+
+func (cursor ViewCursor) encode() string {
+	encoded, _ := json.Marshal(cursor) // a struct of strings can't fail to marshal
+
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeViewCursor(input string) (ViewCursor, error) {
+	cursor := ViewCursor{}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return ViewCursor{}, err
+	}
+
+	if err = json.Unmarshal(decoded, &cursor); err != nil {
+		return ViewCursor{}, err
+	}
+
+	if cursor.SortBy == SortByRegisteredAt {
+		if _, err = ParseSortableTime(cursor.SortValue); err != nil {
+			return ViewCursor{}, err
+		}
+	}
+
+	return cursor, nil
+}
+
+func parseTimestampOrDate(input string, name string) (time.Time, error) {
+	if input == "" {
+		return time.Time{}, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, input); err == nil {
+		return parsed.UTC(), nil
+	}
+
+	if parsed, err := time.Parse("2006-01-02", input); err == nil {
+		return parsed, nil
+	}
+
+	err := errors.Newf("%s must be an RFC3339 timestamp or a date like 2006-01-02", name)
+
+	return time.Time{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "parseTimestampOrDate")
+}
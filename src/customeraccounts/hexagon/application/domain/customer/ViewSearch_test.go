@@ -0,0 +1,226 @@
+package customer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildViewSearch(t *testing.T) {
+	Convey("When a ViewSearch is built without criteria", t, func() {
+		search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{})
+		So(err, ShouldBeNil)
+
+		Convey("Then it should list all Customers by registration date, with the default page size", func() {
+			So(search.SortBy, ShouldEqual, customer.SortByRegisteredAt)
+			So(search.SortDescending, ShouldBeFalse)
+			So(search.PageSize, ShouldEqual, customer.DefaultViewPageSize)
+			So(search.IsEmailConfirmed, ShouldBeNil)
+			So(search.After, ShouldBeNil)
+		})
+	})
+
+	Convey("When a ViewSearch is built with all criteria", t, func() {
+		search, err := customer.BuildViewSearch(
+			customer.ViewSearchCriteria{
+				EmailAddress:       " Kevin@Ball.COM ",
+				NameFragment:       " ball ",
+				ConfirmationStatus: customer.ConfirmationStatusUnconfirmed,
+				RegisteredFrom:     "2020-12-01",
+				RegisteredBefore:   "2020-12-24T18:00:00+01:00",
+				SortBy:             customer.SortByFamilyName,
+				SortDescending:     true,
+				PageSize:           50,
+			},
+		)
+
+		So(err, ShouldBeNil)
+
+		Convey("Then it should contain the normalized criteria", func() {
+			So(search.EmailAddress, ShouldEqual, "Kevin@ball.com")
+			So(search.NameFragment, ShouldEqual, "ball")
+			So(*search.IsEmailConfirmed, ShouldBeFalse)
+			So(search.RegisteredFrom, ShouldResemble, time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC))
+			So(search.RegisteredBefore, ShouldResemble, time.Date(2020, 12, 24, 17, 0, 0, 0, time.UTC))
+			So(search.SortBy, ShouldEqual, customer.SortByFamilyName)
+			So(search.SortDescending, ShouldBeTrue)
+			So(search.PageSize, ShouldEqual, 50)
+		})
+	})
+
+	invalidCriteria := map[string]customer.ViewSearchCriteria{
+		"an invalid email address":       {EmailAddress: "kevin"},
+		"an unknown confirmation status": {ConfirmationStatus: "maybe"},
+		"an invalid registration date":   {RegisteredFrom: "yesterday"},
+		"an unknown sort field":          {SortBy: "shoeSize"},
+		"a too big page size":            {PageSize: customer.MaxViewPageSize + 1},
+		"an invalid cursor":              {Cursor: "not-a-cursor"},
+	}
+
+	for description, criteria := range invalidCriteria {
+		criteria := criteria
+
+		Convey("When a ViewSearch is built with "+description, t, func() {
+			_, err := customer.BuildViewSearch(criteria)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+		})
+	}
+}
+
+func TestViewSearch(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		registeredAt := time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC)
+
+		kevin := customer.View{
+			ID:                      "b",
+			EmailAddress:            "kevin@ball.com",
+			IsEmailAddressConfirmed: true,
+			GivenName:               "Kevin",
+			FamilyName:              "Ball",
+			RegisteredAt:            registeredAt,
+		}
+
+		veronica := customer.View{
+			ID:           "a",
+			EmailAddress: "veronica@fisher.com",
+			GivenName:    "Veronica",
+			FamilyName:   "Fisher",
+			RegisteredAt: registeredAt.Add(time.Hour),
+		}
+
+		deleted := customer.View{
+			ID:           "c",
+			EmailAddress: "svetlana@yevgenivna.com",
+			GivenName:    "Svetlana",
+			FamilyName:   "Yevgenivna",
+			IsDeleted:    true,
+			RegisteredAt: registeredAt,
+		}
+
+		Convey("When Views are matched against a ViewSearch with filters", func() {
+			search, err := customer.BuildViewSearch(
+				customer.ViewSearchCriteria{
+					NameFragment:       "in ba",
+					ConfirmationStatus: customer.ConfirmationStatusConfirmed,
+					RegisteredBefore:   "2020-12-25",
+				},
+			)
+
+			So(err, ShouldBeNil)
+
+			Convey("Then only the View which satisfies all filters should match", func() {
+				So(search.Matches(kevin), ShouldBeTrue)
+				So(search.Matches(veronica), ShouldBeFalse)
+			})
+		})
+
+		Convey("When Views are matched against a ViewSearch for an email address", func() {
+			withInternationalizedDomain := customer.View{
+				ID:           "d",
+				EmailAddress: "jane@bücher.de",
+				GivenName:    "Jane",
+				FamilyName:   "Doe",
+				RegisteredAt: registeredAt,
+			}
+
+			search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{EmailAddress: "JANE@xn--bcher-kva.de"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the View should match regardless of case and of the form of its domain", func() {
+				So(search.Matches(withInternationalizedDomain), ShouldBeTrue)
+				So(search.Matches(kevin), ShouldBeFalse)
+			})
+		})
+
+		Convey("When Views are matched against a ViewSearch without filters", func() {
+			search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{})
+			So(err, ShouldBeNil)
+
+			Convey("Then deleted Views should still not match", func() {
+				So(search.Matches(kevin), ShouldBeTrue)
+				So(search.Matches(deleted), ShouldBeFalse)
+			})
+		})
+
+		Convey("When Views are sorted by registration date", func() {
+			search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the earlier registration should come first", func() {
+				So(search.SortsBefore(kevin, veronica), ShouldBeTrue)
+				So(search.SortsBefore(veronica, kevin), ShouldBeFalse)
+			})
+
+			Convey("Then the id should break ties", func() {
+				So(search.SortsBefore(kevin, deleted), ShouldBeTrue)
+			})
+		})
+
+		Convey("When Views are sorted by family name, descending", func() {
+			search, err := customer.BuildViewSearch(
+				customer.ViewSearchCriteria{SortBy: customer.SortByFamilyName, SortDescending: true},
+			)
+
+			So(err, ShouldBeNil)
+
+			Convey("Then the later family name should come first", func() {
+				So(search.SortsBefore(veronica, kevin), ShouldBeTrue)
+			})
+		})
+
+		Convey("When a ViewPage is built from one View more than the page size", func() {
+			search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{PageSize: 1})
+			So(err, ShouldBeNil)
+
+			page := customer.BuildViewPageFrom(search, []customer.View{kevin, veronica})
+
+			Convey("Then it should only contain the page size", func() {
+				So(page.Views, ShouldResemble, []customer.View{kevin})
+				So(page.NextCursor, ShouldNotBeEmpty)
+			})
+
+			Convey("and when the next ViewSearch continues after its cursor", func() {
+				nextSearch, err := customer.BuildViewSearch(
+					customer.ViewSearchCriteria{PageSize: 1, Cursor: page.NextCursor},
+				)
+
+				So(err, ShouldBeNil)
+
+				Convey("Then only the Views after the last View of the page should follow", func() {
+					So(nextSearch.IsAfterCursor(kevin), ShouldBeFalse)
+					So(nextSearch.IsAfterCursor(veronica), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when the cursor is used with another sorting", func() {
+				_, err := customer.BuildViewSearch(
+					customer.ViewSearchCriteria{SortBy: customer.SortByEmailAddress, Cursor: page.NextCursor},
+				)
+
+				Convey("Then it should fail", func() {
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When a ViewPage is built from no more Views than the page size", func() {
+			search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{PageSize: 2})
+			So(err, ShouldBeNil)
+
+			page := customer.BuildViewPageFrom(search, []customer.View{kevin, veronica})
+
+			Convey("Then it should be the last page", func() {
+				So(page.Views, ShouldHaveLength, 2)
+				So(page.NextCursor, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// assertConfirmationNotLockedOut fails if maxFailedAttempts confirmation attempts failed within the lockoutDuration,
+// so that the ConfirmationHash can't be brute-forced. A maxFailedAttempts of 0 disables the lockout.
+func assertConfirmationNotLockedOut(currentState currentState, maxFailedAttempts uint, lockoutDuration time.Duration) error {
+	lockedUntil, isLockedOut := lockedOutUntil(currentState.confirmationFailures, maxFailedAttempts, lockoutDuration)
+	if !isLockedOut {
+		return nil
+	}
+
+	err := errors.Newf(
+		"too many failed confirmation attempts, retry in [%s] or request a new confirmation hash",
+		time.Until(lockedUntil).Round(time.Second),
+	)
+
+	return errors.Mark(err, shared.ErrDomainConstraintsViolation)
+}
+
+// assertPhoneNumberConfirmationNotLockedOut is the same for the ConfirmationCode, which is much easier to guess.
+func assertPhoneNumberConfirmationNotLockedOut(
+	currentState currentState,
+	maxFailedAttempts uint,
+	lockoutDuration time.Duration,
+) error {
+
+	lockedUntil, isLockedOut := lockedOutUntil(currentState.phoneNumberConfirmationFailures, maxFailedAttempts, lockoutDuration)
+	if !isLockedOut {
+		return nil
+	}
+
+	err := errors.Newf(
+		"too many failed confirmation attempts, retry in [%s] or change the phone number",
+		time.Until(lockedUntil).Round(time.Second),
+	)
+
+	return errors.Mark(err, shared.ErrDomainConstraintsViolation)
+}
+
+func lockedOutUntil(confirmationFailures []time.Time, maxFailedAttempts uint, lockoutDuration time.Duration) (time.Time, bool) {
+	if maxFailedAttempts == 0 {
+		return time.Time{}, false
+	}
+
+	var recentFailures []time.Time
+
+	for _, failedAt := range confirmationFailures {
+		if time.Since(failedAt) < lockoutDuration {
+			recentFailures = append(recentFailures, failedAt)
+		}
+	}
+
+	if uint(len(recentFailures)) < maxFailedAttempts {
+		return time.Time{}, false
+	}
+
+	return recentFailures[uint(len(recentFailures))-maxFailedAttempts].Add(lockoutDuration), true
+}
@@ -0,0 +1,21 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// assertExpectedVersion passes if no expectedVersion was given (0), so clients can opt in to optimistic locking.
+func assertExpectedVersion(currentState currentState, expectedVersion uint) error {
+	if expectedVersion != 0 && expectedVersion != currentState.currentStreamVersion {
+		err := errors.Newf(
+			"customer is at version [%d] but version [%d] was expected",
+			currentState.currentStreamVersion,
+			expectedVersion,
+		)
+
+		return errors.Mark(err, shared.ErrVersionMismatch)
+	}
+
+	return nil
+}
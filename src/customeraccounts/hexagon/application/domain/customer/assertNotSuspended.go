@@ -0,0 +1,16 @@
+package customer
+
+import (
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+func assertNotSuspended(currentState currentState) error {
+	if currentState.isSuspended {
+		err := errors.Newf("customer is suspended: %s", currentState.suspensionReason)
+
+		return errors.Mark(err, shared.ErrDomainConstraintsViolation)
+	}
+
+	return nil
+}
@@ -1,17 +1,29 @@
 package customer
 
 import (
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 )
 
 type currentState struct {
-	id                   value.CustomerID
-	personName           value.PersonName
-	emailAddress         value.EmailAddress
-	isDeleted            bool
-	currentStreamVersion uint
+	id                              value.CustomerID
+	personName                      value.PersonName
+	emailAddress                    value.EmailAddress
+	registeredAt                    time.Time
+	isDeleted                       bool
+	deletedAt                       time.Time
+	isPersonalDataErased            bool
+	isSuspended                     bool
+	suspensionReason                value.SuspensionReason
+	pendingEmailAddress             *value.UnconfirmedEmailAddress // waits for its confirmation, while emailAddress stays active
+	confirmationFailures            []time.Time                    // of the current ConfirmationHash
+	addresses                       map[value.AddressKind]value.Address
+	phoneNumber                     value.PhoneNumber // nil if the Customer has none
+	phoneNumberConfirmationFailures []time.Time       // of the current ConfirmationCode
+	currentStreamVersion            uint
 }
 
 func buildCurrentStateFrom(eventStream es.EventStream) currentState {
@@ -19,20 +31,88 @@ func buildCurrentStateFrom(eventStream es.EventStream) currentState {
 
 	for _, event := range eventStream {
 		switch actualEvent := event.(type) {
+		case domain.CustomerSnapshot:
+			customer.id = actualEvent.CustomerID()
+			customer.personName = actualEvent.PersonName()
+			customer.emailAddress = actualEvent.EmailAddress()
+			customer.registeredAt = actualEvent.RegisteredAt()
+			customer.isDeleted = actualEvent.IsDeleted()
+			customer.deletedAt = actualEvent.DeletedAt()
+			customer.isPersonalDataErased = actualEvent.IsPersonalDataErased()
+			customer.isSuspended = actualEvent.IsSuspended()
+			customer.suspensionReason = actualEvent.SuspensionReason()
+			customer.pendingEmailAddress = actualEvent.PendingEmailAddress()
+			customer.confirmationFailures = actualEvent.RecentConfirmationFailures()
+			customer.addresses = copyAddresses(actualEvent.Addresses())
+			customer.phoneNumber = actualEvent.PhoneNumber()
+			customer.phoneNumberConfirmationFailures = actualEvent.RecentPhoneNumberConfirmationFailures()
 		case domain.CustomerRegistered:
 			customer.id = actualEvent.CustomerID()
 			customer.personName = actualEvent.PersonName()
 			customer.emailAddress = actualEvent.EmailAddress()
+			customer.registeredAt = occurredAt(actualEvent)
 		case domain.CustomerEmailAddressConfirmed:
 			customer.emailAddress = actualEvent.EmailAddress()
+			customer.confirmationFailures = nil
 		case domain.CustomerEmailAddressChanged:
 			customer.emailAddress = actualEvent.EmailAddress()
+			customer.pendingEmailAddress = nil
+			customer.confirmationFailures = nil
+		case domain.CustomerEmailAddressChangeRequested:
+			pendingEmailAddress := actualEvent.EmailAddress()
+			customer.pendingEmailAddress = &pendingEmailAddress
+			customer.confirmationFailures = nil
+		case domain.CustomerEmailAddressChangeCancelled:
+			customer.pendingEmailAddress = nil
+			customer.confirmationFailures = nil
+		case domain.CustomerEmailAddressChangeConfirmed:
+			customer.emailAddress = actualEvent.EmailAddress()
+			customer.pendingEmailAddress = nil
+			customer.confirmationFailures = nil
+		case domain.CustomerEmailAddressConfirmationResendRequested:
+			customer.emailAddress = actualEvent.EmailAddress()
+			customer.confirmationFailures = nil
 		case domain.CustomerNameChanged:
 			customer.personName = actualEvent.PersonName()
+		case domain.CustomerAddressAdded:
+			customer.addresses = withAddress(customer.addresses, actualEvent.AddressKind(), actualEvent.Address())
+		case domain.CustomerAddressChanged:
+			customer.addresses = withAddress(customer.addresses, actualEvent.AddressKind(), actualEvent.Address())
+		case domain.CustomerAddressRemoved:
+			delete(customer.addresses, actualEvent.AddressKind())
+		case domain.CustomerPhoneNumberChanged:
+			customer.phoneNumber = actualEvent.PhoneNumber()
+			customer.phoneNumberConfirmationFailures = nil
+		case domain.CustomerPhoneNumberConfirmed:
+			customer.phoneNumber = actualEvent.PhoneNumber()
+			customer.phoneNumberConfirmationFailures = nil
+		case domain.CustomerPhoneNumberConfirmationFailed:
+			customer.phoneNumberConfirmationFailures = append(customer.phoneNumberConfirmationFailures, occurredAt(actualEvent))
+		case domain.CustomerSuspended:
+			customer.isSuspended = true
+			customer.suspensionReason = actualEvent.Reason()
+		case domain.CustomerReactivated:
+			customer.isSuspended = false
+			customer.suspensionReason = ""
 		case domain.CustomerDeleted:
 			customer.isDeleted = true
+			customer.deletedAt = occurredAt(actualEvent)
+		case domain.CustomerPersonalDataErased:
+			if !customer.isDeleted {
+				customer.isDeleted = true
+				customer.deletedAt = occurredAt(actualEvent)
+			}
+
+			customer.isPersonalDataErased = true
+			customer.pendingEmailAddress = nil
+			customer.addresses = nil
+			customer.phoneNumber = nil
+			customer.phoneNumberConfirmationFailures = nil
+		case domain.CustomerRestored:
+			customer.isDeleted = false
+			customer.deletedAt = time.Time{}
 		case domain.CustomerEmailAddressConfirmationFailed:
-			// nothing to project here
+			customer.confirmationFailures = append(customer.confirmationFailures, occurredAt(actualEvent))
 		default:
 			// until Go has "sum types" we need to use an interface (Event) and this case could exist - we don't want to hide it
 			panic("buildCurrentStateFrom(eventStream): unknown event " + event.Meta().EventName())
@@ -43,3 +123,43 @@ func buildCurrentStateFrom(eventStream es.EventStream) currentState {
 
 	return customer
 }
+
+// copyAddresses makes sure that the addresses of a snapshot are never changed when later events are applied.
+func copyAddresses(addresses map[value.AddressKind]value.Address) map[value.AddressKind]value.Address {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	copied := make(map[value.AddressKind]value.Address, len(addresses))
+
+	for kind, address := range addresses {
+		copied[kind] = address
+	}
+
+	return copied
+}
+
+func withAddress(
+	addresses map[value.AddressKind]value.Address,
+	kind value.AddressKind,
+	address value.Address,
+) map[value.AddressKind]value.Address {
+
+	if addresses == nil {
+		addresses = make(map[value.AddressKind]value.Address)
+	}
+
+	addresses[kind] = address
+
+	return addresses
+}
+
+// occurredAt returns the zero time if the event's timestamp can't be parsed, so such events never count as recent.
+func occurredAt(event es.DomainEvent) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, event.Meta().OccurredAt())
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed.UTC()
+}
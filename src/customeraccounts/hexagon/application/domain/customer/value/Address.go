@@ -0,0 +1,109 @@
+package value
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// postalCodeFormats are the countries (ISO 3166-1 alpha-2) the shop delivers to, with the format of their postal codes.
+var postalCodeFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"IE": regexp.MustCompile(`^[A-Z]\d[\dW] ?[A-Z\d]{4}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"LU": regexp.MustCompile(`^\d{4}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+// Address is a postal address, e.g. for billing or shipping.
+type Address struct {
+	street      string
+	postalCode  string
+	city        string
+	countryCode string
+}
+
+// BuildAddress trims all inputs and uppercases the countryCode and the postalCode, before the postalCode is
+// validated with the format of the country.
+func BuildAddress(street, postalCode, city, countryCode string) (Address, error) {
+	wrapWithMsg := "BuildAddress"
+
+	street = strings.TrimSpace(street)
+	postalCode = strings.ToUpper(strings.TrimSpace(postalCode))
+	city = strings.TrimSpace(city)
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+
+	if street == "" {
+		err := errors.New("empty input for street")
+		return Address{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	if city == "" {
+		err := errors.New("empty input for city")
+		return Address{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	postalCodeFormat, found := postalCodeFormats[countryCode]
+	if !found {
+		err := errors.Newf("unsupported input for countryCode [%s]", countryCode)
+		return Address{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	if !postalCodeFormat.MatchString(postalCode) {
+		err := errors.Newf("invalid input for postalCode [%s] in country [%s]", postalCode, countryCode)
+		return Address{}, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	address := Address{
+		street:      street,
+		postalCode:  postalCode,
+		city:        city,
+		countryCode: countryCode,
+	}
+
+	return address, nil
+}
+
+func RebuildAddress(street, postalCode, city, countryCode string) Address {
+	address := Address{
+		street:      street,
+		postalCode:  postalCode,
+		city:        city,
+		countryCode: countryCode,
+	}
+
+	return address
+}
+
+func (address Address) Street() string {
+	return address.street
+}
+
+func (address Address) PostalCode() string {
+	return address.postalCode
+}
+
+func (address Address) City() string {
+	return address.city
+}
+
+func (address Address) CountryCode() string {
+	return address.countryCode
+}
+
+func (address Address) Equals(other Address) bool {
+	return address == other
+}
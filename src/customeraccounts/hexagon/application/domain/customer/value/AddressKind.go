@@ -0,0 +1,40 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	AddressKindBilling  AddressKind = "billing"
+	AddressKindShipping AddressKind = "shipping"
+)
+
+// AddressKind identifies an Address of a Customer, she can have one Address of each kind.
+type AddressKind string
+
+func BuildAddressKind(input string) (AddressKind, error) {
+	switch kind := AddressKind(strings.ToLower(strings.TrimSpace(input))); kind {
+	case AddressKindBilling, AddressKindShipping:
+		return kind, nil
+	default:
+		err := errors.Newf("unsupported input for addressKind [%s]", input)
+		err = shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "BuildAddressKind")
+
+		return "", err
+	}
+}
+
+func RebuildAddressKind(input string) AddressKind {
+	return AddressKind(input)
+}
+
+func (kind AddressKind) String() string {
+	return string(kind)
+}
+
+func (kind AddressKind) Equals(other AddressKind) bool {
+	return kind.String() == other.String()
+}
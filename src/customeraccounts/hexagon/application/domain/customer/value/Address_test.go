@@ -0,0 +1,110 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildAddress(t *testing.T) {
+	Convey("When an Address is built from valid input", t, func() {
+		address, err := value.BuildAddress(" Hauptstraße 1 ", "10115", " Berlin ", "de")
+
+		Convey("Then it should be normalized", func() {
+			So(err, ShouldBeNil)
+			So(address.Street(), ShouldEqual, "Hauptstraße 1")
+			So(address.PostalCode(), ShouldEqual, "10115")
+			So(address.City(), ShouldEqual, "Berlin")
+			So(address.CountryCode(), ShouldEqual, "DE")
+		})
+	})
+
+	Convey("When Addresses are built with the postal code formats of different countries", t, func() {
+		validPostalCodes := map[string]string{
+			"AT": "1010",
+			"CA": "k1a 0b1",
+			"GB": "SW1A 1AA",
+			"NL": "1012 AB",
+			"PL": "00-950",
+			"US": "20500-0003",
+		}
+
+		for countryCode, postalCode := range validPostalCodes {
+			_, err := value.BuildAddress("Main Street 1", postalCode, "City", countryCode)
+
+			Convey("Then the valid postal code of "+countryCode+" should be accepted", func() {
+				So(err, ShouldBeNil)
+			})
+		}
+
+		invalidPostalCodes := map[string]string{
+			"AT": "10115",
+			"DE": "1010",
+			"GB": "12345",
+			"NL": "AB 1012",
+			"US": "2050",
+		}
+
+		for countryCode, postalCode := range invalidPostalCodes {
+			_, err := value.BuildAddress("Main Street 1", postalCode, "City", countryCode)
+
+			Convey("Then the invalid postal code of "+countryCode+" should be rejected", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+		}
+	})
+
+	Convey("When an Address is built with an unsupported country", t, func() {
+		_, err := value.BuildAddress("Main Street 1", "12345", "City", "XX")
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldBeError)
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+
+	Convey("When an Address is built with an empty street", t, func() {
+		_, err := value.BuildAddress(" ", "10115", "Berlin", "DE")
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldBeError)
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+
+	Convey("When an Address is built with an empty city", t, func() {
+		_, err := value.BuildAddress("Hauptstraße 1", "10115", "", "DE")
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldBeError)
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+}
+
+func TestBuildAddressKind(t *testing.T) {
+	Convey("When AddressKinds are built from supported input", t, func() {
+		billing, errBilling := value.BuildAddressKind("billing")
+		shipping, errShipping := value.BuildAddressKind(" Shipping ")
+
+		Convey("Then they should be built", func() {
+			So(errBilling, ShouldBeNil)
+			So(billing, ShouldEqual, value.AddressKindBilling)
+			So(errShipping, ShouldBeNil)
+			So(shipping, ShouldEqual, value.AddressKindShipping)
+		})
+	})
+
+	Convey("When an AddressKind is built from unsupported input", t, func() {
+		_, err := value.BuildAddressKind("holiday")
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldBeError)
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package value
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+const confirmationCodeDigits = 6
+
+var (
+	confirmationCodeRegExp = regexp.MustCompile(fmt.Sprintf(`^[0-9]{%d}$`, confirmationCodeDigits))
+)
+
+// ConfirmationCode is the short numeric counterpart of the ConfirmationHash, which is sent to a phone number.
+// Other than the hash it's easy to guess, so it's generated with a secure random number and should expire soon.
+type ConfirmationCode string
+
+func GenerateConfirmationCode() ConfirmationCode {
+	upperBound := big.NewInt(1)
+	upperBound.Exp(big.NewInt(10), big.NewInt(confirmationCodeDigits), nil)
+
+	randomInt, err := rand.Int(rand.Reader, upperBound)
+	if err != nil {
+		panic("GenerateConfirmationCode(): the secure random number generator failed: " + err.Error())
+	}
+
+	return ConfirmationCode(fmt.Sprintf("%0*d", confirmationCodeDigits, randomInt.Int64()))
+}
+
+func BuildConfirmationCode(input string) (ConfirmationCode, error) {
+	if matched := confirmationCodeRegExp.MatchString(input); !matched {
+		err := errors.Newf("invalid input for confirmationCode [%s]", input)
+		err = shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "BuildConfirmationCode")
+
+		return "", err
+	}
+
+	confirmationCode := ConfirmationCode(input)
+
+	return confirmationCode, nil
+}
+
+func RebuildConfirmationCode(input string) ConfirmationCode {
+	return ConfirmationCode(input)
+}
+
+func (confirmationCode ConfirmationCode) String() string {
+	return string(confirmationCode)
+}
+
+func (confirmationCode ConfirmationCode) Equals(other ConfirmationCode) bool {
+	return confirmationCode.String() == other.String()
+}
@@ -1,15 +1,19 @@
 package value
 
 import (
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/cockroachdb/errors"
 )
 
 type ConfirmedEmailAddress string
 
+// ConfirmEmailAddressWithHash only accepts hashes younger than confirmationHashTTL, a TTL of 0 disables the expiry.
 func ConfirmEmailAddressWithHash(
 	emailAddress UnconfirmedEmailAddress,
 	confirmationHash ConfirmationHash,
+	confirmationHashTTL time.Duration,
 ) (ConfirmedEmailAddress, error) {
 
 	if !emailAddress.confirmationHash.Equals(confirmationHash) {
@@ -19,6 +23,13 @@ func ConfirmEmailAddressWithHash(
 		)
 	}
 
+	if emailAddress.isConfirmationHashExpired(confirmationHashTTL) {
+		return "", errors.Mark(
+			errors.New("confirmEmailAddressWithHash: confirmation hash expired"),
+			shared.ErrDomainConstraintsViolation,
+		)
+	}
+
 	return ConfirmedEmailAddress(emailAddress.String()), nil
 }
 
@@ -30,6 +41,10 @@ func (emailAddress ConfirmedEmailAddress) String() string {
 	return string(emailAddress)
 }
 
+func (emailAddress ConfirmedEmailAddress) Canonical() string {
+	return canonicalEmailAddress(string(emailAddress))
+}
+
 func (emailAddress ConfirmedEmailAddress) Equals(other EmailAddress) bool {
 	return emailAddress.String() == other.String()
 }
@@ -0,0 +1,46 @@
+package value
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+type ConfirmedPhoneNumber string
+
+// ConfirmPhoneNumberWithCode only accepts codes younger than confirmationCodeTTL, a TTL of 0 disables the expiry.
+func ConfirmPhoneNumberWithCode(
+	phoneNumber UnconfirmedPhoneNumber,
+	confirmationCode ConfirmationCode,
+	confirmationCodeTTL time.Duration,
+) (ConfirmedPhoneNumber, error) {
+
+	if !phoneNumber.confirmationCode.Equals(confirmationCode) {
+		return "", errors.Mark(
+			errors.New("confirmPhoneNumberWithCode: wrong confirmation code supplied"),
+			shared.ErrDomainConstraintsViolation,
+		)
+	}
+
+	if phoneNumber.isConfirmationCodeExpired(confirmationCodeTTL) {
+		return "", errors.Mark(
+			errors.New("confirmPhoneNumberWithCode: confirmation code expired"),
+			shared.ErrDomainConstraintsViolation,
+		)
+	}
+
+	return ConfirmedPhoneNumber(phoneNumber.String()), nil
+}
+
+func RebuildConfirmedPhoneNumber(input string) ConfirmedPhoneNumber {
+	return ConfirmedPhoneNumber(input)
+}
+
+func (phoneNumber ConfirmedPhoneNumber) String() string {
+	return string(phoneNumber)
+}
+
+func (phoneNumber ConfirmedPhoneNumber) Equals(other PhoneNumber) bool {
+	return phoneNumber.String() == other.String()
+}
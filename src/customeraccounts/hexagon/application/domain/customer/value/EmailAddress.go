@@ -1,6 +1,54 @@
 package value
 
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/net/idna"
+)
+
 type EmailAddress interface {
 	String() string
+	Canonical() string
 	Equals(other EmailAddress) bool
 }
+
+// normalizeEmailAddress trims the input and lowercases the domain, the local part is kept as the customer typed it.
+func normalizeEmailAddress(input string) (string, error) {
+	normalized := strings.TrimSpace(input)
+
+	if matched := emailAddressRegExp.MatchString(normalized); !matched {
+		return "", errors.New("input has invalid format")
+	}
+
+	localPart, domain := splitEmailAddress(normalized)
+	domain = strings.ToLower(domain)
+
+	if _, err := idna.Lookup.ToASCII(domain); err != nil {
+		return "", errors.Wrap(err, "input has invalid domain")
+	}
+
+	return localPart + "@" + domain, nil
+}
+
+// canonicalEmailAddress is the form which must be unique: all lowercase and with the domain in punycode.
+// It never fails, so that it also works for email addresses which were recorded before they were normalized.
+func canonicalEmailAddress(emailAddress string) string {
+	localPart, domain := splitEmailAddress(strings.TrimSpace(emailAddress))
+	domain = strings.ToLower(domain)
+
+	if asciiDomain, err := idna.Lookup.ToASCII(domain); err == nil {
+		domain = asciiDomain
+	}
+
+	return strings.ToLower(localPart) + "@" + domain
+}
+
+func splitEmailAddress(emailAddress string) (localPart, domain string) {
+	at := strings.LastIndex(emailAddress, "@")
+	if at < 0 {
+		return emailAddress, ""
+	}
+
+	return emailAddress[:at], emailAddress[at+1:]
+}
@@ -0,0 +1,35 @@
+package value
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	e164PhoneNumberRegExp        = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	phoneNumberSeparatorReplacer = strings.NewReplacer(" ", "", "-", "", ".", "", "/", "", "(", "", ")", "")
+)
+
+type PhoneNumber interface {
+	String() string
+	Equals(other PhoneNumber) bool
+}
+
+// normalizePhoneNumber removes the usual separators and converts the international prefix 00 to +,
+// then the result must be in E.164 format. National numbers without a country code are rejected,
+// because the country they belong to can't be known.
+func normalizePhoneNumber(input string) (string, error) {
+	normalized := phoneNumberSeparatorReplacer.Replace(strings.TrimSpace(input))
+
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + strings.TrimPrefix(normalized, "00")
+	}
+
+	if matched := e164PhoneNumberRegExp.MatchString(normalized); !matched {
+		return "", errors.New("input is not a phone number in international format")
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,36 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// SuspensionReason documents why a Customer was suspended, e.g. a suspicion of fraud.
+type SuspensionReason string
+
+func BuildSuspensionReason(input string) (SuspensionReason, error) {
+	reason := strings.TrimSpace(input)
+
+	if reason == "" {
+		err := errors.New("empty input for suspensionReason")
+		err = shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "BuildSuspensionReason")
+
+		return "", err
+	}
+
+	return SuspensionReason(reason), nil
+}
+
+func RebuildSuspensionReason(input string) SuspensionReason {
+	return SuspensionReason(input)
+}
+
+func (reason SuspensionReason) String() string {
+	return string(reason)
+}
+
+func (reason SuspensionReason) Equals(other SuspensionReason) bool {
+	return reason.String() == other.String()
+}
@@ -2,40 +2,46 @@ package value
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/shared"
-	"github.com/cockroachdb/errors"
 )
 
 var (
 	emailAddressRegExp = regexp.MustCompile(`^\S+@\S+\.\w{2,}$`)
 )
 
+// UnconfirmedEmailAddress keeps the email address as the customer typed it, only trimmed and with a lowercase domain.
+// UnconfirmedEmailAddress knows when its ConfirmationHash was created, so that the hash can expire.
+// The creation time is zero for email addresses recorded before hashes had one, such hashes never expire.
 type UnconfirmedEmailAddress struct {
-	value            string
-	confirmationHash ConfirmationHash
+	value                     string
+	confirmationHash          ConfirmationHash
+	confirmationHashCreatedAt time.Time
 }
 
 func BuildUnconfirmedEmailAddress(input string) (UnconfirmedEmailAddress, error) {
-	if matched := emailAddressRegExp.MatchString(input); !matched {
-		err := errors.New("input has invalid format")
+	normalized, err := normalizeEmailAddress(input)
+	if err != nil {
 		err = shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "UnconfirmedEmailAddress")
 
 		return UnconfirmedEmailAddress{}, err
 	}
 
 	emailAddress := UnconfirmedEmailAddress{
-		value:            input,
-		confirmationHash: GenerateConfirmationHash(input),
+		value:                     normalized,
+		confirmationHash:          GenerateConfirmationHash(normalized),
+		confirmationHashCreatedAt: time.Now().UTC(),
 	}
 
 	return emailAddress, nil
 }
 
-func RebuildUnconfirmedEmailAddress(input, hash string) UnconfirmedEmailAddress {
+func RebuildUnconfirmedEmailAddress(input, hash string, hashCreatedAt time.Time) UnconfirmedEmailAddress {
 	return UnconfirmedEmailAddress{
-		value:            input,
-		confirmationHash: RebuildConfirmationHash(hash),
+		value:                     input,
+		confirmationHash:          RebuildConfirmationHash(hash),
+		confirmationHashCreatedAt: hashCreatedAt,
 	}
 }
 
@@ -43,10 +49,35 @@ func (emailAddress UnconfirmedEmailAddress) String() string {
 	return emailAddress.value
 }
 
+func (emailAddress UnconfirmedEmailAddress) Canonical() string {
+	return canonicalEmailAddress(emailAddress.value)
+}
+
 func (emailAddress UnconfirmedEmailAddress) ConfirmationHash() ConfirmationHash {
 	return emailAddress.confirmationHash
 }
 
+func (emailAddress UnconfirmedEmailAddress) ConfirmationHashCreatedAt() time.Time {
+	return emailAddress.confirmationHashCreatedAt
+}
+
+// WithRegeneratedConfirmationHash returns the same email address with a new ConfirmationHash, which replaces the current one.
+func (emailAddress UnconfirmedEmailAddress) WithRegeneratedConfirmationHash() UnconfirmedEmailAddress {
+	return UnconfirmedEmailAddress{
+		value:                     emailAddress.value,
+		confirmationHash:          GenerateConfirmationHash(emailAddress.value),
+		confirmationHashCreatedAt: time.Now().UTC(),
+	}
+}
+
+func (emailAddress UnconfirmedEmailAddress) isConfirmationHashExpired(confirmationHashTTL time.Duration) bool {
+	if confirmationHashTTL == 0 || emailAddress.confirmationHashCreatedAt.IsZero() {
+		return false
+	}
+
+	return time.Since(emailAddress.confirmationHashCreatedAt) > confirmationHashTTL
+}
+
 func (emailAddress UnconfirmedEmailAddress) Equals(other EmailAddress) bool {
 	return emailAddress.String() == other.String()
 }
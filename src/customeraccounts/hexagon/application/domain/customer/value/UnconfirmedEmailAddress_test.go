@@ -0,0 +1,70 @@
+package value_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildUnconfirmedEmailAddress(t *testing.T) {
+	Convey("When an UnconfirmedEmailAddress is built from input with whitespace and an uppercase domain", t, func() {
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("  John.Doe@Example.COM ")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should keep the local part as typed, but trimmed and with a lowercase domain", func() {
+			So(emailAddress.String(), ShouldEqual, "John.Doe@example.com")
+		})
+
+		Convey("Then its canonical form should be all lowercase", func() {
+			So(emailAddress.Canonical(), ShouldEqual, "john.doe@example.com")
+		})
+	})
+
+	Convey("When an UnconfirmedEmailAddress is built with an internationalized domain", t, func() {
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("Jürgen@Müller.de")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should keep the domain readable", func() {
+			So(emailAddress.String(), ShouldEqual, "Jürgen@müller.de")
+		})
+
+		Convey("Then its canonical form should have a punycode domain", func() {
+			So(emailAddress.Canonical(), ShouldEqual, "jürgen@xn--mller-kva.de")
+		})
+	})
+
+	Convey("When an UnconfirmedEmailAddress is built from input with an invalid format", t, func() {
+		_, err := value.BuildUnconfirmedEmailAddress("john.doe.example.com")
+
+		Convey("Then it should fail", func() {
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+
+	Convey("When an UnconfirmedEmailAddress is built from input with an invalid domain", t, func() {
+		_, err := value.BuildUnconfirmedEmailAddress("john@exa_mple.com")
+
+		Convey("Then it should fail", func() {
+			So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+		})
+	})
+}
+
+func TestEmailAddress_Canonical(t *testing.T) {
+	Convey("Given email addresses which only differ in case", t, func() {
+		unconfirmedEmailAddress := value.RebuildUnconfirmedEmailAddress("John@Example.com", "some-hash", time.Time{})
+		confirmedEmailAddress := value.RebuildConfirmedEmailAddress("john@example.com")
+
+		Convey("Then they should have the same canonical form", func() {
+			So(unconfirmedEmailAddress.Canonical(), ShouldEqual, confirmedEmailAddress.Canonical())
+		})
+
+		Convey("Then they should still not be equal", func() {
+			So(unconfirmedEmailAddress.Equals(confirmedEmailAddress), ShouldBeFalse)
+		})
+	})
+}
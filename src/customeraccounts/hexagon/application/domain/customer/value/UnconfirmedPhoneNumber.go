@@ -0,0 +1,63 @@
+package value
+
+import (
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+)
+
+// UnconfirmedPhoneNumber is normalized to E.164 and knows when its ConfirmationCode was created, so that the code can expire.
+type UnconfirmedPhoneNumber struct {
+	value                     string
+	confirmationCode          ConfirmationCode
+	confirmationCodeCreatedAt time.Time
+}
+
+func BuildUnconfirmedPhoneNumber(input string) (UnconfirmedPhoneNumber, error) {
+	normalized, err := normalizePhoneNumber(input)
+	if err != nil {
+		err = shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, "UnconfirmedPhoneNumber")
+
+		return UnconfirmedPhoneNumber{}, err
+	}
+
+	phoneNumber := UnconfirmedPhoneNumber{
+		value:                     normalized,
+		confirmationCode:          GenerateConfirmationCode(),
+		confirmationCodeCreatedAt: time.Now().UTC(),
+	}
+
+	return phoneNumber, nil
+}
+
+func RebuildUnconfirmedPhoneNumber(input, code string, codeCreatedAt time.Time) UnconfirmedPhoneNumber {
+	return UnconfirmedPhoneNumber{
+		value:                     input,
+		confirmationCode:          RebuildConfirmationCode(code),
+		confirmationCodeCreatedAt: codeCreatedAt,
+	}
+}
+
+func (phoneNumber UnconfirmedPhoneNumber) String() string {
+	return phoneNumber.value
+}
+
+func (phoneNumber UnconfirmedPhoneNumber) ConfirmationCode() ConfirmationCode {
+	return phoneNumber.confirmationCode
+}
+
+func (phoneNumber UnconfirmedPhoneNumber) ConfirmationCodeCreatedAt() time.Time {
+	return phoneNumber.confirmationCodeCreatedAt
+}
+
+func (phoneNumber UnconfirmedPhoneNumber) isConfirmationCodeExpired(confirmationCodeTTL time.Duration) bool {
+	if confirmationCodeTTL == 0 {
+		return false
+	}
+
+	return time.Since(phoneNumber.confirmationCodeCreatedAt) > confirmationCodeTTL
+}
+
+func (phoneNumber UnconfirmedPhoneNumber) Equals(other PhoneNumber) bool {
+	return phoneNumber.String() == other.String()
+}
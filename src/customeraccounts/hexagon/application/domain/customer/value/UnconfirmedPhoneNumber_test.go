@@ -0,0 +1,93 @@
+package value_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildUnconfirmedPhoneNumber(t *testing.T) {
+	Convey("When an UnconfirmedPhoneNumber is built from input with separators", t, func() {
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber(" +49 (30) 1234-5678 ")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should be normalized to E.164", func() {
+			So(phoneNumber.String(), ShouldEqual, "+493012345678")
+		})
+
+		Convey("Then it should have a numeric ConfirmationCode with 6 digits", func() {
+			So(phoneNumber.ConfirmationCode().String(), ShouldHaveLength, 6)
+			_, err := value.BuildConfirmationCode(phoneNumber.ConfirmationCode().String())
+			So(err, ShouldBeNil)
+			So(phoneNumber.ConfirmationCodeCreatedAt(), ShouldNotBeZeroValue)
+		})
+	})
+
+	Convey("When an UnconfirmedPhoneNumber is built with the international prefix 00", t, func() {
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("0044 20 7946 0958")
+		So(err, ShouldBeNil)
+
+		Convey("Then the prefix should be replaced with +", func() {
+			So(phoneNumber.String(), ShouldEqual, "+442079460958")
+		})
+	})
+
+	for _, input := range []string{"", "030 12345678", "+49 30 abc", "+0123456789", "+1234", "+1234567890123456"} {
+		invalidInput := input
+
+		Convey("When an UnconfirmedPhoneNumber is built from the invalid input ["+invalidInput+"]", t, func() {
+			_, err := value.BuildUnconfirmedPhoneNumber(invalidInput)
+
+			Convey("Then it should fail", func() {
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+		})
+	}
+}
+
+func TestConfirmPhoneNumberWithCode(t *testing.T) {
+	Convey("Given an UnconfirmedPhoneNumber", t, func() {
+		phoneNumber := value.RebuildUnconfirmedPhoneNumber("+493012345678", "123456", time.Now().UTC())
+
+		Convey("When it is confirmed with its code", func() {
+			confirmedPhoneNumber, err := value.ConfirmPhoneNumberWithCode(phoneNumber, "123456", time.Minute)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be confirmed", func() {
+				So(confirmedPhoneNumber.Equals(phoneNumber), ShouldBeTrue)
+			})
+		})
+
+		Convey("When it is confirmed with a wrong code", func() {
+			_, err := value.ConfirmPhoneNumberWithCode(phoneNumber, "654321", time.Minute)
+
+			Convey("Then it should fail", func() {
+				So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given an UnconfirmedPhoneNumber with an old code", t, func() {
+		phoneNumber := value.RebuildUnconfirmedPhoneNumber("+493012345678", "123456", time.Now().Add(-time.Hour).UTC())
+
+		Convey("When it is confirmed with its code after the code expired", func() {
+			_, err := value.ConfirmPhoneNumberWithCode(phoneNumber, "123456", time.Minute)
+
+			Convey("Then it should fail", func() {
+				So(errors.Is(err, shared.ErrDomainConstraintsViolation), ShouldBeTrue)
+			})
+		})
+
+		Convey("When it is confirmed with its code and the expiry is disabled", func() {
+			_, err := value.ConfirmPhoneNumberWithCode(phoneNumber, "123456", 0)
+
+			Convey("Then it should be confirmed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
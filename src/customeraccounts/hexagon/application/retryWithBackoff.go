@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// retryWithBackoff doubles the retryBackoff after each failed attempt and gives up after maxAttempts.
+// Errors marked as shared.ErrInputIsInvalid are returned right away, because retrying them would never succeed.
+func retryWithBackoff(ctx context.Context, fn func() error, maxAttempts uint8, retryBackoff time.Duration) error {
+	var err error
+
+	for attempt := uint8(1); ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if errors.Is(err, shared.ErrInputIsInvalid) {
+			return err
+		}
+
+		if attempt >= maxAttempts {
+			return errors.Wrap(err, shared.ErrMaxRetriesExceeded.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.WithSecondaryError(ctx.Err(), err)
+		case <-time.After(retryBackoff * time.Duration(1<<(attempt-1))):
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package email
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// FileSender is a local stand-in for a mail server which appends each message as one line of json to a file.
+type FileSender struct {
+	mutex    sync.Mutex
+	from     string
+	filePath string
+}
+
+func NewFileSender(from string, filePath string) *FileSender {
+	return &FileSender{
+		from:     from,
+		filePath: filePath,
+	}
+}
+
+func (s *FileSender) SendConfirmationEmail(_ context.Context, confirmationEmail application.ConfirmationEmail) error {
+	wrapWithMsg := "fileSender.SendConfirmationEmail"
+
+	message, err := renderConfirmationEmail(s.from, confirmationEmail)
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	line, err := jsoniter.ConfigFastest.Marshal(message)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if err = file.Close(); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package email
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/cockroachdb/errors"
+)
+
+// InMemorySender is a local stand-in for a mail server which keeps all sent messages, e.g. for tests.
+type InMemorySender struct {
+	mutex    sync.RWMutex
+	from     string
+	messages []Message
+}
+
+func NewInMemorySender(from string) *InMemorySender {
+	return &InMemorySender{
+		from: from,
+	}
+}
+
+func (s *InMemorySender) SendConfirmationEmail(_ context.Context, confirmationEmail application.ConfirmationEmail) error {
+	message, err := renderConfirmationEmail(s.from, confirmationEmail)
+	if err != nil {
+		return errors.Wrap(err, "inMemorySender.SendConfirmationEmail")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	return nil
+}
+
+func (s *InMemorySender) SentMessages() []Message {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]Message{}, s.messages...)
+}
@@ -0,0 +1,100 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// Message is a rendered email, independent of how it is delivered.
+type Message struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type confirmationEmailTemplate struct {
+	subject string
+	body    *template.Template
+}
+
+var confirmationEmailTemplates = map[string]confirmationEmailTemplate{
+	application.ConfirmationEmailForRegistration: {
+		subject: "Please confirm your email address",
+		body: template.Must(template.New(application.ConfirmationEmailForRegistration).Parse(
+			`Hello {{.GivenName}} {{.FamilyName}},
+
+thank you for registering! Please confirm your email address {{.EmailAddress}} with this confirmation hash:
+
+{{.ConfirmationHash}}
+
+Your customer ID is {{.CustomerID}}.
+`)),
+	},
+	application.ConfirmationEmailForEmailAddressChange: {
+		subject: "Please confirm your new email address",
+		body: template.Must(template.New(application.ConfirmationEmailForEmailAddressChange).Parse(
+			`Hello,
+
+you changed the email address of your account to {{.EmailAddress}}. Please confirm it with this confirmation hash:
+
+{{.ConfirmationHash}}
+
+Your customer ID is {{.CustomerID}}.
+`)),
+	},
+	application.ConfirmationEmailForResend: {
+		subject: "Your new confirmation hash",
+		body: template.Must(template.New(application.ConfirmationEmailForResend).Parse(
+			`Hello,
+
+you requested a new confirmation hash for your email address {{.EmailAddress}}. Please confirm it with this one:
+
+{{.ConfirmationHash}}
+
+Previously sent confirmation hashes are no longer valid.
+
+Your customer ID is {{.CustomerID}}.
+`)),
+	},
+}
+
+func renderConfirmationEmail(from string, confirmationEmail application.ConfirmationEmail) (Message, error) {
+	wrapWithMsg := "renderConfirmationEmail"
+
+	confirmationTemplate, found := confirmationEmailTemplates[confirmationEmail.Reason]
+	if !found {
+		err := errors.Newf("no template for confirmation emails of reason [%s]", confirmationEmail.Reason)
+		return Message{}, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	body := new(bytes.Buffer)
+	if err := confirmationTemplate.body.Execute(body, confirmationEmail); err != nil {
+		return Message{}, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return Message{
+		From:    from,
+		To:      confirmationEmail.EmailAddress,
+		Subject: confirmationTemplate.subject,
+		Body:    body.String(),
+	}, nil
+}
+
+// bytes renders the Message in the format SMTP expects (RFC 5322).
+func (message Message) bytes() []byte {
+	return []byte(
+		fmt.Sprintf("From: %s\r\n", message.From) +
+			fmt.Sprintf("To: %s\r\n", message.To) +
+			fmt.Sprintf("Subject: %s\r\n", message.Subject) +
+			"MIME-Version: 1.0\r\n" +
+			"Content-Type: text/plain; charset=UTF-8\r\n" +
+			"\r\n" +
+			message.Body,
+	)
+}
@@ -0,0 +1,150 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+	"unicode"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// smtpTimeout limits each delivery, because the ctx of a subscription has no deadline.
+const smtpTimeout = 30 * time.Second
+
+// SMTPSender delivers messages via an SMTP server. It uses STARTTLS and authentication if the server supports them.
+// Messages which can never be delivered, e.g. because the server rejects the recipient, fail with ErrInputIsInvalid.
+type SMTPSender struct {
+	from        string
+	hostAndPort string
+	username    string
+	password    string
+}
+
+func NewSMTPSender(from string, hostAndPort string, username string, password string) *SMTPSender {
+	return &SMTPSender{
+		from:        from,
+		hostAndPort: hostAndPort,
+		username:    username,
+		password:    password,
+	}
+}
+
+func (s *SMTPSender) SendConfirmationEmail(ctx context.Context, confirmationEmail application.ConfirmationEmail) error {
+	wrapWithMsg := "smtpSender.SendConfirmationEmail"
+
+	message, err := renderConfirmationEmail(s.from, confirmationEmail)
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if _, err = mail.ParseAddress(message.To); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	if err = s.send(ctx, message); err != nil {
+		if errors.Is(err, shared.ErrInputIsInvalid) {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) send(ctx context.Context, message Message) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	host, _, err := net.SplitHostPort(s.hostAndPort)
+	if err != nil {
+		return err
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.hostAndPort)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline) // the smtp package does not support a ctx
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, host)); err != nil {
+			return err
+		}
+	}
+
+	if ok, _ := client.Extension("SMTPUTF8"); !ok && !isASCII(message.To) {
+		return errors.Mark(errors.Newf("server does not support SMTPUTF8 for [%s]", message.To), shared.ErrInputIsInvalid)
+	}
+
+	if err = client.Mail(message.From); err != nil {
+		return err
+	}
+
+	if err = client.Rcpt(message.To); err != nil {
+		return markAsInvalidIfRejected(err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = writer.Write(message.bytes()); err != nil {
+		_ = writer.Close()
+
+		return err
+	}
+
+	if err = writer.Close(); err != nil {
+		return markAsInvalidIfRejected(err)
+	}
+
+	return client.Quit()
+}
+
+// markAsInvalidIfRejected marks permanent rejections (5xx) of the recipient or the message, which are not worth a retry.
+func markAsInvalidIfRejected(err error) error {
+	var protocolErr *textproto.Error
+
+	if errors.As(err, &protocolErr) && protocolErr.Code >= 500 {
+		return errors.Mark(err, shared.ErrInputIsInvalid)
+	}
+
+	return err
+}
+
+func isASCII(input string) bool {
+	for _, r := range input {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+
+	return true
+}
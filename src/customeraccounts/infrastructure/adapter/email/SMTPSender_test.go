@@ -0,0 +1,121 @@
+package email_test
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/email"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSMTPSender(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+
+		confirmationEmail := application.ConfirmationEmail{
+			Reason:           application.ConfirmationEmailForRegistration,
+			CustomerID:       "64bcf656-da30-4f5a-b0b5-aead60965aa3",
+			EmailAddress:     "kevin@ball.com",
+			ConfirmationHash: "0acf14bbeaf0b9c6ef8e39d7f9254336",
+			GivenName:        "Kevin",
+			FamilyName:       "Ball",
+		}
+
+		Convey("When a confirmation email is sent to an email address which was erased", func() {
+			sender := email.NewSMTPSender("noreply@go-iddd.local", "localhost:1", "", "")
+			confirmationEmail.EmailAddress = "[erased]"
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+
+			Convey("Then it should fail without trying to deliver it, because it can never be delivered", func() {
+				So(err, ShouldBeError)
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+		})
+
+		Convey("Given an SMTP server which rejects the recipient", func() {
+			hostAndPort := startSMTPServer(t, "550 5.1.1 mailbox unavailable")
+			sender := email.NewSMTPSender("noreply@go-iddd.local", hostAndPort, "", "")
+
+			Convey("When a confirmation email is sent", func() {
+				err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+
+				Convey("Then it should fail, because it can never be delivered", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+
+			Convey("When a confirmation email is sent to a non-ASCII email address", func() {
+				confirmationEmail.EmailAddress = "kévin@ball.com"
+				err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+
+				Convey("Then it should fail, because the server does not support SMTPUTF8", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Given an SMTP server which is temporarily unavailable for the recipient", func() {
+			hostAndPort := startSMTPServer(t, "451 4.3.0 try again later")
+			sender := email.NewSMTPSender("noreply@go-iddd.local", hostAndPort, "", "")
+
+			Convey("When a confirmation email is sent", func() {
+				err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+
+				Convey("Then it should fail, so that it can be retried", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeFalse)
+					So(errors.Is(err, shared.ErrTechnical), ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
+
+// startSMTPServer accepts one connection and answers RCPT with rcptReply, it doesn't support any extensions.
+func startSMTPServer(t *testing.T, rcptReply string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		protocol := textproto.NewConn(conn)
+		_ = protocol.PrintfLine("220 localhost ESMTP")
+
+		for {
+			line, err := protocol.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); command {
+			case "RCPT":
+				_ = protocol.PrintfLine(rcptReply)
+			case "QUIT":
+				_ = protocol.PrintfLine("221 bye")
+				return
+			default:
+				_ = protocol.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String()
+}
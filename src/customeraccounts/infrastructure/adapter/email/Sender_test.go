@@ -0,0 +1,98 @@
+package email_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/email"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSenders(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		from := "noreply@go-iddd.local"
+
+		confirmationEmail := application.ConfirmationEmail{
+			Reason:           application.ConfirmationEmailForRegistration,
+			CustomerID:       "64bcf656-da30-4f5a-b0b5-aead60965aa3",
+			EmailAddress:     "kevin@ball.com",
+			ConfirmationHash: "0acf14bbeaf0b9c6ef8e39d7f9254336",
+			GivenName:        "Kevin",
+			FamilyName:       "Ball",
+		}
+
+		Convey("When a confirmation email is sent with the InMemorySender", func() {
+			sender := email.NewInMemorySender(from)
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be rendered with the template for its reason", func() {
+				messages := sender.SentMessages()
+				So(messages, ShouldHaveLength, 1)
+				So(messages[0].From, ShouldEqual, from)
+				So(messages[0].To, ShouldEqual, confirmationEmail.EmailAddress)
+				So(messages[0].Subject, ShouldEqual, "Please confirm your email address")
+				So(messages[0].Body, ShouldContainSubstring, "Hello Kevin Ball")
+				So(messages[0].Body, ShouldContainSubstring, confirmationEmail.ConfirmationHash)
+			})
+		})
+
+		Convey("When a confirmation email for a changed email address is sent", func() {
+			sender := email.NewInMemorySender(from)
+			confirmationEmail.Reason = application.ConfirmationEmailForEmailAddressChange
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be rendered with the template for changed email addresses", func() {
+				So(sender.SentMessages()[0].Subject, ShouldEqual, "Please confirm your new email address")
+			})
+		})
+
+		Convey("When a resent confirmation email is sent", func() {
+			sender := email.NewInMemorySender(from)
+			confirmationEmail.Reason = application.ConfirmationEmailForResend
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be rendered with the template for resent confirmation hashes", func() {
+				So(sender.SentMessages()[0].Subject, ShouldEqual, "Your new confirmation hash")
+				So(sender.SentMessages()[0].Body, ShouldContainSubstring, confirmationEmail.ConfirmationHash)
+			})
+		})
+
+		Convey("When a confirmation email with an unknown reason is sent", func() {
+			sender := email.NewInMemorySender(from)
+			confirmationEmail.Reason = "unknown"
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeError)
+				So(sender.SentMessages(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When confirmation emails are sent with the FileSender", func() {
+			filePath := filepath.Join(t.TempDir(), "emails.jsonl")
+			sender := email.NewFileSender(from, filePath)
+
+			err := sender.SendConfirmationEmail(ctx, confirmationEmail)
+			So(err, ShouldBeNil)
+			err = sender.SendConfirmationEmail(ctx, confirmationEmail)
+			So(err, ShouldBeNil)
+
+			Convey("Then each message should be appended as one line of json", func() {
+				content, err := os.ReadFile(filePath)
+				So(err, ShouldBeNil)
+
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				So(lines, ShouldHaveLength, 2)
+				So(lines[0], ShouldContainSubstring, `"to":"kevin@ball.com"`)
+			})
+		})
+	})
+}
@@ -2,8 +2,10 @@ package customergrpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	customergrpcproto "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc/proto"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -12,111 +14,698 @@ import (
 type customerServer struct {
 	register            hexagon.ForRegisteringCustomers
 	confirmEmailAddress hexagon.ForConfirmingCustomerEmailAddresses
+	requestResend       hexagon.ForRequestingCustomerEmailAddressConfirmationResends
 	changeEmailAddress  hexagon.ForChangingCustomerEmailAddresses
+	cancelChange        hexagon.ForCancellingCustomerEmailAddressChanges
 	changeName          hexagon.ForChangingCustomerNames
+	addAddress          hexagon.ForAddingCustomerAddresses
+	changeAddress       hexagon.ForChangingCustomerAddresses
+	removeAddress       hexagon.ForRemovingCustomerAddresses
+	changePhoneNumber   hexagon.ForChangingCustomerPhoneNumbers
+	confirmPhoneNumber  hexagon.ForConfirmingCustomerPhoneNumbers
+	suspend             hexagon.ForSuspendingCustomers
+	reactivate          hexagon.ForReactivatingCustomers
 	delete              hexagon.ForDeletingCustomers
+	restore             hexagon.ForRestoringCustomers
+	erasePersonalData   hexagon.ForErasingCustomerPersonalData
 	retrieveView        hexagon.ForRetrievingCustomerViews
+	retrieveViewAsOf    hexagon.ForRetrievingCustomerViewsAsOf
+	retrieveViewByEmail hexagon.ForRetrievingCustomerViewsByEmailAddress
+	export              hexagon.ForExportingCustomerData
+	retrieveHistory     hexagon.ForRetrievingCustomerEventHistories
+	searchViews         hexagon.ForSearchingCustomerViews
+	watch               hexagon.ForWatchingCustomers
 }
 
 func NewCustomerServer(
 	register hexagon.ForRegisteringCustomers,
 	confirmEmailAddress hexagon.ForConfirmingCustomerEmailAddresses,
+	requestResend hexagon.ForRequestingCustomerEmailAddressConfirmationResends,
 	changeEmailAddress hexagon.ForChangingCustomerEmailAddresses,
+	cancelChange hexagon.ForCancellingCustomerEmailAddressChanges,
 	changeName hexagon.ForChangingCustomerNames,
+	addAddress hexagon.ForAddingCustomerAddresses,
+	changeAddress hexagon.ForChangingCustomerAddresses,
+	removeAddress hexagon.ForRemovingCustomerAddresses,
+	changePhoneNumber hexagon.ForChangingCustomerPhoneNumbers,
+	confirmPhoneNumber hexagon.ForConfirmingCustomerPhoneNumbers,
+	suspend hexagon.ForSuspendingCustomers,
+	reactivate hexagon.ForReactivatingCustomers,
 	delete hexagon.ForDeletingCustomers, //nolint:gocritic // false positive (shadowing of predeclared identifier: delete)
+	restore hexagon.ForRestoringCustomers,
+	erasePersonalData hexagon.ForErasingCustomerPersonalData,
 	retrieveView hexagon.ForRetrievingCustomerViews,
+	retrieveViewAsOf hexagon.ForRetrievingCustomerViewsAsOf,
+	retrieveViewByEmail hexagon.ForRetrievingCustomerViewsByEmailAddress,
+	export hexagon.ForExportingCustomerData,
+	retrieveHistory hexagon.ForRetrievingCustomerEventHistories,
+	searchViews hexagon.ForSearchingCustomerViews,
+	watch hexagon.ForWatchingCustomers,
 ) customergrpcproto.CustomerServer {
 	server := &customerServer{
 		register:            register,
 		confirmEmailAddress: confirmEmailAddress,
+		requestResend:       requestResend,
 		changeEmailAddress:  changeEmailAddress,
+		cancelChange:        cancelChange,
 		changeName:          changeName,
+		addAddress:          addAddress,
+		changeAddress:       changeAddress,
+		removeAddress:       removeAddress,
+		changePhoneNumber:   changePhoneNumber,
+		confirmPhoneNumber:  confirmPhoneNumber,
+		suspend:             suspend,
+		reactivate:          reactivate,
 		delete:              delete,
+		restore:             restore,
+		erasePersonalData:   erasePersonalData,
 		retrieveView:        retrieveView,
+		retrieveViewAsOf:    retrieveViewAsOf,
+		retrieveViewByEmail: retrieveViewByEmail,
+		export:              export,
+		retrieveHistory:     retrieveHistory,
+		searchViews:         searchViews,
+		watch:               watch,
 	}
 
 	return server
 }
 
 func (server *customerServer) Register(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.RegisterRequest,
 ) (*customergrpcproto.RegisterResponse, error) {
 
-	customerIDValue := value.GenerateCustomerID()
+	ctx, err := withIdempotencyKeyFrom(ctx, "Register")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
 
-	if err := server.register(customerIDValue, req.EmailAddress, req.GivenName, req.FamilyName); err != nil {
+	customerIDValue, err := server.register(ctx, value.GenerateCustomerID(), req.EmailAddress, req.GivenName, req.FamilyName)
+	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	setETagHeader(ctx, 1) // a registration always starts the event stream
+
 	return &customergrpcproto.RegisterResponse{Id: customerIDValue.String()}, nil
 }
 
 func (server *customerServer) ConfirmEmailAddress(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.ConfirmEmailAddressRequest,
 ) (*empty.Empty, error) {
 
-	if err := server.confirmEmailAddress(req.Id, req.ConfirmationHash); err != nil {
+	ctx, err := withIdempotencyKeyFrom(ctx, "ConfirmEmailAddress")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.confirmEmailAddress(ctx, req.Id, req.ConfirmationHash, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) RequestEmailAddressConfirmationResend(
+	ctx context.Context,
+	req *customergrpcproto.RequestEmailAddressConfirmationResendRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "RequestEmailAddressConfirmationResend")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.requestResend(ctx, req.Id, expectedVersion)
+	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	setETagHeader(ctx, newVersion)
+
 	return &empty.Empty{}, nil
 }
 
 func (server *customerServer) ChangeEmailAddress(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.ChangeEmailAddressRequest,
 ) (*empty.Empty, error) {
 
-	if err := server.changeEmailAddress(req.Id, req.EmailAddress); err != nil {
+	ctx, err := withIdempotencyKeyFrom(ctx, "ChangeEmailAddress")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.changeEmailAddress(ctx, req.Id, req.EmailAddress, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) CancelEmailAddressChange(
+	ctx context.Context,
+	req *customergrpcproto.CancelEmailAddressChangeRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "CancelEmailAddressChange")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.cancelChange(ctx, req.Id, expectedVersion)
+	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	setETagHeader(ctx, newVersion)
+
 	return &empty.Empty{}, nil
 }
 
 func (server *customerServer) ChangeName(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.ChangeNameRequest,
 ) (*empty.Empty, error) {
 
-	if err := server.changeName(req.Id, req.GivenName, req.FamilyName); err != nil {
+	ctx, err := withIdempotencyKeyFrom(ctx, "ChangeName")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.changeName(ctx, req.Id, req.GivenName, req.FamilyName, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) AddAddress(
+	ctx context.Context,
+	req *customergrpcproto.AddAddressRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "AddAddress")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.addAddress(ctx, req.Id, req.Kind, req.Street, req.PostalCode, req.City, req.CountryCode, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) ChangeAddress(
+	ctx context.Context,
+	req *customergrpcproto.ChangeAddressRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "ChangeAddress")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.changeAddress(ctx, req.Id, req.Kind, req.Street, req.PostalCode, req.City, req.CountryCode, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) RemoveAddress(
+	ctx context.Context,
+	req *customergrpcproto.RemoveAddressRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "RemoveAddress")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.removeAddress(ctx, req.Id, req.Kind, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) ChangePhoneNumber(
+	ctx context.Context,
+	req *customergrpcproto.ChangePhoneNumberRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "ChangePhoneNumber")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.changePhoneNumber(ctx, req.Id, req.PhoneNumber, expectedVersion)
+	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) ConfirmPhoneNumber(
+	ctx context.Context,
+	req *customergrpcproto.ConfirmPhoneNumberRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "ConfirmPhoneNumber")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.confirmPhoneNumber(ctx, req.Id, req.ConfirmationCode, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) Suspend(
+	ctx context.Context,
+	req *customergrpcproto.SuspendRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "Suspend")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.suspend(ctx, req.Id, req.Reason, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) Reactivate(
+	ctx context.Context,
+	req *customergrpcproto.ReactivateRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "Reactivate")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.reactivate(ctx, req.Id, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
 	return &empty.Empty{}, nil
 }
 
 func (server *customerServer) Delete(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.DeleteRequest,
 ) (*empty.Empty, error) {
 
-	if err := server.delete(req.Id); err != nil {
+	ctx, err := withIdempotencyKeyFrom(ctx, "Delete")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.delete(ctx, req.Id, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) Restore(
+	ctx context.Context,
+	req *customergrpcproto.RestoreRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "Restore")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.restore(ctx, req.Id, expectedVersion)
+	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	setETagHeader(ctx, newVersion)
+
+	return &empty.Empty{}, nil
+}
+
+func (server *customerServer) ErasePersonalData(
+	ctx context.Context,
+	req *customergrpcproto.ErasePersonalDataRequest,
+) (*empty.Empty, error) {
+
+	ctx, err := withIdempotencyKeyFrom(ctx, "ErasePersonalData")
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	expectedVersion, err := expectedVersionFrom(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	newVersion, err := server.erasePersonalData(ctx, req.Id, expectedVersion)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, newVersion)
+
 	return &empty.Empty{}, nil
 }
 
 func (server *customerServer) RetrieveView(
-	_ context.Context,
+	ctx context.Context,
 	req *customergrpcproto.RetrieveViewRequest,
 ) (*customergrpcproto.RetrieveViewResponse, error) {
 
-	view, err := server.retrieveView(req.Id)
+	if req.AsOfVersion != 0 || req.AsOfTime != "" {
+		// a historic View has no ETag, it can't be the base for a command
+		view, err := server.retrieveViewAsOf(ctx, req.Id, uint(req.AsOfVersion), req.AsOfTime)
+		if err != nil {
+			return nil, MapToGRPCErrors(err)
+		}
+
+		return buildRetrieveViewResponse(view), nil
+	}
+
+	view, err := server.retrieveView(ctx, req.Id)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, view.Version)
+
+	return buildRetrieveViewResponse(view), nil
+}
+
+func (server *customerServer) RetrieveViewByEmailAddress(
+	ctx context.Context,
+	req *customergrpcproto.RetrieveViewByEmailAddressRequest,
+) (*customergrpcproto.RetrieveViewResponse, error) {
+
+	view, err := server.retrieveViewByEmail(ctx, req.EmailAddress)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	setETagHeader(ctx, view.Version)
+
+	return buildRetrieveViewResponse(view), nil
+}
+
+func (server *customerServer) Export(
+	ctx context.Context,
+	req *customergrpcproto.ExportRequest,
+) (*customergrpcproto.ExportResponse, error) {
+
+	export, err := server.export(ctx, req.Id)
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	response := &customergrpcproto.ExportResponse{
+		View:                 buildRetrieveViewResponse(export.View),
+		Events:               make([]*customergrpcproto.ExportedEvent, 0, len(export.Events)),
+		UniqueEmailAddresses: export.UniqueEmailAddresses,
+	}
+
+	for _, event := range export.Events {
+		response.Events = append(
+			response.Events,
+			&customergrpcproto.ExportedEvent{
+				Name:          event.Name,
+				Description:   event.Description,
+				OccurredAt:    event.OccurredAt,
+				StreamVersion: uint64(event.StreamVersion),
+				IsFailure:     event.IsFailure,
+			},
+		)
+	}
+
+	return response, nil
+}
+
+func (server *customerServer) RetrieveEventHistory(
+	ctx context.Context,
+	req *customergrpcproto.RetrieveEventHistoryRequest,
+) (*customergrpcproto.RetrieveEventHistoryResponse, error) {
+
+	page, err := server.retrieveHistory(ctx, req.Id, uint(req.FromVersion), uint(req.MaxEvents))
 	if err != nil {
 		return nil, MapToGRPCErrors(err)
 	}
 
+	response := &customergrpcproto.RetrieveEventHistoryResponse{
+		Events:          make([]*customergrpcproto.HistoricEvent, 0, len(page.Events)),
+		NextFromVersion: uint64(page.NextFromVersion),
+	}
+
+	for _, event := range page.Events {
+		response.Events = append(response.Events, buildHistoricEventResponse(event))
+	}
+
+	return response, nil
+}
+
+func (server *customerServer) ListCustomers(
+	ctx context.Context,
+	req *customergrpcproto.ListCustomersRequest,
+) (*customergrpcproto.CustomerViewsResponse, error) {
+
+	page, err := server.searchViews(
+		ctx,
+		customer.ViewSearchCriteria{
+			SortBy:         req.SortBy,
+			SortDescending: req.SortDescending,
+			PageSize:       req.PageSize,
+			Cursor:         req.Cursor,
+		},
+	)
+
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	return buildCustomerViewsResponse(page), nil
+}
+
+func (server *customerServer) SearchCustomers(
+	ctx context.Context,
+	req *customergrpcproto.SearchCustomersRequest,
+) (*customergrpcproto.CustomerViewsResponse, error) {
+
+	page, err := server.searchViews(
+		ctx,
+		customer.ViewSearchCriteria{
+			EmailAddress:       req.EmailAddress,
+			NameFragment:       req.NameFragment,
+			ConfirmationStatus: req.ConfirmationStatus,
+			RegisteredFrom:     req.RegisteredFrom,
+			RegisteredBefore:   req.RegisteredBefore,
+			SortBy:             req.SortBy,
+			SortDescending:     req.SortDescending,
+			PageSize:           req.PageSize,
+			Cursor:             req.Cursor,
+		},
+	)
+
+	if err != nil {
+		return nil, MapToGRPCErrors(err)
+	}
+
+	return buildCustomerViewsResponse(page), nil
+}
+
+// WatchCustomer ends with the client's connection, a watch which ends for another reason can be resumed with afterVersion.
+func (server *customerServer) WatchCustomer(
+	req *customergrpcproto.WatchCustomerRequest,
+	stream customergrpcproto.Customer_WatchCustomerServer,
+) error {
+
+	err := server.watch(
+		stream.Context(),
+		req.Id,
+		uint(req.AfterVersion),
+		func(event customer.HistoricEvent) error {
+			return stream.Send(buildHistoricEventResponse(event))
+		},
+	)
+
+	if err != nil {
+		return MapToGRPCErrors(err)
+	}
+
+	return nil
+}
+
+func buildCustomerViewsResponse(page customer.ViewPage) *customergrpcproto.CustomerViewsResponse {
+	response := &customergrpcproto.CustomerViewsResponse{
+		Views:      make([]*customergrpcproto.RetrieveViewResponse, 0, len(page.Views)),
+		NextCursor: page.NextCursor,
+	}
+
+	for _, view := range page.Views {
+		response.Views = append(response.Views, buildRetrieveViewResponse(view))
+	}
+
+	return response
+}
+
+func buildRetrieveViewResponse(view customer.View) *customergrpcproto.RetrieveViewResponse {
 	response := &customergrpcproto.RetrieveViewResponse{
+		Id:                      view.ID,
 		EmailAddress:            view.EmailAddress,
 		IsEmailAddressConfirmed: view.IsEmailAddressConfirmed,
+		PendingEmailAddress:     view.PendingEmailAddress,
 		GivenName:               view.GivenName,
 		FamilyName:              view.FamilyName,
+		Status:                  view.Status,
+		PhoneNumber:             view.PhoneNumber,
+		IsPhoneNumberConfirmed:  view.IsPhoneNumberConfirmed,
 		Version:                 uint64(view.Version),
 	}
 
-	return response, nil
+	if !view.RegisteredAt.IsZero() {
+		response.RegisteredAt = view.RegisteredAt.Format(time.RFC3339Nano)
+	}
+
+	for _, address := range view.Addresses {
+		response.Addresses = append(
+			response.Addresses,
+			&customergrpcproto.Address{
+				Kind:        address.Kind,
+				Street:      address.Street,
+				PostalCode:  address.PostalCode,
+				City:        address.City,
+				CountryCode: address.CountryCode,
+			},
+		)
+	}
+
+	return response
+}
+
+func buildHistoricEventResponse(event customer.HistoricEvent) *customergrpcproto.HistoricEvent {
+	return &customergrpcproto.HistoricEvent{
+		Name:          event.Name,
+		StreamVersion: uint64(event.StreamVersion),
+		OccurredAt:    event.OccurredAt,
+		MessageID:     event.MessageID,
+		CausationID:   event.CausationID,
+		IsFailure:     event.IsFailure,
+		Payload:       event.Payload,
+	}
 }
@@ -3,6 +3,7 @@ package customergrpc_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
@@ -12,6 +13,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/ptypes/empty"
 	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -21,11 +23,58 @@ var mockedView = customer.View{
 	ID:                      generatedID.String(),
 	EmailAddress:            "fiona@gallagher.net",
 	IsEmailAddressConfirmed: true,
+	PendingEmailAddress:     "fiona@pratt.net",
 	GivenName:               "Fiona",
 	FamilyName:              "Gallagher",
 	IsDeleted:               false,
-	Version:                 2,
+	Status:                  customer.StatusActive,
+	Addresses: []customer.AddressView{
+		{Kind: "billing", Street: "Main Street 1", PostalCode: "10115", City: "Berlin", CountryCode: "DE"},
+	},
+	PhoneNumber:            "+493012345678",
+	IsPhoneNumberConfirmed: true,
+	RegisteredAt:           time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC),
+	Version:                2,
 }
+var mockedExport = customer.Export{
+	View: mockedView,
+	Events: []customer.ExportedEvent{
+		{
+			Name:          "CustomerRegistered",
+			Description:   "Registered as Fiona Gallagher with the email address fiona@gallagher.net",
+			OccurredAt:    "2020-12-24T18:00:00Z",
+			StreamVersion: 1,
+		},
+		{
+			Name:          "CustomerEmailAddressConfirmationFailed",
+			Description:   "Failed to confirm the email address: wrong confirmation hash supplied",
+			OccurredAt:    "2020-12-24T18:05:00Z",
+			StreamVersion: 2,
+			IsFailure:     true,
+		},
+	},
+	UniqueEmailAddresses: []string{"fiona@gallagher.net", "fiona@pratt.net"},
+}
+var mockedViewPage = customer.ViewPage{
+	Views:      []customer.View{mockedView},
+	NextCursor: "some-cursor",
+}
+var mockedEventHistory = customer.EventHistoryPage{
+	Events: []customer.HistoricEvent{
+		{
+			Name:          "CustomerRegistered",
+			StreamVersion: 1,
+			OccurredAt:    "2020-12-24T18:00:00Z",
+			MessageID:     "some-message-id",
+			CausationID:   "some-causation-id",
+			Payload:       map[string]string{"emailAddress": "f***@gallagher.net"},
+		},
+	},
+	NextFromVersion: 2,
+}
+var searchedCriteria customer.ViewSearchCriteria
+var retrievedAsOfVersion uint
+var retrievedAsOfTime string
 var expectedErrCode = codes.InvalidArgument
 var expectedErrMsg = "invalid input"
 
@@ -90,6 +139,71 @@ func TestGRPCServer(t *testing.T) {
 			})
 		})
 
+		Convey("\nUsecase: RequestEmailAddressConfirmationResend", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.RequestEmailAddressConfirmationResend(
+						context.Background(),
+						&customergrpcproto.RequestEmailAddressConfirmationResendRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.RequestEmailAddressConfirmationResend(
+						context.Background(),
+						&customergrpcproto.RequestEmailAddressConfirmationResendRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+
+			Convey("Given the application will return a rate limit error", func() {
+				rateLimitedCustomerServer := customergrpc.NewCustomerServer(
+					nil,
+					nil,
+					func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+						return 0, errors.Mark(errors.New("confirmation email was sent recently"), shared.ErrRateLimited)
+					},
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+				)
+
+				Convey("When the request is handled", func() {
+					_, err := rateLimitedCustomerServer.RequestEmailAddressConfirmationResend(
+						context.Background(),
+						&customergrpcproto.RequestEmailAddressConfirmationResendRequest{},
+					)
+
+					Convey("Then it should fail with ResourceExhausted", func() {
+						So(status.Code(err), ShouldEqual, codes.ResourceExhausted)
+					})
+				})
+			})
+		})
+
 		Convey("\nUsecase: ChangeEmailAddress", func() {
 			Convey("Given the application will return success", func() {
 				Convey("When the request is handled", func() {
@@ -114,6 +228,30 @@ func TestGRPCServer(t *testing.T) {
 			})
 		})
 
+		Convey("\nUsecase: CancelEmailAddressChange", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.CancelEmailAddressChange(
+						context.Background(),
+						&customergrpcproto.CancelEmailAddressChangeRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.CancelEmailAddressChange(
+						context.Background(),
+						&customergrpcproto.CancelEmailAddressChangeRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
 		Convey("\nUsecase: ChangeName", func() {
 			Convey("Given the application will return success", func() {
 				Convey("When the request is handled", func() {
@@ -138,6 +276,174 @@ func TestGRPCServer(t *testing.T) {
 			})
 		})
 
+		Convey("\nUsecase: AddAddress", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.AddAddress(
+						context.Background(),
+						&customergrpcproto.AddAddressRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.AddAddress(
+						context.Background(),
+						&customergrpcproto.AddAddressRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: ChangeAddress", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.ChangeAddress(
+						context.Background(),
+						&customergrpcproto.ChangeAddressRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.ChangeAddress(
+						context.Background(),
+						&customergrpcproto.ChangeAddressRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: RemoveAddress", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.RemoveAddress(
+						context.Background(),
+						&customergrpcproto.RemoveAddressRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.RemoveAddress(
+						context.Background(),
+						&customergrpcproto.RemoveAddressRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: ChangePhoneNumber", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.ChangePhoneNumber(
+						context.Background(),
+						&customergrpcproto.ChangePhoneNumberRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.ChangePhoneNumber(
+						context.Background(),
+						&customergrpcproto.ChangePhoneNumberRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: ConfirmPhoneNumber", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.ConfirmPhoneNumber(
+						context.Background(),
+						&customergrpcproto.ConfirmPhoneNumberRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.ConfirmPhoneNumber(
+						context.Background(),
+						&customergrpcproto.ConfirmPhoneNumberRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: Suspend", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.Suspend(
+						context.Background(),
+						&customergrpcproto.SuspendRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.Suspend(
+						context.Background(),
+						&customergrpcproto.SuspendRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: Reactivate", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.Reactivate(
+						context.Background(),
+						&customergrpcproto.ReactivateRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.Reactivate(
+						context.Background(),
+						&customergrpcproto.ReactivateRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
 		Convey("\nUsecase: Delete", func() {
 			Convey("Given the application will return success", func() {
 				Convey("When the request is handled", func() {
@@ -162,6 +468,54 @@ func TestGRPCServer(t *testing.T) {
 			})
 		})
 
+		Convey("\nUsecase: Restore", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.Restore(
+						context.Background(),
+						&customergrpcproto.RestoreRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.Restore(
+						context.Background(),
+						&customergrpcproto.RestoreRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
+		Convey("\nUsecase: ErasePersonalData", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.ErasePersonalData(
+						context.Background(),
+						&customergrpcproto.ErasePersonalDataRequest{},
+					)
+
+					thenItShouldSuccees(res, err)
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.ErasePersonalData(
+						context.Background(),
+						&customergrpcproto.ErasePersonalDataRequest{},
+					)
+
+					thenItShouldFailWithTheExpectedError(res, err)
+				})
+			})
+		})
+
 		Convey("\nUsecase: RetrieveView", func() {
 			Convey("Given the application will return success", func() {
 				Convey("When the request is handled", func() {
@@ -175,11 +529,26 @@ func TestGRPCServer(t *testing.T) {
 						So(res, ShouldNotBeNil)
 
 						expectedRes := &customergrpcproto.RetrieveViewResponse{
+							Id:                      mockedView.ID,
 							EmailAddress:            mockedView.EmailAddress,
 							IsEmailAddressConfirmed: mockedView.IsEmailAddressConfirmed,
+							PendingEmailAddress:     mockedView.PendingEmailAddress,
 							GivenName:               mockedView.GivenName,
 							FamilyName:              mockedView.FamilyName,
+							Status:                  mockedView.Status,
+							PhoneNumber:             mockedView.PhoneNumber,
+							IsPhoneNumberConfirmed:  mockedView.IsPhoneNumberConfirmed,
+							RegisteredAt:            "2020-12-24T18:00:00Z",
 							Version:                 uint64(mockedView.Version),
+							Addresses: []*customergrpcproto.Address{
+								{
+									Kind:        mockedView.Addresses[0].Kind,
+									Street:      mockedView.Addresses[0].Street,
+									PostalCode:  mockedView.Addresses[0].PostalCode,
+									City:        mockedView.Addresses[0].City,
+									CountryCode: mockedView.Addresses[0].CountryCode,
+								},
+							},
 						}
 
 						So(res, ShouldResemble, expectedRes)
@@ -187,6 +556,23 @@ func TestGRPCServer(t *testing.T) {
 				})
 			})
 
+			Convey("Given the application will return success for a historic View", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.RetrieveView(
+						context.Background(),
+						&customergrpcproto.RetrieveViewRequest{AsOfVersion: 2, AsOfTime: "2020-12-24"},
+					)
+
+					Convey("Then it should succeed with the View as of the requested version or time", func() {
+						So(err, ShouldBeNil)
+						So(res, ShouldNotBeNil)
+						So(res.Id, ShouldEqual, mockedView.ID)
+						So(retrievedAsOfVersion, ShouldEqual, 2)
+						So(retrievedAsOfTime, ShouldEqual, "2020-12-24")
+					})
+				})
+			})
+
 			Convey("Given the application will return an error", func() {
 				Convey("When the request is handled", func() {
 					res, err := failureCustomerServer.RetrieveView(
@@ -202,9 +588,278 @@ func TestGRPCServer(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("\nUsecase: RetrieveViewByEmailAddress", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.RetrieveViewByEmailAddress(
+						context.Background(),
+						&customergrpcproto.RetrieveViewByEmailAddressRequest{EmailAddress: mockedView.EmailAddress},
+					)
+
+					Convey("Then it should succeed", func() {
+						So(err, ShouldBeNil)
+						So(res, ShouldNotBeNil)
+						So(res.EmailAddress, ShouldEqual, mockedView.EmailAddress)
+						So(res.Version, ShouldEqual, uint64(mockedView.Version))
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.RetrieveViewByEmailAddress(
+						context.Background(),
+						&customergrpcproto.RetrieveViewByEmailAddressRequest{},
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(res, ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("\nUsecase: Export", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.Export(
+						context.Background(),
+						&customergrpcproto.ExportRequest{},
+					)
+
+					Convey("Then it should succeed", func() {
+						So(err, ShouldBeNil)
+						So(res, ShouldNotBeNil)
+						So(res.View.EmailAddress, ShouldEqual, mockedView.EmailAddress)
+						So(res.View.Version, ShouldEqual, uint64(mockedView.Version))
+						So(res.Events, ShouldHaveLength, 2)
+						So(res.Events[1].Name, ShouldEqual, mockedExport.Events[1].Name)
+						So(res.Events[1].Description, ShouldEqual, mockedExport.Events[1].Description)
+						So(res.Events[1].OccurredAt, ShouldEqual, mockedExport.Events[1].OccurredAt)
+						So(res.Events[1].StreamVersion, ShouldEqual, uint64(2))
+						So(res.Events[1].IsFailure, ShouldBeTrue)
+						So(res.UniqueEmailAddresses, ShouldResemble, mockedExport.UniqueEmailAddresses)
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.Export(
+						context.Background(),
+						&customergrpcproto.ExportRequest{},
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(res, ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("\nUsecase: RetrieveEventHistory", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.RetrieveEventHistory(
+						context.Background(),
+						&customergrpcproto.RetrieveEventHistoryRequest{MaxEvents: 1},
+					)
+
+					Convey("Then it should succeed", func() {
+						So(err, ShouldBeNil)
+						So(res, ShouldNotBeNil)
+						So(res.Events, ShouldHaveLength, 1)
+						So(res.Events[0].Name, ShouldEqual, mockedEventHistory.Events[0].Name)
+						So(res.Events[0].StreamVersion, ShouldEqual, uint64(1))
+						So(res.Events[0].OccurredAt, ShouldEqual, mockedEventHistory.Events[0].OccurredAt)
+						So(res.Events[0].MessageID, ShouldEqual, mockedEventHistory.Events[0].MessageID)
+						So(res.Events[0].CausationID, ShouldEqual, mockedEventHistory.Events[0].CausationID)
+						So(res.Events[0].Payload, ShouldResemble, mockedEventHistory.Events[0].Payload)
+						So(res.NextFromVersion, ShouldEqual, uint64(2))
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.RetrieveEventHistory(
+						context.Background(),
+						&customergrpcproto.RetrieveEventHistoryRequest{},
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(res, ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("\nUsecase: ListCustomers", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.ListCustomers(
+						context.Background(),
+						&customergrpcproto.ListCustomersRequest{
+							PageSize:       10,
+							Cursor:         "previous-cursor",
+							SortBy:         customer.SortByFamilyName,
+							SortDescending: true,
+						},
+					)
+
+					Convey("Then it should succeed", func() {
+						So(err, ShouldBeNil)
+						So(res.Views, ShouldHaveLength, 1)
+						So(res.Views[0].EmailAddress, ShouldEqual, mockedView.EmailAddress)
+						So(res.NextCursor, ShouldEqual, mockedViewPage.NextCursor)
+					})
+
+					Convey("and it should pass the paging and sorting without filters", func() {
+						So(
+							searchedCriteria,
+							ShouldResemble,
+							customer.ViewSearchCriteria{
+								SortBy:         customer.SortByFamilyName,
+								SortDescending: true,
+								PageSize:       10,
+								Cursor:         "previous-cursor",
+							},
+						)
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.ListCustomers(
+						context.Background(),
+						&customergrpcproto.ListCustomersRequest{},
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(res, ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("\nUsecase: SearchCustomers", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					res, err := successCustomerServer.SearchCustomers(
+						context.Background(),
+						&customergrpcproto.SearchCustomersRequest{
+							EmailAddress:       "fiona@gallagher.net",
+							NameFragment:       "gall",
+							ConfirmationStatus: customer.ConfirmationStatusConfirmed,
+							RegisteredFrom:     "2020-12-01",
+							RegisteredBefore:   "2021-01-01",
+						},
+					)
+
+					Convey("Then it should succeed", func() {
+						So(err, ShouldBeNil)
+						So(res.Views, ShouldHaveLength, 1)
+						So(res.NextCursor, ShouldEqual, mockedViewPage.NextCursor)
+					})
+
+					Convey("and it should pass the filters", func() {
+						So(
+							searchedCriteria,
+							ShouldResemble,
+							customer.ViewSearchCriteria{
+								EmailAddress:       "fiona@gallagher.net",
+								NameFragment:       "gall",
+								ConfirmationStatus: customer.ConfirmationStatusConfirmed,
+								RegisteredFrom:     "2020-12-01",
+								RegisteredBefore:   "2021-01-01",
+							},
+						)
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					res, err := failureCustomerServer.SearchCustomers(
+						context.Background(),
+						&customergrpcproto.SearchCustomersRequest{},
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(res, ShouldBeNil)
+					})
+				})
+			})
+		})
+
+		Convey("\nUsecase: WatchCustomer", func() {
+			Convey("Given the application will return success", func() {
+				Convey("When the request is handled", func() {
+					stream := &watchCustomerStreamMock{ctx: context.Background()}
+
+					err := successCustomerServer.WatchCustomer(
+						&customergrpcproto.WatchCustomerRequest{AfterVersion: 1},
+						stream,
+					)
+
+					Convey("Then it should send the events", func() {
+						So(err, ShouldBeNil)
+						So(stream.sent, ShouldHaveLength, 1)
+						So(stream.sent[0].Name, ShouldEqual, mockedEventHistory.Events[0].Name)
+						So(stream.sent[0].StreamVersion, ShouldEqual, uint64(1))
+						So(stream.sent[0].Payload, ShouldResemble, mockedEventHistory.Events[0].Payload)
+					})
+				})
+			})
+
+			Convey("Given the application will return an error", func() {
+				Convey("When the request is handled", func() {
+					stream := &watchCustomerStreamMock{ctx: context.Background()}
+
+					err := failureCustomerServer.WatchCustomer(
+						&customergrpcproto.WatchCustomerRequest{},
+						stream,
+					)
+
+					Convey("Then it should fail with the exptected error", func() {
+						So(err, ShouldBeError)
+						So(err, ShouldResemble, status.Error(expectedErrCode, expectedErrMsg))
+						So(stream.sent, ShouldBeEmpty)
+					})
+				})
+			})
+		})
 	})
 }
 
+type watchCustomerStreamMock struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*customergrpcproto.HistoricEvent
+}
+
+func (stream *watchCustomerStreamMock) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *watchCustomerStreamMock) Send(event *customergrpcproto.HistoricEvent) error {
+	stream.sent = append(stream.sent, event)
+
+	return nil
+}
+
 func thenItShouldSuccees(res *empty.Empty, err error) {
 	Convey("Then it should succeed", func() {
 		So(err, ShouldBeNil)
@@ -222,25 +877,80 @@ func thenItShouldFailWithTheExpectedError(res *empty.Empty, err error) {
 
 func buildSuccessCustomerServer() customergrpcproto.CustomerServer {
 	customerGRPCServer := customergrpc.NewCustomerServer(
-		func(customerIDValue value.CustomerID, emailAddress, givenName, familyName string) error {
+		func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error) {
 			generatedID = customerIDValue
-			return nil
+			return customerIDValue, nil
+		},
+		func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID, confirmationHash string) error {
-			return nil
+		func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID, emailAddress string) error {
-			return nil
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID, givenName, familyName string) error {
-			return nil
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID string) error {
-			return nil
+		func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID string) (customer.View, error) {
+		func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, reason string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string) (customer.View, error) {
 			return mockedView, nil
 		},
+		func(ctx context.Context, customerID string, asOfVersion uint, asOfTime string) (customer.View, error) {
+			retrievedAsOfVersion = asOfVersion
+			retrievedAsOfTime = asOfTime
+
+			return mockedView, nil
+		},
+		func(ctx context.Context, emailAddress string) (customer.View, error) {
+			return mockedView, nil
+		},
+		func(ctx context.Context, customerID string) (customer.Export, error) {
+			return mockedExport, nil
+		},
+		func(ctx context.Context, customerID string, fromVersion, maxEvents uint) (customer.EventHistoryPage, error) {
+			return mockedEventHistory, nil
+		},
+		func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+			searchedCriteria = criteria
+			return mockedViewPage, nil
+		},
+		func(ctx context.Context, customerID string, afterVersion uint, push func(event customer.HistoricEvent) error) error {
+			return push(mockedEventHistory.Events[0])
+		},
 	)
 
 	return customerGRPCServer
@@ -251,24 +961,75 @@ func buildFailureCustomerServer() customergrpcproto.CustomerServer {
 	mockedErr := errors.Mark(errors.New(expectedErrMsg), shared.ErrInputIsInvalid)
 
 	customerGRPCServer := customergrpc.NewCustomerServer(
-		func(customerIDValue value.CustomerID, emailAddress, givenName, familyName string) error {
-			return mockedErr
+		func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error) {
+			return "", mockedErr
 		},
-		func(customerID, confirmationHash string) error {
-			return mockedErr
+		func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
 		},
-		func(customerID, emailAddress string) error {
-			return mockedErr
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
 		},
-		func(customerID, givenName, familyName string) error {
-			return mockedErr
+		func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
 		},
-		func(customerID string) error {
-			return mockedErr
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID, reason string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
 		},
-		func(customerID string) (customer.View, error) {
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 0, mockedErr
+		},
+		func(ctx context.Context, customerID string) (customer.View, error) {
 			return mockedView, mockedErr
 		},
+		func(ctx context.Context, customerID string, asOfVersion uint, asOfTime string) (customer.View, error) {
+			return mockedView, mockedErr
+		},
+		func(ctx context.Context, emailAddress string) (customer.View, error) {
+			return mockedView, mockedErr
+		},
+		func(ctx context.Context, customerID string) (customer.Export, error) {
+			return customer.Export{}, mockedErr
+		},
+		func(ctx context.Context, customerID string, fromVersion, maxEvents uint) (customer.EventHistoryPage, error) {
+			return customer.EventHistoryPage{}, mockedErr
+		},
+		func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+			return customer.ViewPage{}, mockedErr
+		},
+		func(ctx context.Context, customerID string, afterVersion uint, push func(event customer.HistoricEvent) error) error {
+			return mockedErr
+		},
 	)
 
 	return customerGRPCServer
@@ -0,0 +1,62 @@
+package customergrpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	IfMatchMetadataKey = "if-match"
+	ETagMetadataKey    = "etag"
+)
+
+// expectedVersionFrom prefers the expectedVersion from the request. Otherwise it falls back to an ETag in the
+// if-match metadata, which the REST gateway fills from the If-Match header. 0 means that no version is expected.
+func expectedVersionFrom(ctx context.Context, requestedVersion uint64) (uint, error) {
+	if requestedVersion != 0 {
+		return uint(requestedVersion), nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+
+	ifMatch := md.Get(IfMatchMetadataKey)
+	if len(ifMatch) == 0 {
+		return 0, nil
+	}
+
+	return versionFromETag(ifMatch[0])
+}
+
+func versionFromETag(eTag string) (uint, error) {
+	eTag = strings.TrimSpace(eTag)
+
+	if eTag == "*" {
+		return 0, nil
+	}
+
+	if !strings.HasPrefix(eTag, `"`) || !strings.HasSuffix(eTag, `"`) {
+		return 0, errors.Mark(errors.Newf("invalid ETag [%s]", eTag), shared.ErrInputIsInvalid)
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(eTag, `"`), 10, 32)
+	if err != nil {
+		return 0, errors.Mark(errors.Newf("invalid ETag [%s]", eTag), shared.ErrInputIsInvalid)
+	}
+
+	return uint(version), nil
+}
+
+// setETagHeader sends the version as ETag, so that REST clients can use it with If-Match.
+func setETagHeader(ctx context.Context, version uint) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ETagMetadataKey, fmt.Sprintf(`"%d"`, version))) // it's only a convenience
+}
@@ -0,0 +1,178 @@
+package customergrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	customergrpc "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc"
+	customergrpcproto "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc/proto"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServerExpectedVersion(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var receivedExpectedVersion uint
+
+		server := customergrpc.NewCustomerServer(
+			func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error) {
+				return customerIDValue, nil
+			},
+			func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (uint, error) {
+				receivedExpectedVersion = expectedVersion
+
+				if expectedVersion == 7 {
+					return 0, errors.Mark(errors.New("customer is at version [2] but version [7] was expected"), shared.ErrVersionMismatch)
+				}
+
+				return expectedVersion + 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, reason string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, customerID string, asOfVersion uint, asOfTime string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, emailAddress string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, customerID string) (customer.Export, error) {
+				return customer.Export{}, nil
+			},
+			func(ctx context.Context, customerID string, fromVersion, maxEvents uint) (customer.EventHistoryPage, error) {
+				return customer.EventHistoryPage{}, nil
+			},
+			func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+				return customer.ViewPage{}, nil
+			},
+			func(ctx context.Context, customerID string, afterVersion uint, push func(event customer.HistoricEvent) error) error {
+				return nil
+			},
+		)
+
+		Convey("When the request contains an expected version", func() {
+			_, err := server.ChangeName(context.Background(), &customergrpcproto.ChangeNameRequest{ExpectedVersion: 3})
+
+			Convey("Then it should be passed to the application", func() {
+				So(err, ShouldBeNil)
+				So(receivedExpectedVersion, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When the request metadata contains an If-Match ETag", func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("if-match", `"5"`))
+			_, err := server.ChangeName(ctx, &customergrpcproto.ChangeNameRequest{})
+
+			Convey("Then its version should be passed to the application", func() {
+				So(err, ShouldBeNil)
+				So(receivedExpectedVersion, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When the command succeeds", func() {
+			stream := &headerRecordingServerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			_, err := server.ChangeName(ctx, &customergrpcproto.ChangeNameRequest{ExpectedVersion: 3})
+
+			Convey("Then the new version should be sent as ETag", func() {
+				So(err, ShouldBeNil)
+				So(stream.header.Get(customergrpc.ETagMetadataKey), ShouldResemble, []string{`"4"`})
+			})
+		})
+
+		Convey("When the request metadata contains an invalid If-Match ETag", func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("if-match", "five"))
+			_, err := server.ChangeName(ctx, &customergrpcproto.ChangeNameRequest{})
+
+			Convey("Then it should fail with InvalidArgument", func() {
+				So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When the application reports a version mismatch", func() {
+			_, err := server.ChangeName(context.Background(), &customergrpcproto.ChangeNameRequest{ExpectedVersion: 7})
+
+			Convey("Then it should fail with FailedPrecondition and a version mismatch violation", func() {
+				st := status.Convert(err)
+				So(st.Code(), ShouldEqual, codes.FailedPrecondition)
+				So(st.Details(), ShouldHaveLength, 1)
+
+				preconditionFailure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+				So(ok, ShouldBeTrue)
+				So(preconditionFailure.GetViolations()[0].GetType(), ShouldEqual, customergrpc.VersionMismatchViolationType)
+			})
+		})
+	})
+}
+
+type headerRecordingServerTransportStream struct {
+	header metadata.MD
+}
+
+func (stream *headerRecordingServerTransportStream) Method() string {
+	return "ChangeName"
+}
+
+func (stream *headerRecordingServerTransportStream) SetHeader(md metadata.MD) error {
+	stream.header = metadata.Join(stream.header, md)
+
+	return nil
+}
+
+func (stream *headerRecordingServerTransportStream) SendHeader(md metadata.MD) error {
+	return stream.SetHeader(md)
+}
+
+func (stream *headerRecordingServerTransportStream) SetTrailer(_ metadata.MD) error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package customergrpc
+
+import (
+	"context"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	IdempotencyKeyMetadataKey = "idempotency-key"
+	maxIdempotencyKeyLength   = 255
+)
+
+// withIdempotencyKeyFrom puts the idempotency-key metadata, which the REST gateway fills from the Idempotency-Key
+// header, into the ctx. The key is scoped by the commandName, so it can't replay the result of a different command.
+func withIdempotencyKeyFrom(ctx context.Context, commandName string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	keys := md.Get(IdempotencyKeyMetadataKey)
+	if len(keys) == 0 || keys[0] == "" {
+		return ctx, nil
+	}
+
+	if len(keys[0]) > maxIdempotencyKeyLength {
+		err := errors.Newf("idempotency key must not be longer than %d characters", maxIdempotencyKeyLength)
+		return ctx, errors.Mark(err, shared.ErrInputIsInvalid)
+	}
+
+	return es.ContextWithIdempotencyKey(ctx, es.BuildIdempotencyKey(keys[0], commandName)), nil
+}
@@ -0,0 +1,133 @@
+package customergrpc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	customergrpc "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc"
+	customergrpcproto "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc/proto"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServerIdempotencyKey(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var receivedIdempotencyKey es.IdempotencyKey
+		var hasIdempotencyKey bool
+		originalID := value.GenerateCustomerID()
+
+		server := customergrpc.NewCustomerServer(
+			func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error) {
+				receivedIdempotencyKey, hasIdempotencyKey = es.IdempotencyKeyFrom(ctx)
+				return originalID, nil
+			},
+			func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID, reason string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				receivedIdempotencyKey, hasIdempotencyKey = es.IdempotencyKeyFrom(ctx)
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+				return 1, nil
+			},
+			func(ctx context.Context, customerID string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, customerID string, asOfVersion uint, asOfTime string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, emailAddress string) (customer.View, error) {
+				return customer.View{}, nil
+			},
+			func(ctx context.Context, customerID string) (customer.Export, error) {
+				return customer.Export{}, nil
+			},
+			func(ctx context.Context, customerID string, fromVersion, maxEvents uint) (customer.EventHistoryPage, error) {
+				return customer.EventHistoryPage{}, nil
+			},
+			func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+				return customer.ViewPage{}, nil
+			},
+			func(ctx context.Context, customerID string, afterVersion uint, push func(event customer.HistoricEvent) error) error {
+				return nil
+			},
+		)
+
+		Convey("When a Register request contains an idempotency key", func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", "some-key"))
+			res, err := server.Register(ctx, &customergrpcproto.RegisterRequest{})
+
+			Convey("Then the key should be passed to the application, scoped by the command", func() {
+				So(err, ShouldBeNil)
+				So(hasIdempotencyKey, ShouldBeTrue)
+				So(receivedIdempotencyKey.Key(), ShouldEqual, "some-key")
+				So(receivedIdempotencyKey.CommandName(), ShouldEqual, "Register")
+			})
+
+			Convey("Then the ID returned by the application should be responded", func() {
+				So(res.Id, ShouldEqual, originalID.String())
+			})
+		})
+
+		Convey("When a Delete request contains no idempotency key", func() {
+			_, err := server.Delete(context.Background(), &customergrpcproto.DeleteRequest{})
+
+			Convey("Then no key should be passed to the application", func() {
+				So(err, ShouldBeNil)
+				So(hasIdempotencyKey, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a request contains a too long idempotency key", func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", strings.Repeat("k", 256)))
+			_, err := server.Delete(ctx, &customergrpcproto.DeleteRequest{})
+
+			Convey("Then it should fail with InvalidArgument", func() {
+				So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+	})
+}
@@ -1,12 +1,18 @@
 package customergrpc
 
 import (
+	"context"
+
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/cockroachdb/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// VersionMismatchViolationType marks the PreconditionFailure detail of errors caused by an outdated expected version.
+const VersionMismatchViolationType = "VERSION_MISMATCH"
+
 func MapToGRPCErrors(appErr error) error {
 	var code codes.Code
 
@@ -20,15 +26,42 @@ func MapToGRPCErrors(appErr error) error {
 
 	case errors.Is(appErr, shared.ErrDomainConstraintsViolation):
 		code = codes.FailedPrecondition
+	case errors.Is(appErr, shared.ErrVersionMismatch):
+		return versionMismatchError(appErr)
+	case errors.Is(appErr, shared.ErrRateLimited):
+		code = codes.ResourceExhausted
 
 	case errors.Is(appErr, shared.ErrMaxRetriesExceeded):
 		code = codes.Aborted
 	case errors.Is(appErr, shared.ErrConcurrencyConflict):
 		code = codes.Aborted
 
+	case errors.Is(appErr, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(appErr, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+
 	default:
 		code = codes.Internal
 	}
 
 	return status.Errorf(code, "%s", errors.Cause(appErr))
 }
+
+func versionMismatchError(appErr error) error {
+	st := status.Newf(codes.FailedPrecondition, "%s", errors.Cause(appErr))
+
+	detailed, err := st.WithDetails(
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: VersionMismatchViolationType, Subject: "customer", Description: appErr.Error()},
+			},
+		},
+	)
+
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
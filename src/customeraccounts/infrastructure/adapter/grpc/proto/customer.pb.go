@@ -123,6 +123,7 @@ func (m *RegisterResponse) GetId() string {
 type ConfirmEmailAddressRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	ConfirmationHash     string   `protobuf:"bytes,2,opt,name=confirmationHash,proto3" json:"confirmationHash,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -167,9 +168,68 @@ func (m *ConfirmEmailAddressRequest) GetConfirmationHash() string {
 	return ""
 }
 
+func (m *ConfirmEmailAddressRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type RequestEmailAddressConfirmationResendRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestEmailAddressConfirmationResendRequest) Reset() {
+	*m = RequestEmailAddressConfirmationResendRequest{}
+}
+func (m *RequestEmailAddressConfirmationResendRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*RequestEmailAddressConfirmationResendRequest) ProtoMessage() {}
+func (*RequestEmailAddressConfirmationResendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{3}
+}
+
+func (m *RequestEmailAddressConfirmationResendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestEmailAddressConfirmationResendRequest.Unmarshal(m, b)
+}
+func (m *RequestEmailAddressConfirmationResendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestEmailAddressConfirmationResendRequest.Marshal(b, m, deterministic)
+}
+func (m *RequestEmailAddressConfirmationResendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestEmailAddressConfirmationResendRequest.Merge(m, src)
+}
+func (m *RequestEmailAddressConfirmationResendRequest) XXX_Size() int {
+	return xxx_messageInfo_RequestEmailAddressConfirmationResendRequest.Size(m)
+}
+func (m *RequestEmailAddressConfirmationResendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestEmailAddressConfirmationResendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestEmailAddressConfirmationResendRequest proto.InternalMessageInfo
+
+func (m *RequestEmailAddressConfirmationResendRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RequestEmailAddressConfirmationResendRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type ChangeEmailAddressRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	EmailAddress         string   `protobuf:"bytes,2,opt,name=emailAddress,proto3" json:"emailAddress,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -179,7 +239,7 @@ func (m *ChangeEmailAddressRequest) Reset()         { *m = ChangeEmailAddressReq
 func (m *ChangeEmailAddressRequest) String() string { return proto.CompactTextString(m) }
 func (*ChangeEmailAddressRequest) ProtoMessage()    {}
 func (*ChangeEmailAddressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9efa92dae3d6ec46, []int{3}
+	return fileDescriptor_9efa92dae3d6ec46, []int{4}
 }
 
 func (m *ChangeEmailAddressRequest) XXX_Unmarshal(b []byte) error {
@@ -214,10 +274,65 @@ func (m *ChangeEmailAddressRequest) GetEmailAddress() string {
 	return ""
 }
 
+func (m *ChangeEmailAddressRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type CancelEmailAddressChangeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelEmailAddressChangeRequest) Reset()         { *m = CancelEmailAddressChangeRequest{} }
+func (m *CancelEmailAddressChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelEmailAddressChangeRequest) ProtoMessage()    {}
+func (*CancelEmailAddressChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{5}
+}
+
+func (m *CancelEmailAddressChangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelEmailAddressChangeRequest.Unmarshal(m, b)
+}
+func (m *CancelEmailAddressChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelEmailAddressChangeRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelEmailAddressChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelEmailAddressChangeRequest.Merge(m, src)
+}
+func (m *CancelEmailAddressChangeRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelEmailAddressChangeRequest.Size(m)
+}
+func (m *CancelEmailAddressChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelEmailAddressChangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelEmailAddressChangeRequest proto.InternalMessageInfo
+
+func (m *CancelEmailAddressChangeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CancelEmailAddressChangeRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type ChangeNameRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	GivenName            string   `protobuf:"bytes,2,opt,name=givenName,proto3" json:"givenName,omitempty"`
 	FamilyName           string   `protobuf:"bytes,3,opt,name=familyName,proto3" json:"familyName,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,4,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -227,7 +342,7 @@ func (m *ChangeNameRequest) Reset()         { *m = ChangeNameRequest{} }
 func (m *ChangeNameRequest) String() string { return proto.CompactTextString(m) }
 func (*ChangeNameRequest) ProtoMessage()    {}
 func (*ChangeNameRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9efa92dae3d6ec46, []int{4}
+	return fileDescriptor_9efa92dae3d6ec46, []int{6}
 }
 
 func (m *ChangeNameRequest) XXX_Unmarshal(b []byte) error {
@@ -269,268 +384,1821 @@ func (m *ChangeNameRequest) GetFamilyName() string {
 	return ""
 }
 
-type DeleteRequest struct {
+func (m *ChangeNameRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type AddAddressRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Street               string   `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	PostalCode           string   `protobuf:"bytes,4,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	City                 string   `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode          string   `protobuf:"bytes,6,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,7,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9efa92dae3d6ec46, []int{5}
+func (m *AddAddressRequest) Reset()         { *m = AddAddressRequest{} }
+func (m *AddAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*AddAddressRequest) ProtoMessage()    {}
+func (*AddAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{7}
 }
 
-func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+func (m *AddAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddAddressRequest.Unmarshal(m, b)
 }
-func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+func (m *AddAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddAddressRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRequest.Merge(m, src)
+func (m *AddAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddAddressRequest.Merge(m, src)
 }
-func (m *DeleteRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteRequest.Size(m)
+func (m *AddAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_AddAddressRequest.Size(m)
 }
-func (m *DeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+func (m *AddAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddAddressRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_AddAddressRequest proto.InternalMessageInfo
 
-func (m *DeleteRequest) GetId() string {
+func (m *AddAddressRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-type RetrieveViewRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AddAddressRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
 }
 
-func (m *RetrieveViewRequest) Reset()         { *m = RetrieveViewRequest{} }
-func (m *RetrieveViewRequest) String() string { return proto.CompactTextString(m) }
-func (*RetrieveViewRequest) ProtoMessage()    {}
-func (*RetrieveViewRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9efa92dae3d6ec46, []int{6}
+func (m *AddAddressRequest) GetStreet() string {
+	if m != nil {
+		return m.Street
+	}
+	return ""
 }
 
-func (m *RetrieveViewRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RetrieveViewRequest.Unmarshal(m, b)
-}
-func (m *RetrieveViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RetrieveViewRequest.Marshal(b, m, deterministic)
-}
-func (m *RetrieveViewRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RetrieveViewRequest.Merge(m, src)
-}
-func (m *RetrieveViewRequest) XXX_Size() int {
-	return xxx_messageInfo_RetrieveViewRequest.Size(m)
-}
-func (m *RetrieveViewRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RetrieveViewRequest.DiscardUnknown(m)
+func (m *AddAddressRequest) GetPostalCode() string {
+	if m != nil {
+		return m.PostalCode
+	}
+	return ""
 }
 
-var xxx_messageInfo_RetrieveViewRequest proto.InternalMessageInfo
+func (m *AddAddressRequest) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
 
-func (m *RetrieveViewRequest) GetId() string {
+func (m *AddAddressRequest) GetCountryCode() string {
 	if m != nil {
-		return m.Id
+		return m.CountryCode
 	}
 	return ""
 }
 
-type RetrieveViewResponse struct {
-	EmailAddress            string   `protobuf:"bytes,1,opt,name=emailAddress,proto3" json:"emailAddress,omitempty"`
-	IsEmailAddressConfirmed bool     `protobuf:"varint,2,opt,name=isEmailAddressConfirmed,proto3" json:"isEmailAddressConfirmed,omitempty"`
-	GivenName               string   `protobuf:"bytes,3,opt,name=givenName,proto3" json:"givenName,omitempty"`
-	FamilyName              string   `protobuf:"bytes,4,opt,name=familyName,proto3" json:"familyName,omitempty"`
-	Version                 uint64   `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
-	XXX_unrecognized        []byte   `json:"-"`
-	XXX_sizecache           int32    `json:"-"`
+func (m *AddAddressRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
 }
 
-func (m *RetrieveViewResponse) Reset()         { *m = RetrieveViewResponse{} }
-func (m *RetrieveViewResponse) String() string { return proto.CompactTextString(m) }
-func (*RetrieveViewResponse) ProtoMessage()    {}
-func (*RetrieveViewResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9efa92dae3d6ec46, []int{7}
+type ChangeAddressRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Street               string   `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	PostalCode           string   `protobuf:"bytes,4,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	City                 string   `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode          string   `protobuf:"bytes,6,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,7,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RetrieveViewResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RetrieveViewResponse.Unmarshal(m, b)
+func (m *ChangeAddressRequest) Reset()         { *m = ChangeAddressRequest{} }
+func (m *ChangeAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangeAddressRequest) ProtoMessage()    {}
+func (*ChangeAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{8}
 }
-func (m *RetrieveViewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RetrieveViewResponse.Marshal(b, m, deterministic)
+
+func (m *ChangeAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeAddressRequest.Unmarshal(m, b)
 }
-func (m *RetrieveViewResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RetrieveViewResponse.Merge(m, src)
+func (m *ChangeAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeAddressRequest.Marshal(b, m, deterministic)
 }
-func (m *RetrieveViewResponse) XXX_Size() int {
-	return xxx_messageInfo_RetrieveViewResponse.Size(m)
+func (m *ChangeAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeAddressRequest.Merge(m, src)
 }
-func (m *RetrieveViewResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RetrieveViewResponse.DiscardUnknown(m)
+func (m *ChangeAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangeAddressRequest.Size(m)
+}
+func (m *ChangeAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeAddressRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RetrieveViewResponse proto.InternalMessageInfo
+var xxx_messageInfo_ChangeAddressRequest proto.InternalMessageInfo
 
-func (m *RetrieveViewResponse) GetEmailAddress() string {
+func (m *ChangeAddressRequest) GetId() string {
 	if m != nil {
-		return m.EmailAddress
+		return m.Id
 	}
 	return ""
 }
 
-func (m *RetrieveViewResponse) GetIsEmailAddressConfirmed() bool {
+func (m *ChangeAddressRequest) GetKind() string {
 	if m != nil {
-		return m.IsEmailAddressConfirmed
+		return m.Kind
 	}
-	return false
+	return ""
 }
 
-func (m *RetrieveViewResponse) GetGivenName() string {
+func (m *ChangeAddressRequest) GetStreet() string {
 	if m != nil {
-		return m.GivenName
+		return m.Street
 	}
 	return ""
 }
 
-func (m *RetrieveViewResponse) GetFamilyName() string {
+func (m *ChangeAddressRequest) GetPostalCode() string {
 	if m != nil {
-		return m.FamilyName
+		return m.PostalCode
 	}
 	return ""
 }
 
-func (m *RetrieveViewResponse) GetVersion() uint64 {
+func (m *ChangeAddressRequest) GetCity() string {
 	if m != nil {
-		return m.Version
+		return m.City
 	}
-	return 0
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*RegisterRequest)(nil), "customergrpcproto.RegisterRequest")
-	proto.RegisterType((*RegisterResponse)(nil), "customergrpcproto.RegisterResponse")
-	proto.RegisterType((*ConfirmEmailAddressRequest)(nil), "customergrpcproto.ConfirmEmailAddressRequest")
-	proto.RegisterType((*ChangeEmailAddressRequest)(nil), "customergrpcproto.ChangeEmailAddressRequest")
-	proto.RegisterType((*ChangeNameRequest)(nil), "customergrpcproto.ChangeNameRequest")
-	proto.RegisterType((*DeleteRequest)(nil), "customergrpcproto.DeleteRequest")
-	proto.RegisterType((*RetrieveViewRequest)(nil), "customergrpcproto.RetrieveViewRequest")
-	proto.RegisterType((*RetrieveViewResponse)(nil), "customergrpcproto.RetrieveViewResponse")
+func (m *ChangeAddressRequest) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("customer.proto", fileDescriptor_9efa92dae3d6ec46) }
-
-var fileDescriptor_9efa92dae3d6ec46 = []byte{
-	// 536 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0xdd, 0x0f, 0xd2, 0x51, 0x28, 0xcd, 0x06, 0xb5, 0xa9, 0x5b, 0x95, 0xb0, 0x85, 0x52,
-	0x02, 0xd8, 0x2a, 0x5c, 0x10, 0x37, 0x14, 0x2a, 0x71, 0x02, 0xc9, 0x07, 0xc4, 0x85, 0xc3, 0x36,
-	0x9e, 0xb8, 0x2b, 0xd9, 0x5e, 0xe3, 0x75, 0x82, 0x0a, 0xe2, 0x82, 0xc4, 0x99, 0x03, 0x7f, 0x8b,
-	0x1b, 0x57, 0x8e, 0xfc, 0x10, 0xe4, 0xb5, 0xad, 0x38, 0xb1, 0x37, 0x44, 0xea, 0xd1, 0x33, 0xcf,
-	0xf3, 0xde, 0xcc, 0xbe, 0x07, 0xdb, 0xa3, 0x89, 0x4c, 0x45, 0x88, 0x89, 0x1d, 0x27, 0x22, 0x15,
-	0xa4, 0x53, 0x7e, 0xfb, 0x49, 0x3c, 0x52, 0x25, 0xeb, 0xc0, 0x17, 0xc2, 0x0f, 0xd0, 0x51, 0x5f,
-	0x17, 0x93, 0xb1, 0x83, 0x61, 0x9c, 0x5e, 0xe5, 0x78, 0xeb, 0xb0, 0x68, 0xb2, 0x98, 0x3b, 0x2c,
-	0x8a, 0x44, 0xca, 0x52, 0x2e, 0x22, 0x99, 0x77, 0xa9, 0x84, 0x5b, 0x2e, 0xfa, 0x5c, 0xa6, 0x98,
-	0xb8, 0xf8, 0x71, 0x82, 0x32, 0x25, 0x14, 0xda, 0x18, 0x32, 0x1e, 0xbc, 0xf4, 0xbc, 0x04, 0xa5,
-	0xec, 0x19, 0x7d, 0xe3, 0x74, 0xcb, 0x9d, 0xab, 0x91, 0x43, 0xd8, 0xf2, 0xf9, 0x14, 0xa3, 0x37,
-	0x2c, 0xc4, 0x9e, 0xa9, 0x00, 0xb3, 0x02, 0x39, 0x02, 0x18, 0xb3, 0x90, 0x07, 0x57, 0xaa, 0xbd,
-	0xa6, 0xda, 0x95, 0x0a, 0xa5, 0xb0, 0x33, 0x23, 0x95, 0xb1, 0x88, 0x24, 0x92, 0x6d, 0x30, 0xb9,
-	0x57, 0x70, 0x99, 0xdc, 0xa3, 0xef, 0xc1, 0x1a, 0x8a, 0x68, 0xcc, 0x93, 0xf0, 0xbc, 0x42, 0x5c,
-	0x6a, 0x5c, 0x40, 0x93, 0x01, 0xec, 0x8c, 0x72, 0xb4, 0xda, 0xee, 0x35, 0x93, 0x97, 0x85, 0xac,
-	0x5a, 0x9d, 0xbe, 0x85, 0xfd, 0xe1, 0x25, 0x8b, 0x7c, 0x5c, 0x65, 0xf0, 0xe2, 0x31, 0xcc, 0xfa,
-	0x31, 0x28, 0x83, 0x4e, 0x3e, 0x30, 0x5b, 0x4e, 0x37, 0xe8, 0x7a, 0x17, 0xbb, 0x03, 0x37, 0x5f,
-	0x61, 0x80, 0xa9, 0x6e, 0x3c, 0xbd, 0x0f, 0x5d, 0x17, 0xd3, 0x84, 0xe3, 0x14, 0xdf, 0x71, 0xfc,
-	0xa4, 0x83, 0xfd, 0x32, 0xe0, 0xf6, 0x3c, 0xae, 0x38, 0xff, 0x2a, 0x8f, 0xfe, 0x1c, 0xf6, 0xb8,
-	0xac, 0x1e, 0xad, 0x78, 0x20, 0xf4, 0xd4, 0x42, 0x2d, 0x57, 0xd7, 0x9e, 0x5f, 0x7e, 0x6d, 0xf9,
-	0xf2, 0xeb, 0x8b, 0xcb, 0x93, 0x1e, 0xdc, 0x98, 0x62, 0x22, 0xb9, 0x88, 0x7a, 0x1b, 0x7d, 0xe3,
-	0x74, 0xdd, 0x2d, 0x3f, 0x9f, 0xfe, 0xd9, 0x80, 0xd6, 0xb0, 0x88, 0x03, 0x09, 0xa0, 0x55, 0xba,
-	0x8a, 0x50, 0xbb, 0x96, 0x12, 0x7b, 0xc1, 0xe7, 0xd6, 0xf1, 0x52, 0x4c, 0x7e, 0x17, 0xba, 0xf7,
-	0xed, 0xf7, 0xdf, 0x9f, 0x66, 0x87, 0xb6, 0x9d, 0xe9, 0x99, 0x53, 0xe2, 0x5f, 0x18, 0x03, 0xf2,
-	0xc3, 0x80, 0x6e, 0x83, 0x41, 0xc9, 0x93, 0x86, 0xa9, 0x7a, 0x23, 0x5b, 0xbb, 0x76, 0x1e, 0x4f,
-	0xbb, 0xcc, 0xae, 0x7d, 0x9e, 0x65, 0x97, 0x9e, 0x29, 0xde, 0x47, 0xd6, 0x49, 0x95, 0xd7, 0xf9,
-	0xc2, 0xbd, 0xaf, 0x8e, 0x7a, 0x13, 0x96, 0x8f, 0x71, 0x0a, 0x77, 0x67, 0x8a, 0xbe, 0x1b, 0x40,
-	0xea, 0xc6, 0x26, 0x8f, 0x9b, 0x04, 0xe9, 0xfc, 0xaf, 0xd5, 0xf3, 0x50, 0xe9, 0x39, 0xb6, 0x8e,
-	0x96, 0xeb, 0xc9, 0x74, 0x84, 0x00, 0xb3, 0x38, 0x90, 0x7b, 0x5a, 0xfa, 0x4a, 0x5a, 0xb4, 0xb4,
-	0x77, 0x15, 0xed, 0x81, 0xb5, 0x5b, 0xa7, 0x8d, 0x58, 0x88, 0x19, 0xdd, 0x07, 0xd8, 0xcc, 0xa3,
-	0x41, 0xfa, 0x0d, 0x54, 0x73, 0xa9, 0xd1, 0xd2, 0xec, 0x2b, 0x9a, 0xee, 0xa0, 0x53, 0xa3, 0x21,
-	0x9f, 0xa1, 0x5d, 0x0d, 0x0c, 0x39, 0x69, 0x74, 0x4d, 0x2d, 0x79, 0xd6, 0x83, 0xff, 0xe2, 0x0a,
-	0x87, 0x15, 0xdc, 0xa4, 0xce, 0x7d, 0xb1, 0xa9, 0x7e, 0x7b, 0xf6, 0x2f, 0x00, 0x00, 0xff, 0xff,
-	0xef, 0x84, 0x5e, 0x9d, 0x03, 0x06, 0x00, 0x00,
+func (m *ChangeAddressRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// CustomerClient is the client API for Customer service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CustomerClient interface {
-	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
-	ConfirmEmailAddress(ctx context.Context, in *ConfirmEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ChangeEmailAddress(ctx context.Context, in *ChangeEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ChangeName(ctx context.Context, in *ChangeNameRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	RetrieveView(ctx context.Context, in *RetrieveViewRequest, opts ...grpc.CallOption) (*RetrieveViewResponse, error)
+type RemoveAddressRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type customerClient struct {
-	cc *grpc.ClientConn
+func (m *RemoveAddressRequest) Reset()         { *m = RemoveAddressRequest{} }
+func (m *RemoveAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveAddressRequest) ProtoMessage()    {}
+func (*RemoveAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{9}
 }
 
-func NewCustomerClient(cc *grpc.ClientConn) CustomerClient {
-	return &customerClient{cc}
+func (m *RemoveAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveAddressRequest.Unmarshal(m, b)
 }
-
-func (c *customerClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
-	out := new(RegisterResponse)
-	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Register", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *RemoveAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveAddressRequest.Merge(m, src)
+}
+func (m *RemoveAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveAddressRequest.Size(m)
+}
+func (m *RemoveAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAddressRequest.DiscardUnknown(m)
 }
 
-func (c *customerClient) ConfirmEmailAddress(ctx context.Context, in *ConfirmEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ConfirmEmailAddress", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RemoveAddressRequest proto.InternalMessageInfo
+
+func (m *RemoveAddressRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return ""
 }
 
-func (c *customerClient) ChangeEmailAddress(ctx context.Context, in *ChangeEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangeEmailAddress", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RemoveAddressRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
 	}
-	return out, nil
+	return ""
 }
 
-func (c *customerClient) ChangeName(ctx context.Context, in *ChangeNameRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangeName", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RemoveAddressRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
 	}
-	return out, nil
+	return 0
+}
+
+type ChangePhoneNumberRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PhoneNumber          string   `protobuf:"bytes,2,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangePhoneNumberRequest) Reset()         { *m = ChangePhoneNumberRequest{} }
+func (m *ChangePhoneNumberRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangePhoneNumberRequest) ProtoMessage()    {}
+func (*ChangePhoneNumberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{10}
+}
+
+func (m *ChangePhoneNumberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePhoneNumberRequest.Unmarshal(m, b)
+}
+func (m *ChangePhoneNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePhoneNumberRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangePhoneNumberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePhoneNumberRequest.Merge(m, src)
+}
+func (m *ChangePhoneNumberRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangePhoneNumberRequest.Size(m)
+}
+func (m *ChangePhoneNumberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePhoneNumberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangePhoneNumberRequest proto.InternalMessageInfo
+
+func (m *ChangePhoneNumberRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChangePhoneNumberRequest) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *ChangePhoneNumberRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type ConfirmPhoneNumberRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConfirmationCode     string   `protobuf:"bytes,2,opt,name=confirmationCode,proto3" json:"confirmationCode,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmPhoneNumberRequest) Reset()         { *m = ConfirmPhoneNumberRequest{} }
+func (m *ConfirmPhoneNumberRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmPhoneNumberRequest) ProtoMessage()    {}
+func (*ConfirmPhoneNumberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{11}
+}
+
+func (m *ConfirmPhoneNumberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmPhoneNumberRequest.Unmarshal(m, b)
+}
+func (m *ConfirmPhoneNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmPhoneNumberRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmPhoneNumberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmPhoneNumberRequest.Merge(m, src)
+}
+func (m *ConfirmPhoneNumberRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmPhoneNumberRequest.Size(m)
+}
+func (m *ConfirmPhoneNumberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmPhoneNumberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmPhoneNumberRequest proto.InternalMessageInfo
+
+func (m *ConfirmPhoneNumberRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConfirmPhoneNumberRequest) GetConfirmationCode() string {
+	if m != nil {
+		return m.ConfirmationCode
+	}
+	return ""
+}
+
+func (m *ConfirmPhoneNumberRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type SuspendRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SuspendRequest) Reset()         { *m = SuspendRequest{} }
+func (m *SuspendRequest) String() string { return proto.CompactTextString(m) }
+func (*SuspendRequest) ProtoMessage()    {}
+func (*SuspendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{12}
+}
+
+func (m *SuspendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuspendRequest.Unmarshal(m, b)
+}
+func (m *SuspendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuspendRequest.Marshal(b, m, deterministic)
+}
+func (m *SuspendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuspendRequest.Merge(m, src)
+}
+func (m *SuspendRequest) XXX_Size() int {
+	return xxx_messageInfo_SuspendRequest.Size(m)
+}
+func (m *SuspendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuspendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuspendRequest proto.InternalMessageInfo
+
+func (m *SuspendRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SuspendRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SuspendRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type ReactivateRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReactivateRequest) Reset()         { *m = ReactivateRequest{} }
+func (m *ReactivateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReactivateRequest) ProtoMessage()    {}
+func (*ReactivateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{13}
+}
+
+func (m *ReactivateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReactivateRequest.Unmarshal(m, b)
+}
+func (m *ReactivateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReactivateRequest.Marshal(b, m, deterministic)
+}
+func (m *ReactivateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReactivateRequest.Merge(m, src)
+}
+func (m *ReactivateRequest) XXX_Size() int {
+	return xxx_messageInfo_ReactivateRequest.Size(m)
+}
+func (m *ReactivateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReactivateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReactivateRequest proto.InternalMessageInfo
+
+func (m *ReactivateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ReactivateRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{14}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{15}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type ErasePersonalDataRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErasePersonalDataRequest) Reset()         { *m = ErasePersonalDataRequest{} }
+func (m *ErasePersonalDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ErasePersonalDataRequest) ProtoMessage()    {}
+func (*ErasePersonalDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{16}
+}
+
+func (m *ErasePersonalDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErasePersonalDataRequest.Unmarshal(m, b)
+}
+func (m *ErasePersonalDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErasePersonalDataRequest.Marshal(b, m, deterministic)
+}
+func (m *ErasePersonalDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErasePersonalDataRequest.Merge(m, src)
+}
+func (m *ErasePersonalDataRequest) XXX_Size() int {
+	return xxx_messageInfo_ErasePersonalDataRequest.Size(m)
+}
+func (m *ErasePersonalDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErasePersonalDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErasePersonalDataRequest proto.InternalMessageInfo
+
+func (m *ErasePersonalDataRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ErasePersonalDataRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type RetrieveViewRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AsOfVersion          uint64   `protobuf:"varint,2,opt,name=asOfVersion,proto3" json:"asOfVersion,omitempty"`
+	AsOfTime             string   `protobuf:"bytes,3,opt,name=asOfTime,proto3" json:"asOfTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetrieveViewRequest) Reset()         { *m = RetrieveViewRequest{} }
+func (m *RetrieveViewRequest) String() string { return proto.CompactTextString(m) }
+func (*RetrieveViewRequest) ProtoMessage()    {}
+func (*RetrieveViewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{17}
+}
+
+func (m *RetrieveViewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrieveViewRequest.Unmarshal(m, b)
+}
+func (m *RetrieveViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrieveViewRequest.Marshal(b, m, deterministic)
+}
+func (m *RetrieveViewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrieveViewRequest.Merge(m, src)
+}
+func (m *RetrieveViewRequest) XXX_Size() int {
+	return xxx_messageInfo_RetrieveViewRequest.Size(m)
+}
+func (m *RetrieveViewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrieveViewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetrieveViewRequest proto.InternalMessageInfo
+
+func (m *RetrieveViewRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RetrieveViewRequest) GetAsOfVersion() uint64 {
+	if m != nil {
+		return m.AsOfVersion
+	}
+	return 0
+}
+
+func (m *RetrieveViewRequest) GetAsOfTime() string {
+	if m != nil {
+		return m.AsOfTime
+	}
+	return ""
+}
+
+type RetrieveViewByEmailAddressRequest struct {
+	EmailAddress         string   `protobuf:"bytes,1,opt,name=emailAddress,proto3" json:"emailAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetrieveViewByEmailAddressRequest) Reset()         { *m = RetrieveViewByEmailAddressRequest{} }
+func (m *RetrieveViewByEmailAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*RetrieveViewByEmailAddressRequest) ProtoMessage()    {}
+func (*RetrieveViewByEmailAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{18}
+}
+
+func (m *RetrieveViewByEmailAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrieveViewByEmailAddressRequest.Unmarshal(m, b)
+}
+func (m *RetrieveViewByEmailAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrieveViewByEmailAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *RetrieveViewByEmailAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrieveViewByEmailAddressRequest.Merge(m, src)
+}
+func (m *RetrieveViewByEmailAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_RetrieveViewByEmailAddressRequest.Size(m)
+}
+func (m *RetrieveViewByEmailAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrieveViewByEmailAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetrieveViewByEmailAddressRequest proto.InternalMessageInfo
+
+func (m *RetrieveViewByEmailAddressRequest) GetEmailAddress() string {
+	if m != nil {
+		return m.EmailAddress
+	}
+	return ""
+}
+
+type RetrieveViewResponse struct {
+	EmailAddress            string     `protobuf:"bytes,1,opt,name=emailAddress,proto3" json:"emailAddress,omitempty"`
+	IsEmailAddressConfirmed bool       `protobuf:"varint,2,opt,name=isEmailAddressConfirmed,proto3" json:"isEmailAddressConfirmed,omitempty"`
+	GivenName               string     `protobuf:"bytes,3,opt,name=givenName,proto3" json:"givenName,omitempty"`
+	FamilyName              string     `protobuf:"bytes,4,opt,name=familyName,proto3" json:"familyName,omitempty"`
+	Version                 uint64     `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	PendingEmailAddress     string     `protobuf:"bytes,6,opt,name=pendingEmailAddress,proto3" json:"pendingEmailAddress,omitempty"`
+	Status                  string     `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Addresses               []*Address `protobuf:"bytes,8,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	PhoneNumber             string     `protobuf:"bytes,9,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	IsPhoneNumberConfirmed  bool       `protobuf:"varint,10,opt,name=isPhoneNumberConfirmed,proto3" json:"isPhoneNumberConfirmed,omitempty"`
+	Id                      string     `protobuf:"bytes,11,opt,name=id,proto3" json:"id,omitempty"`
+	RegisteredAt            string     `protobuf:"bytes,12,opt,name=registeredAt,proto3" json:"registeredAt,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}   `json:"-"`
+	XXX_unrecognized        []byte     `json:"-"`
+	XXX_sizecache           int32      `json:"-"`
+}
+
+func (m *RetrieveViewResponse) Reset()         { *m = RetrieveViewResponse{} }
+func (m *RetrieveViewResponse) String() string { return proto.CompactTextString(m) }
+func (*RetrieveViewResponse) ProtoMessage()    {}
+func (*RetrieveViewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{19}
+}
+
+func (m *RetrieveViewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrieveViewResponse.Unmarshal(m, b)
+}
+func (m *RetrieveViewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrieveViewResponse.Marshal(b, m, deterministic)
+}
+func (m *RetrieveViewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrieveViewResponse.Merge(m, src)
+}
+func (m *RetrieveViewResponse) XXX_Size() int {
+	return xxx_messageInfo_RetrieveViewResponse.Size(m)
+}
+func (m *RetrieveViewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrieveViewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetrieveViewResponse proto.InternalMessageInfo
+
+func (m *RetrieveViewResponse) GetEmailAddress() string {
+	if m != nil {
+		return m.EmailAddress
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetIsEmailAddressConfirmed() bool {
+	if m != nil {
+		return m.IsEmailAddressConfirmed
+	}
+	return false
+}
+
+func (m *RetrieveViewResponse) GetGivenName() string {
+	if m != nil {
+		return m.GivenName
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetFamilyName() string {
+	if m != nil {
+		return m.FamilyName
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RetrieveViewResponse) GetPendingEmailAddress() string {
+	if m != nil {
+		return m.PendingEmailAddress
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetAddresses() []*Address {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *RetrieveViewResponse) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetIsPhoneNumberConfirmed() bool {
+	if m != nil {
+		return m.IsPhoneNumberConfirmed
+	}
+	return false
+}
+
+func (m *RetrieveViewResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RetrieveViewResponse) GetRegisteredAt() string {
+	if m != nil {
+		return m.RegisteredAt
+	}
+	return ""
+}
+
+type Address struct {
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Street               string   `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	PostalCode           string   `protobuf:"bytes,3,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	City                 string   `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode          string   `protobuf:"bytes,5,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Address) Reset()         { *m = Address{} }
+func (m *Address) String() string { return proto.CompactTextString(m) }
+func (*Address) ProtoMessage()    {}
+func (*Address) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{20}
+}
+
+func (m *Address) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Address.Unmarshal(m, b)
+}
+func (m *Address) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Address.Marshal(b, m, deterministic)
+}
+func (m *Address) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Address.Merge(m, src)
+}
+func (m *Address) XXX_Size() int {
+	return xxx_messageInfo_Address.Size(m)
+}
+func (m *Address) XXX_DiscardUnknown() {
+	xxx_messageInfo_Address.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Address proto.InternalMessageInfo
+
+func (m *Address) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Address) GetStreet() string {
+	if m != nil {
+		return m.Street
+	}
+	return ""
+}
+
+func (m *Address) GetPostalCode() string {
+	if m != nil {
+		return m.PostalCode
+	}
+	return ""
+}
+
+func (m *Address) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *Address) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{21}
+}
+
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ExportResponse struct {
+	View                 *RetrieveViewResponse `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	Events               []*ExportedEvent      `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	UniqueEmailAddresses []string              `protobuf:"bytes,3,rep,name=uniqueEmailAddresses,proto3" json:"uniqueEmailAddresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ExportResponse) Reset()         { *m = ExportResponse{} }
+func (m *ExportResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()    {}
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{22}
+}
+
+func (m *ExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportResponse.Unmarshal(m, b)
+}
+func (m *ExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportResponse.Merge(m, src)
+}
+func (m *ExportResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportResponse.Size(m)
+}
+func (m *ExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportResponse proto.InternalMessageInfo
+
+func (m *ExportResponse) GetView() *RetrieveViewResponse {
+	if m != nil {
+		return m.View
+	}
+	return nil
+}
+
+func (m *ExportResponse) GetEvents() []*ExportedEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ExportResponse) GetUniqueEmailAddresses() []string {
+	if m != nil {
+		return m.UniqueEmailAddresses
+	}
+	return nil
+}
+
+type ExportedEvent struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	OccurredAt           string   `protobuf:"bytes,3,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	StreamVersion        uint64   `protobuf:"varint,4,opt,name=streamVersion,proto3" json:"streamVersion,omitempty"`
+	IsFailure            bool     `protobuf:"varint,5,opt,name=isFailure,proto3" json:"isFailure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportedEvent) Reset()         { *m = ExportedEvent{} }
+func (m *ExportedEvent) String() string { return proto.CompactTextString(m) }
+func (*ExportedEvent) ProtoMessage()    {}
+func (*ExportedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{23}
+}
+
+func (m *ExportedEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportedEvent.Unmarshal(m, b)
+}
+func (m *ExportedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportedEvent.Marshal(b, m, deterministic)
+}
+func (m *ExportedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportedEvent.Merge(m, src)
+}
+func (m *ExportedEvent) XXX_Size() int {
+	return xxx_messageInfo_ExportedEvent.Size(m)
+}
+func (m *ExportedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportedEvent proto.InternalMessageInfo
+
+func (m *ExportedEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExportedEvent) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ExportedEvent) GetOccurredAt() string {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return ""
+}
+
+func (m *ExportedEvent) GetStreamVersion() uint64 {
+	if m != nil {
+		return m.StreamVersion
+	}
+	return 0
+}
+
+func (m *ExportedEvent) GetIsFailure() bool {
+	if m != nil {
+		return m.IsFailure
+	}
+	return false
+}
+
+type RetrieveEventHistoryRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromVersion          uint64   `protobuf:"varint,2,opt,name=fromVersion,proto3" json:"fromVersion,omitempty"`
+	MaxEvents            uint32   `protobuf:"varint,3,opt,name=maxEvents,proto3" json:"maxEvents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetrieveEventHistoryRequest) Reset()         { *m = RetrieveEventHistoryRequest{} }
+func (m *RetrieveEventHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*RetrieveEventHistoryRequest) ProtoMessage()    {}
+func (*RetrieveEventHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{24}
+}
+
+func (m *RetrieveEventHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrieveEventHistoryRequest.Unmarshal(m, b)
+}
+func (m *RetrieveEventHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrieveEventHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *RetrieveEventHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrieveEventHistoryRequest.Merge(m, src)
+}
+func (m *RetrieveEventHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_RetrieveEventHistoryRequest.Size(m)
+}
+func (m *RetrieveEventHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrieveEventHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetrieveEventHistoryRequest proto.InternalMessageInfo
+
+func (m *RetrieveEventHistoryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RetrieveEventHistoryRequest) GetFromVersion() uint64 {
+	if m != nil {
+		return m.FromVersion
+	}
+	return 0
+}
+
+func (m *RetrieveEventHistoryRequest) GetMaxEvents() uint32 {
+	if m != nil {
+		return m.MaxEvents
+	}
+	return 0
+}
+
+type RetrieveEventHistoryResponse struct {
+	Events               []*HistoricEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextFromVersion      uint64           `protobuf:"varint,2,opt,name=nextFromVersion,proto3" json:"nextFromVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RetrieveEventHistoryResponse) Reset()         { *m = RetrieveEventHistoryResponse{} }
+func (m *RetrieveEventHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*RetrieveEventHistoryResponse) ProtoMessage()    {}
+func (*RetrieveEventHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{25}
+}
+
+func (m *RetrieveEventHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrieveEventHistoryResponse.Unmarshal(m, b)
+}
+func (m *RetrieveEventHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrieveEventHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *RetrieveEventHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrieveEventHistoryResponse.Merge(m, src)
+}
+func (m *RetrieveEventHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_RetrieveEventHistoryResponse.Size(m)
+}
+func (m *RetrieveEventHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrieveEventHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetrieveEventHistoryResponse proto.InternalMessageInfo
+
+func (m *RetrieveEventHistoryResponse) GetEvents() []*HistoricEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *RetrieveEventHistoryResponse) GetNextFromVersion() uint64 {
+	if m != nil {
+		return m.NextFromVersion
+	}
+	return 0
+}
+
+type HistoricEvent struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StreamVersion        uint64            `protobuf:"varint,2,opt,name=streamVersion,proto3" json:"streamVersion,omitempty"`
+	OccurredAt           string            `protobuf:"bytes,3,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	MessageID            string            `protobuf:"bytes,4,opt,name=messageID,proto3" json:"messageID,omitempty"`
+	CausationID          string            `protobuf:"bytes,5,opt,name=causationID,proto3" json:"causationID,omitempty"`
+	IsFailure            bool              `protobuf:"varint,6,opt,name=isFailure,proto3" json:"isFailure,omitempty"`
+	Payload              map[string]string `protobuf:"bytes,7,rep,name=payload,proto3" json:"payload,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *HistoricEvent) Reset()         { *m = HistoricEvent{} }
+func (m *HistoricEvent) String() string { return proto.CompactTextString(m) }
+func (*HistoricEvent) ProtoMessage()    {}
+func (*HistoricEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{26}
+}
+
+func (m *HistoricEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoricEvent.Unmarshal(m, b)
+}
+func (m *HistoricEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoricEvent.Marshal(b, m, deterministic)
+}
+func (m *HistoricEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoricEvent.Merge(m, src)
+}
+func (m *HistoricEvent) XXX_Size() int {
+	return xxx_messageInfo_HistoricEvent.Size(m)
+}
+func (m *HistoricEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoricEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoricEvent proto.InternalMessageInfo
+
+func (m *HistoricEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HistoricEvent) GetStreamVersion() uint64 {
+	if m != nil {
+		return m.StreamVersion
+	}
+	return 0
+}
+
+func (m *HistoricEvent) GetOccurredAt() string {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return ""
+}
+
+func (m *HistoricEvent) GetMessageID() string {
+	if m != nil {
+		return m.MessageID
+	}
+	return ""
+}
+
+func (m *HistoricEvent) GetCausationID() string {
+	if m != nil {
+		return m.CausationID
+	}
+	return ""
+}
+
+func (m *HistoricEvent) GetIsFailure() bool {
+	if m != nil {
+		return m.IsFailure
+	}
+	return false
+}
+
+func (m *HistoricEvent) GetPayload() map[string]string {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type ListCustomersRequest struct {
+	PageSize             uint32   `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Cursor               string   `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	SortBy               string   `protobuf:"bytes,3,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	SortDescending       bool     `protobuf:"varint,4,opt,name=sortDescending,proto3" json:"sortDescending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCustomersRequest) Reset()         { *m = ListCustomersRequest{} }
+func (m *ListCustomersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCustomersRequest) ProtoMessage()    {}
+func (*ListCustomersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{27}
+}
+
+func (m *ListCustomersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCustomersRequest.Unmarshal(m, b)
+}
+func (m *ListCustomersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCustomersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCustomersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCustomersRequest.Merge(m, src)
+}
+func (m *ListCustomersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCustomersRequest.Size(m)
+}
+func (m *ListCustomersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCustomersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCustomersRequest proto.InternalMessageInfo
+
+func (m *ListCustomersRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCustomersRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListCustomersRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+func (m *ListCustomersRequest) GetSortDescending() bool {
+	if m != nil {
+		return m.SortDescending
+	}
+	return false
+}
+
+type SearchCustomersRequest struct {
+	EmailAddress         string   `protobuf:"bytes,1,opt,name=emailAddress,proto3" json:"emailAddress,omitempty"`
+	NameFragment         string   `protobuf:"bytes,2,opt,name=nameFragment,proto3" json:"nameFragment,omitempty"`
+	ConfirmationStatus   string   `protobuf:"bytes,3,opt,name=confirmationStatus,proto3" json:"confirmationStatus,omitempty"`
+	RegisteredFrom       string   `protobuf:"bytes,4,opt,name=registeredFrom,proto3" json:"registeredFrom,omitempty"`
+	RegisteredBefore     string   `protobuf:"bytes,5,opt,name=registeredBefore,proto3" json:"registeredBefore,omitempty"`
+	PageSize             uint32   `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Cursor               string   `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	SortBy               string   `protobuf:"bytes,8,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	SortDescending       bool     `protobuf:"varint,9,opt,name=sortDescending,proto3" json:"sortDescending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchCustomersRequest) Reset()         { *m = SearchCustomersRequest{} }
+func (m *SearchCustomersRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchCustomersRequest) ProtoMessage()    {}
+func (*SearchCustomersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{28}
+}
+
+func (m *SearchCustomersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchCustomersRequest.Unmarshal(m, b)
+}
+func (m *SearchCustomersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchCustomersRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchCustomersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchCustomersRequest.Merge(m, src)
+}
+func (m *SearchCustomersRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchCustomersRequest.Size(m)
+}
+func (m *SearchCustomersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchCustomersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchCustomersRequest proto.InternalMessageInfo
+
+func (m *SearchCustomersRequest) GetEmailAddress() string {
+	if m != nil {
+		return m.EmailAddress
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetNameFragment() string {
+	if m != nil {
+		return m.NameFragment
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetConfirmationStatus() string {
+	if m != nil {
+		return m.ConfirmationStatus
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetRegisteredFrom() string {
+	if m != nil {
+		return m.RegisteredFrom
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetRegisteredBefore() string {
+	if m != nil {
+		return m.RegisteredBefore
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchCustomersRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+func (m *SearchCustomersRequest) GetSortDescending() bool {
+	if m != nil {
+		return m.SortDescending
+	}
+	return false
+}
+
+type CustomerViewsResponse struct {
+	Views                []*RetrieveViewResponse `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	NextCursor           string                  `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *CustomerViewsResponse) Reset()         { *m = CustomerViewsResponse{} }
+func (m *CustomerViewsResponse) String() string { return proto.CompactTextString(m) }
+func (*CustomerViewsResponse) ProtoMessage()    {}
+func (*CustomerViewsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{29}
+}
+
+func (m *CustomerViewsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomerViewsResponse.Unmarshal(m, b)
+}
+func (m *CustomerViewsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomerViewsResponse.Marshal(b, m, deterministic)
+}
+func (m *CustomerViewsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerViewsResponse.Merge(m, src)
+}
+func (m *CustomerViewsResponse) XXX_Size() int {
+	return xxx_messageInfo_CustomerViewsResponse.Size(m)
+}
+func (m *CustomerViewsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerViewsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerViewsResponse proto.InternalMessageInfo
+
+func (m *CustomerViewsResponse) GetViews() []*RetrieveViewResponse {
+	if m != nil {
+		return m.Views
+	}
+	return nil
+}
+
+func (m *CustomerViewsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type WatchCustomerRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AfterVersion         uint64   `protobuf:"varint,2,opt,name=afterVersion,proto3" json:"afterVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchCustomerRequest) Reset()         { *m = WatchCustomerRequest{} }
+func (m *WatchCustomerRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchCustomerRequest) ProtoMessage()    {}
+func (*WatchCustomerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9efa92dae3d6ec46, []int{30}
+}
+
+func (m *WatchCustomerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchCustomerRequest.Unmarshal(m, b)
+}
+func (m *WatchCustomerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchCustomerRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchCustomerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchCustomerRequest.Merge(m, src)
+}
+func (m *WatchCustomerRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchCustomerRequest.Size(m)
+}
+func (m *WatchCustomerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchCustomerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchCustomerRequest proto.InternalMessageInfo
+
+func (m *WatchCustomerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WatchCustomerRequest) GetAfterVersion() uint64 {
+	if m != nil {
+		return m.AfterVersion
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*RegisterRequest)(nil), "customergrpcproto.RegisterRequest")
+	proto.RegisterType((*RegisterResponse)(nil), "customergrpcproto.RegisterResponse")
+	proto.RegisterType((*ConfirmEmailAddressRequest)(nil), "customergrpcproto.ConfirmEmailAddressRequest")
+	proto.RegisterType((*RequestEmailAddressConfirmationResendRequest)(nil), "customergrpcproto.RequestEmailAddressConfirmationResendRequest")
+	proto.RegisterType((*ChangeEmailAddressRequest)(nil), "customergrpcproto.ChangeEmailAddressRequest")
+	proto.RegisterType((*CancelEmailAddressChangeRequest)(nil), "customergrpcproto.CancelEmailAddressChangeRequest")
+	proto.RegisterType((*ChangeNameRequest)(nil), "customergrpcproto.ChangeNameRequest")
+	proto.RegisterType((*AddAddressRequest)(nil), "customergrpcproto.AddAddressRequest")
+	proto.RegisterType((*ChangeAddressRequest)(nil), "customergrpcproto.ChangeAddressRequest")
+	proto.RegisterType((*RemoveAddressRequest)(nil), "customergrpcproto.RemoveAddressRequest")
+	proto.RegisterType((*ChangePhoneNumberRequest)(nil), "customergrpcproto.ChangePhoneNumberRequest")
+	proto.RegisterType((*ConfirmPhoneNumberRequest)(nil), "customergrpcproto.ConfirmPhoneNumberRequest")
+	proto.RegisterType((*SuspendRequest)(nil), "customergrpcproto.SuspendRequest")
+	proto.RegisterType((*ReactivateRequest)(nil), "customergrpcproto.ReactivateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "customergrpcproto.DeleteRequest")
+	proto.RegisterType((*RestoreRequest)(nil), "customergrpcproto.RestoreRequest")
+	proto.RegisterType((*ErasePersonalDataRequest)(nil), "customergrpcproto.ErasePersonalDataRequest")
+	proto.RegisterType((*RetrieveViewRequest)(nil), "customergrpcproto.RetrieveViewRequest")
+	proto.RegisterType((*RetrieveViewByEmailAddressRequest)(nil), "customergrpcproto.RetrieveViewByEmailAddressRequest")
+	proto.RegisterType((*RetrieveViewResponse)(nil), "customergrpcproto.RetrieveViewResponse")
+	proto.RegisterType((*Address)(nil), "customergrpcproto.Address")
+	proto.RegisterType((*ExportRequest)(nil), "customergrpcproto.ExportRequest")
+	proto.RegisterType((*ExportResponse)(nil), "customergrpcproto.ExportResponse")
+	proto.RegisterType((*ExportedEvent)(nil), "customergrpcproto.ExportedEvent")
+	proto.RegisterType((*RetrieveEventHistoryRequest)(nil), "customergrpcproto.RetrieveEventHistoryRequest")
+	proto.RegisterType((*RetrieveEventHistoryResponse)(nil), "customergrpcproto.RetrieveEventHistoryResponse")
+	proto.RegisterType((*HistoricEvent)(nil), "customergrpcproto.HistoricEvent")
+	proto.RegisterMapType((map[string]string)(nil), "customergrpcproto.HistoricEvent.PayloadEntry")
+	proto.RegisterType((*ListCustomersRequest)(nil), "customergrpcproto.ListCustomersRequest")
+	proto.RegisterType((*SearchCustomersRequest)(nil), "customergrpcproto.SearchCustomersRequest")
+	proto.RegisterType((*CustomerViewsResponse)(nil), "customergrpcproto.CustomerViewsResponse")
+	proto.RegisterType((*WatchCustomerRequest)(nil), "customergrpcproto.WatchCustomerRequest")
+}
+
+func init() { proto.RegisterFile("customer.proto", fileDescriptor_9efa92dae3d6ec46) }
+
+var fileDescriptor_9efa92dae3d6ec46 = []byte{
+	// 1893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcf, 0x6f, 0xdb, 0xc8,
+	0x15, 0x06, 0x25, 0x5b, 0x3f, 0x9e, 0x2d, 0x3b, 0x1e, 0x6b, 0x6d, 0x99, 0x71, 0x13, 0x99, 0x9b,
+	0x38, 0x5a, 0x65, 0x2b, 0x65, 0xdd, 0xa2, 0x35, 0x52, 0x2c, 0x8a, 0xc4, 0x76, 0x76, 0xb3, 0x68,
+	0xb7, 0x01, 0xbd, 0x48, 0x0f, 0x45, 0x0f, 0x63, 0x72, 0x2c, 0xb3, 0x91, 0x48, 0x85, 0x43, 0xc9,
+	0x56, 0x82, 0x00, 0x6d, 0xd0, 0x5e, 0xda, 0x02, 0x3d, 0x14, 0x28, 0xd0, 0x63, 0x8f, 0xfd, 0x1b,
+	0x7a, 0x28, 0x7a, 0x2f, 0x50, 0xa0, 0xbd, 0xf4, 0xd4, 0x53, 0xff, 0x90, 0x62, 0x86, 0x43, 0x91,
+	0x14, 0x67, 0x24, 0xba, 0xc9, 0x69, 0x6f, 0x9c, 0x37, 0xc3, 0xf9, 0xbe, 0xf9, 0xde, 0xcc, 0x9b,
+	0x37, 0x0f, 0xd6, 0xac, 0x11, 0x0d, 0xbc, 0x01, 0xf1, 0x3b, 0x43, 0xdf, 0x0b, 0x3c, 0xb4, 0x11,
+	0xb5, 0x7b, 0xfe, 0xd0, 0xe2, 0x26, 0xfd, 0x66, 0xcf, 0xf3, 0x7a, 0x7d, 0xd2, 0xe5, 0xad, 0xb3,
+	0xd1, 0x79, 0x97, 0x0c, 0x86, 0xc1, 0x24, 0x1c, 0xaf, 0xef, 0x8a, 0x4e, 0x3c, 0x74, 0xba, 0xd8,
+	0x75, 0xbd, 0x00, 0x07, 0x8e, 0xe7, 0xd2, 0xb0, 0xd7, 0xa0, 0xb0, 0x6e, 0x92, 0x9e, 0x43, 0x03,
+	0xe2, 0x9b, 0xe4, 0xe5, 0x88, 0xd0, 0x00, 0x19, 0xb0, 0x4a, 0x06, 0xd8, 0xe9, 0x3f, 0xb2, 0x6d,
+	0x9f, 0x50, 0xda, 0xd0, 0x9a, 0x5a, 0xab, 0x6a, 0xa6, 0x6c, 0x68, 0x17, 0xaa, 0x3d, 0x67, 0x4c,
+	0xdc, 0x2f, 0xf1, 0x80, 0x34, 0x0a, 0x7c, 0x40, 0x6c, 0x40, 0xb7, 0x00, 0xce, 0xf1, 0xc0, 0xe9,
+	0x4f, 0x78, 0x77, 0x91, 0x77, 0x27, 0x2c, 0x86, 0x01, 0x37, 0x62, 0x50, 0x3a, 0xf4, 0x5c, 0x4a,
+	0xd0, 0x1a, 0x14, 0x1c, 0x5b, 0x60, 0x15, 0x1c, 0xdb, 0x78, 0xab, 0x81, 0x7e, 0xe4, 0xb9, 0xe7,
+	0x8e, 0x3f, 0x38, 0x49, 0x20, 0x47, 0x24, 0x67, 0x86, 0xa3, 0x36, 0xdc, 0xb0, 0xc2, 0xd1, 0x7c,
+	0x79, 0x9f, 0x63, 0x7a, 0x21, 0x78, 0x65, 0xec, 0xa8, 0x05, 0xeb, 0xe4, 0x6a, 0x48, 0xac, 0x80,
+	0xd8, 0xcf, 0x89, 0x4f, 0x1d, 0xcf, 0xe5, 0x1c, 0x97, 0xcc, 0x59, 0xb3, 0x71, 0x01, 0x1f, 0x0b,
+	0xc0, 0x24, 0x87, 0xa3, 0xc4, 0x84, 0x26, 0xa1, 0xc4, 0xb5, 0x55, 0xac, 0x24, 0x48, 0x05, 0x39,
+	0xd2, 0x04, 0x76, 0x8e, 0x2e, 0xb0, 0xdb, 0x23, 0x79, 0x16, 0x3b, 0xeb, 0xa1, 0x82, 0xc4, 0x43,
+	0xf9, 0x17, 0xf9, 0x13, 0xb8, 0x7d, 0x84, 0x5d, 0x8b, 0xf4, 0x53, 0x6b, 0xe4, 0x64, 0xde, 0x7d,
+	0x5d, 0xbf, 0xd1, 0x60, 0x23, 0x9c, 0x8b, 0x79, 0x5e, 0x35, 0xdf, 0x3b, 0x6d, 0x27, 0x19, 0x9b,
+	0x25, 0x39, 0x9b, 0x7f, 0x68, 0xb0, 0xf1, 0xc8, 0xb6, 0x17, 0xc8, 0x8b, 0x60, 0xe9, 0x85, 0xe3,
+	0xda, 0x82, 0x08, 0xff, 0x46, 0x5b, 0x50, 0xa2, 0x81, 0x4f, 0x48, 0x20, 0xf0, 0x45, 0x8b, 0x71,
+	0x1b, 0x7a, 0x34, 0xc0, 0xfd, 0x23, 0xcf, 0x26, 0x1c, 0xb6, 0x6a, 0x26, 0x2c, 0x6c, 0x2e, 0xcb,
+	0x09, 0x26, 0x8d, 0xe5, 0x70, 0x2e, 0xf6, 0x8d, 0x9a, 0xb0, 0x62, 0x79, 0x23, 0x37, 0xf0, 0x27,
+	0xfc, 0xa7, 0x12, 0xef, 0x4a, 0x9a, 0x64, 0x2b, 0x2a, 0xcb, 0x57, 0xf4, 0x4f, 0x0d, 0xea, 0xa1,
+	0xbe, 0x5f, 0xa3, 0x45, 0xd9, 0x50, 0x37, 0xc9, 0xc0, 0x1b, 0xff, 0x3f, 0x6b, 0xca, 0xbf, 0xef,
+	0xc7, 0xd0, 0x08, 0x95, 0x7b, 0x76, 0xe1, 0xb9, 0xe4, 0xcb, 0xd1, 0xe0, 0x8c, 0xf8, 0x2a, 0xa4,
+	0x26, 0xac, 0x0c, 0xe3, 0x51, 0x02, 0x30, 0x69, 0xba, 0x06, 0xee, 0x2f, 0x34, 0xd8, 0x11, 0x21,
+	0x24, 0x07, 0xf2, 0x4c, 0x60, 0xe3, 0xe2, 0x4a, 0x02, 0x9b, 0x4a, 0x61, 0x05, 0x87, 0x33, 0x58,
+	0x3b, 0x1d, 0xd1, 0xe1, 0x9c, 0xd0, 0xb5, 0x05, 0x25, 0x9f, 0x60, 0x2a, 0x4e, 0x76, 0xd5, 0x14,
+	0xad, 0x6b, 0x60, 0xfc, 0x10, 0x36, 0x4c, 0x82, 0xad, 0xc0, 0x19, 0xe3, 0xe0, 0x3d, 0x44, 0x92,
+	0xa7, 0x50, 0x3b, 0x26, 0x7d, 0xf2, 0x3e, 0xa6, 0xfa, 0x02, 0xd6, 0x4c, 0x42, 0x03, 0xcf, 0x7f,
+	0x0f, 0x73, 0x7d, 0x05, 0x8d, 0x13, 0x1f, 0x53, 0xf2, 0x8c, 0xf8, 0xd4, 0x73, 0x71, 0xff, 0x18,
+	0x07, 0xf8, 0xdd, 0x67, 0xb5, 0x60, 0xd3, 0x24, 0x81, 0xef, 0x90, 0x31, 0x79, 0xee, 0x90, 0xcb,
+	0x39, 0xdb, 0x12, 0xd3, 0x1f, 0x9d, 0xa7, 0x27, 0x4b, 0x9a, 0x90, 0x0e, 0x15, 0xd6, 0xfc, 0xca,
+	0x99, 0x46, 0xce, 0x69, 0xdb, 0xf8, 0x0c, 0xf6, 0x92, 0x20, 0x8f, 0x27, 0xb2, 0xbb, 0x27, 0x47,
+	0x36, 0x60, 0xfc, 0xa7, 0x08, 0xf5, 0xe4, 0x4c, 0xd3, 0x4b, 0x3d, 0xc7, 0xcf, 0xe8, 0x10, 0xb6,
+	0x1d, 0x2a, 0xb9, 0x5e, 0x49, 0x78, 0xae, 0x2b, 0xa6, 0xaa, 0x3b, 0x7d, 0x6b, 0x14, 0xe7, 0xdf,
+	0x1a, 0x4b, 0x99, 0x5b, 0xa3, 0x01, 0xe5, 0xb1, 0xd0, 0x6d, 0x99, 0xeb, 0x16, 0x35, 0xd1, 0x03,
+	0xd8, 0x64, 0x27, 0xc3, 0x71, 0x7b, 0x49, 0x5c, 0x11, 0xd2, 0x64, 0x5d, 0x61, 0x20, 0xc5, 0xc1,
+	0x88, 0x36, 0xca, 0x51, 0x20, 0x65, 0x2d, 0x74, 0x08, 0x55, 0x1c, 0x0e, 0x21, 0xb4, 0x51, 0x69,
+	0x16, 0x5b, 0x2b, 0x07, 0x7a, 0x27, 0x93, 0xbf, 0x75, 0x22, 0xc9, 0xe3, 0xc1, 0xb3, 0x01, 0xa7,
+	0x9a, 0x0d, 0x38, 0xdf, 0x81, 0x2d, 0x87, 0x26, 0x02, 0x48, 0x2c, 0x1b, 0x70, 0xd9, 0x14, 0xbd,
+	0x62, 0x0f, 0xad, 0x24, 0x93, 0x09, 0x5f, 0x24, 0x63, 0xc4, 0x7e, 0x14, 0x34, 0x56, 0x43, 0x1f,
+	0x25, 0x6d, 0xec, 0x16, 0x2f, 0x47, 0x6b, 0x8d, 0x82, 0xae, 0x26, 0xbd, 0x48, 0x0a, 0x73, 0x2e,
+	0x92, 0xa2, 0xf2, 0x22, 0x59, 0x52, 0x5f, 0x24, 0xcb, 0x99, 0x8b, 0xc4, 0xb8, 0x0d, 0xb5, 0x93,
+	0xab, 0xa1, 0xe7, 0x07, 0x8a, 0x63, 0x61, 0xfc, 0x45, 0x83, 0xb5, 0x68, 0x84, 0xd8, 0x89, 0xdf,
+	0x83, 0xa5, 0xb1, 0x43, 0x2e, 0xf9, 0xa0, 0x95, 0x83, 0x7b, 0x12, 0x27, 0xc8, 0x36, 0xb0, 0xc9,
+	0x7f, 0x42, 0x87, 0x50, 0x22, 0x63, 0xe2, 0x06, 0x2c, 0xd3, 0x62, 0x3e, 0x6c, 0x4a, 0x7e, 0x0f,
+	0xf1, 0x88, 0x7d, 0xc2, 0x06, 0x9a, 0x62, 0x3c, 0x3a, 0x80, 0xfa, 0xc8, 0x75, 0x5e, 0x8e, 0x52,
+	0x69, 0x1d, 0xa1, 0x8d, 0x62, 0xb3, 0xd8, 0xaa, 0x9a, 0xd2, 0x3e, 0xe3, 0xcf, 0x1a, 0xd4, 0x52,
+	0xb3, 0x31, 0x99, 0x5c, 0xb6, 0x89, 0x85, 0xe4, 0xec, 0x9b, 0xc9, 0x64, 0x13, 0x6a, 0xf9, 0xce,
+	0x30, 0x70, 0xa6, 0x41, 0x3a, 0x69, 0x62, 0xe2, 0x7b, 0x96, 0x35, 0xf2, 0x43, 0xb7, 0x0a, 0xf1,
+	0x63, 0x0b, 0xba, 0x03, 0x35, 0xe6, 0x26, 0x3c, 0x48, 0x27, 0x4d, 0x69, 0x23, 0x3b, 0x64, 0x0e,
+	0x7d, 0x82, 0x9d, 0xfe, 0xc8, 0x0f, 0x9d, 0x51, 0x31, 0x63, 0x83, 0x31, 0x80, 0x9b, 0x91, 0x6e,
+	0x9c, 0xea, 0xe7, 0x0e, 0x0d, 0x3c, 0x7f, 0x32, 0x27, 0x5e, 0x9d, 0xfb, 0xde, 0x60, 0x26, 0x5e,
+	0x25, 0x4c, 0x0c, 0x6e, 0x80, 0xaf, 0x4e, 0x42, 0xb5, 0x19, 0xe7, 0x9a, 0x19, 0x1b, 0xd8, 0xa3,
+	0x60, 0x57, 0x8e, 0x27, 0xdc, 0x1c, 0x7b, 0x4a, 0x53, 0x7a, 0x2a, 0xfc, 0xc7, 0xb1, 0xd2, 0x9e,
+	0x6a, 0xc1, 0xba, 0x4b, 0xae, 0x82, 0x27, 0x19, 0x7a, 0xb3, 0x66, 0xe3, 0xef, 0x05, 0xa8, 0xa5,
+	0xe6, 0x90, 0xfa, 0x27, 0xa3, 0x6e, 0x41, 0xa6, 0xee, 0x22, 0x1f, 0x31, 0x39, 0x08, 0xa5, 0xb8,
+	0x47, 0x9e, 0x1e, 0x8b, 0x53, 0x12, 0x1b, 0xf8, 0x51, 0xc1, 0x23, 0xca, 0x13, 0x80, 0xa7, 0xc7,
+	0xd3, 0xa3, 0x12, 0x9b, 0xd2, 0xde, 0x2b, 0xcd, 0x78, 0x0f, 0x7d, 0x06, 0xe5, 0x21, 0x9e, 0xf4,
+	0x3d, 0x6c, 0x37, 0xca, 0x5c, 0xae, 0x6f, 0x2e, 0x92, 0xab, 0xf3, 0x2c, 0x1c, 0x7f, 0xc2, 0x0e,
+	0xa3, 0x19, 0xfd, 0xad, 0x3f, 0x84, 0xd5, 0x64, 0x07, 0xba, 0x01, 0xc5, 0x17, 0x64, 0x22, 0xf4,
+	0x60, 0x9f, 0xa8, 0x0e, 0xcb, 0x63, 0xdc, 0x1f, 0x45, 0xb9, 0x4b, 0xd8, 0x78, 0x58, 0x38, 0xd4,
+	0x8c, 0x5f, 0x6b, 0x50, 0xff, 0x81, 0x43, 0x83, 0x23, 0x81, 0x3c, 0xbd, 0x79, 0x74, 0xa8, 0x0c,
+	0x71, 0x8f, 0x9c, 0x3a, 0xaf, 0x42, 0x65, 0x6b, 0xe6, 0xb4, 0xcd, 0x02, 0x8e, 0x35, 0xf2, 0xa9,
+	0x17, 0xa5, 0x62, 0xa2, 0xc5, 0xec, 0xd4, 0xf3, 0x83, 0xc7, 0x93, 0x69, 0x46, 0xcb, 0x5b, 0x68,
+	0x1f, 0xd6, 0xd8, 0xd7, 0x31, 0xa1, 0x56, 0x18, 0xbe, 0xb9, 0x98, 0x15, 0x73, 0xc6, 0x6a, 0xfc,
+	0xbb, 0x00, 0x5b, 0xa7, 0x04, 0xfb, 0xd6, 0x45, 0x86, 0x4e, 0x9e, 0xbb, 0xcc, 0x80, 0x55, 0xe6,
+	0xfc, 0x27, 0x3e, 0xee, 0x0d, 0x88, 0x1b, 0x45, 0xc3, 0x94, 0x0d, 0x75, 0x00, 0x25, 0x13, 0xb7,
+	0xd3, 0xf0, 0xde, 0x08, 0xe9, 0x4a, 0x7a, 0x18, 0xf5, 0x38, 0x16, 0xb3, 0x7d, 0x28, 0xf6, 0xc1,
+	0x8c, 0x95, 0x25, 0x8a, 0xb1, 0xe5, 0x31, 0x39, 0xf7, 0xfc, 0x28, 0x78, 0x66, 0xec, 0x29, 0x69,
+	0x4b, 0x4a, 0x69, 0xcb, 0x0a, 0x69, 0x2b, 0x0b, 0xa4, 0xad, 0x4a, 0xa5, 0x1d, 0xc3, 0x07, 0x91,
+	0xa6, 0x2c, 0xc4, 0xd2, 0xe9, 0x99, 0xfd, 0x14, 0x96, 0x59, 0x94, 0x8d, 0x8e, 0x6c, 0xee, 0xd8,
+	0x1c, 0xfe, 0xc5, 0x8e, 0x10, 0x3b, 0xa1, 0x47, 0xc9, 0xed, 0x90, 0xb0, 0x18, 0x5f, 0x40, 0xfd,
+	0xc7, 0x38, 0x88, 0x1d, 0x3a, 0xe7, 0x51, 0x8d, 0xcf, 0x03, 0xe2, 0xa7, 0xcf, 0x6b, 0xca, 0x76,
+	0xf0, 0xb7, 0x6d, 0xa8, 0x44, 0xf3, 0xa0, 0x3e, 0x54, 0xa2, 0x2a, 0x06, 0x32, 0xa4, 0xa4, 0x53,
+	0x75, 0x15, 0xfd, 0xc3, 0xb9, 0x63, 0xc2, 0x45, 0x19, 0xdb, 0x6f, 0xff, 0xf5, 0xdf, 0xdf, 0x17,
+	0x36, 0x8c, 0xd5, 0xee, 0xf8, 0x93, 0x6e, 0x34, 0xfe, 0xa1, 0xd6, 0x46, 0xbf, 0xd3, 0x60, 0x53,
+	0x52, 0x0f, 0x41, 0xb2, 0x23, 0xab, 0xae, 0x9b, 0xe8, 0x5b, 0x9d, 0xb0, 0x1c, 0xd4, 0x89, 0x6a,
+	0x45, 0x9d, 0x13, 0x56, 0x2b, 0x32, 0x3e, 0xe1, 0xb8, 0xf7, 0xf5, 0xfd, 0x24, 0x6e, 0xf7, 0xb5,
+	0x63, 0xbf, 0xe9, 0xf2, 0x1d, 0x2e, 0x32, 0x93, 0xae, 0xd8, 0xa0, 0x8c, 0xd1, 0x5f, 0x35, 0xb8,
+	0x9b, 0xab, 0x3a, 0x82, 0xbe, 0x2f, 0x5d, 0x79, 0xfe, 0xba, 0x8a, 0x92, 0xf5, 0xa7, 0x9c, 0xf5,
+	0x77, 0x8d, 0x83, 0x7c, 0xac, 0xf9, 0xcc, 0x5d, 0x9f, 0x4f, 0xcd, 0x56, 0xf0, 0x2b, 0x0d, 0x50,
+	0xb6, 0xea, 0x82, 0x3e, 0x96, 0x49, 0xaa, 0x2a, 0xce, 0x28, 0xb9, 0x7d, 0xc4, 0xb9, 0x7d, 0xa8,
+	0xdf, 0x9a, 0xcf, 0x8d, 0xf1, 0xf8, 0x83, 0x06, 0x0d, 0x55, 0x09, 0x06, 0x1d, 0xc8, 0xd8, 0xcc,
+	0xaf, 0xd7, 0x28, 0x39, 0x75, 0x38, 0xa7, 0x56, 0x7b, 0x91, 0x97, 0x45, 0x8e, 0x8b, 0x06, 0x00,
+	0x71, 0xf1, 0x06, 0xdd, 0x51, 0xea, 0x92, 0xa8, 0xed, 0x28, 0xb1, 0xf7, 0x38, 0xf6, 0x4d, 0x7d,
+	0x2b, 0x8b, 0xcd, 0xe2, 0x23, 0xd3, 0xe1, 0x12, 0x20, 0xae, 0xce, 0x48, 0xe1, 0x32, 0xc5, 0x1b,
+	0x25, 0xdc, 0x7d, 0x0e, 0x77, 0xd7, 0x68, 0x66, 0xe1, 0xa2, 0x55, 0xbe, 0x66, 0xb9, 0xeb, 0x1b,
+	0x06, 0xfc, 0x06, 0x6a, 0xa9, 0x22, 0x0a, 0xba, 0xa7, 0x5c, 0xea, 0xf5, 0xe0, 0xf5, 0x5c, 0xf0,
+	0xaf, 0xa0, 0x96, 0xaa, 0x77, 0x20, 0x79, 0x0c, 0xcc, 0x56, 0x44, 0x94, 0xf0, 0x2d, 0x0e, 0x6f,
+	0xb4, 0x17, 0xc2, 0xa3, 0xb7, 0xd3, 0x02, 0x5d, 0xe2, 0xb5, 0x80, 0xee, 0x2b, 0xd7, 0x9f, 0x2d,
+	0x59, 0x2c, 0x22, 0xa1, 0x7f, 0x23, 0x4b, 0x82, 0x3f, 0x64, 0x5c, 0x3e, 0x0b, 0x13, 0xe0, 0xb7,
+	0xec, 0x20, 0x66, 0x4a, 0x22, 0xf2, 0x83, 0xa8, 0xaa, 0x9c, 0x28, 0x69, 0x3c, 0xe0, 0x34, 0xda,
+	0xfa, 0xdd, 0xb9, 0x34, 0x92, 0x91, 0x6d, 0x00, 0x65, 0x51, 0x1d, 0x41, 0x7b, 0x12, 0x0a, 0xe9,
+	0xca, 0x89, 0x12, 0xf7, 0x1e, 0xc7, 0xdd, 0x33, 0x76, 0xb3, 0xb8, 0x94, 0xcf, 0xc0, 0xae, 0x14,
+	0x06, 0x37, 0x04, 0x88, 0x0b, 0x25, 0xd2, 0x6d, 0x9f, 0xa9, 0xa3, 0x28, 0x41, 0xef, 0x70, 0xd0,
+	0x5b, 0xed, 0xb9, 0xa0, 0xe8, 0xa7, 0x50, 0x0a, 0x6b, 0x29, 0x48, 0x96, 0x20, 0xa7, 0xca, 0x2c,
+	0x4a, 0xa4, 0x1d, 0x8e, 0xb4, 0xd9, 0xde, 0xc8, 0x20, 0xa1, 0x9f, 0x41, 0x59, 0xd4, 0x57, 0xa4,
+	0xfa, 0xa5, 0x6b, 0x2f, 0x8b, 0x96, 0x62, 0xec, 0x64, 0x97, 0xe2, 0x87, 0x33, 0x30, 0xf1, 0x7e,
+	0xae, 0xc1, 0x46, 0xa6, 0x00, 0x23, 0xdd, 0xbf, 0xaa, 0x32, 0x8d, 0x92, 0xc0, 0x3e, 0x27, 0xd0,
+	0x6c, 0x4b, 0x22, 0xf8, 0x50, 0x4c, 0x63, 0x33, 0xb0, 0x57, 0xb0, 0x9a, 0x4c, 0x50, 0xd0, 0xfe,
+	0xc2, 0x0c, 0x26, 0xc4, 0xcd, 0x9b, 0xe9, 0x44, 0x52, 0x23, 0x89, 0xd4, 0x7f, 0xd2, 0x40, 0x57,
+	0x17, 0x71, 0xd0, 0xb7, 0x17, 0x40, 0x48, 0x6b, 0x3e, 0xf9, 0x89, 0x09, 0x17, 0xa1, 0xd4, 0x6e,
+	0xa3, 0xdd, 0xb3, 0x49, 0xf2, 0x36, 0x41, 0x1e, 0x94, 0xc2, 0xf7, 0x2c, 0x52, 0x3f, 0x9c, 0x23,
+	0xe8, 0xbd, 0x39, 0x23, 0x04, 0x68, 0x93, 0x83, 0xea, 0xa8, 0x91, 0x75, 0x0b, 0x09, 0x61, 0xfe,
+	0xa8, 0xc5, 0xf5, 0xa8, 0xe4, 0x33, 0x11, 0x75, 0xe6, 0x2c, 0x4c, 0xf2, 0x7e, 0xd5, 0xbb, 0xb9,
+	0xc7, 0xe7, 0xe0, 0x16, 0xbe, 0x33, 0x27, 0x50, 0x4b, 0xbd, 0x76, 0xa4, 0xb1, 0x5e, 0xf6, 0x1e,
+	0xd2, 0x5b, 0xb2, 0x68, 0x28, 0xcb, 0xa8, 0x8d, 0x0f, 0x38, 0x8b, 0x75, 0x54, 0x4b, 0xb9, 0x05,
+	0xfd, 0x52, 0x83, 0xf5, 0x99, 0xc7, 0x0d, 0xfa, 0x48, 0x16, 0xdf, 0xa4, 0x0f, 0xa0, 0x6b, 0xe0,
+	0xef, 0x72, 0xfc, 0x2d, 0x54, 0x4f, 0x6f, 0x0b, 0xca, 0xe7, 0x65, 0x97, 0x6d, 0x2a, 0x21, 0x97,
+	0x2a, 0x20, 0x4b, 0xd9, 0xf5, 0x85, 0xaf, 0x79, 0xe3, 0x36, 0x47, 0xde, 0x41, 0xdb, 0x59, 0xfd,
+	0x2f, 0xd9, 0x8c, 0x0f, 0xb4, 0xb3, 0x12, 0xff, 0xef, 0x5b, 0xff, 0x1b, 0x00, 0x7c, 0xd8, 0xe8,
+	0xa4, 0x58, 0x1d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CustomerClient is the client API for Customer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CustomerClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	ConfirmEmailAddress(ctx context.Context, in *ConfirmEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RequestEmailAddressConfirmationResend(ctx context.Context, in *RequestEmailAddressConfirmationResendRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangeEmailAddress(ctx context.Context, in *ChangeEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	CancelEmailAddressChange(ctx context.Context, in *CancelEmailAddressChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangeName(ctx context.Context, in *ChangeNameRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	AddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangeAddress(ctx context.Context, in *ChangeAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RemoveAddress(ctx context.Context, in *RemoveAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangePhoneNumber(ctx context.Context, in *ChangePhoneNumberRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ConfirmPhoneNumber(ctx context.Context, in *ConfirmPhoneNumberRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Reactivate(ctx context.Context, in *ReactivateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ErasePersonalData(ctx context.Context, in *ErasePersonalDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RetrieveView(ctx context.Context, in *RetrieveViewRequest, opts ...grpc.CallOption) (*RetrieveViewResponse, error)
+	RetrieveViewByEmailAddress(ctx context.Context, in *RetrieveViewByEmailAddressRequest, opts ...grpc.CallOption) (*RetrieveViewResponse, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	RetrieveEventHistory(ctx context.Context, in *RetrieveEventHistoryRequest, opts ...grpc.CallOption) (*RetrieveEventHistoryResponse, error)
+	ListCustomers(ctx context.Context, in *ListCustomersRequest, opts ...grpc.CallOption) (*CustomerViewsResponse, error)
+	SearchCustomers(ctx context.Context, in *SearchCustomersRequest, opts ...grpc.CallOption) (*CustomerViewsResponse, error)
+	WatchCustomer(ctx context.Context, in *WatchCustomerRequest, opts ...grpc.CallOption) (Customer_WatchCustomerClient, error)
+}
+
+type customerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCustomerClient(cc *grpc.ClientConn) CustomerClient {
+	return &customerClient{cc}
+}
+
+func (c *customerClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ConfirmEmailAddress(ctx context.Context, in *ConfirmEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ConfirmEmailAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) RequestEmailAddressConfirmationResend(ctx context.Context, in *RequestEmailAddressConfirmationResendRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/RequestEmailAddressConfirmationResend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ChangeEmailAddress(ctx context.Context, in *ChangeEmailAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangeEmailAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) CancelEmailAddressChange(ctx context.Context, in *CancelEmailAddressChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/CancelEmailAddressChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ChangeName(ctx context.Context, in *ChangeNameRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangeName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) AddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/AddAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ChangeAddress(ctx context.Context, in *ChangeAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangeAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) RemoveAddress(ctx context.Context, in *RemoveAddressRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/RemoveAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ChangePhoneNumber(ctx context.Context, in *ChangePhoneNumberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ChangePhoneNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ConfirmPhoneNumber(ctx context.Context, in *ConfirmPhoneNumberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ConfirmPhoneNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Suspend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) Reactivate(ctx context.Context, in *ReactivateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Reactivate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *customerClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -542,6 +2210,24 @@ func (c *customerClient) Delete(ctx context.Context, in *DeleteRequest, opts ...
 	return out, nil
 }
 
+func (c *customerClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ErasePersonalData(ctx context.Context, in *ErasePersonalDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ErasePersonalData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *customerClient) RetrieveView(ctx context.Context, in *RetrieveViewRequest, opts ...grpc.CallOption) (*RetrieveViewResponse, error) {
 	out := new(RetrieveViewResponse)
 	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/RetrieveView", in, out, opts...)
@@ -551,14 +2237,108 @@ func (c *customerClient) RetrieveView(ctx context.Context, in *RetrieveViewReque
 	return out, nil
 }
 
+func (c *customerClient) RetrieveViewByEmailAddress(ctx context.Context, in *RetrieveViewByEmailAddressRequest, opts ...grpc.CallOption) (*RetrieveViewResponse, error) {
+	out := new(RetrieveViewResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/RetrieveViewByEmailAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) RetrieveEventHistory(ctx context.Context, in *RetrieveEventHistoryRequest, opts ...grpc.CallOption) (*RetrieveEventHistoryResponse, error) {
+	out := new(RetrieveEventHistoryResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/RetrieveEventHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ListCustomers(ctx context.Context, in *ListCustomersRequest, opts ...grpc.CallOption) (*CustomerViewsResponse, error) {
+	out := new(CustomerViewsResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/ListCustomers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) SearchCustomers(ctx context.Context, in *SearchCustomersRequest, opts ...grpc.CallOption) (*CustomerViewsResponse, error) {
+	out := new(CustomerViewsResponse)
+	err := c.cc.Invoke(ctx, "/customergrpcproto.Customer/SearchCustomers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) WatchCustomer(ctx context.Context, in *WatchCustomerRequest, opts ...grpc.CallOption) (Customer_WatchCustomerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Customer_serviceDesc.Streams[0], "/customergrpcproto.Customer/WatchCustomer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &customerWatchCustomerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Customer_WatchCustomerClient interface {
+	Recv() (*HistoricEvent, error)
+	grpc.ClientStream
+}
+
+type customerWatchCustomerClient struct {
+	grpc.ClientStream
+}
+
+func (x *customerWatchCustomerClient) Recv() (*HistoricEvent, error) {
+	m := new(HistoricEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CustomerServer is the server API for Customer service.
 type CustomerServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	ConfirmEmailAddress(context.Context, *ConfirmEmailAddressRequest) (*empty.Empty, error)
+	RequestEmailAddressConfirmationResend(context.Context, *RequestEmailAddressConfirmationResendRequest) (*empty.Empty, error)
 	ChangeEmailAddress(context.Context, *ChangeEmailAddressRequest) (*empty.Empty, error)
+	CancelEmailAddressChange(context.Context, *CancelEmailAddressChangeRequest) (*empty.Empty, error)
 	ChangeName(context.Context, *ChangeNameRequest) (*empty.Empty, error)
+	AddAddress(context.Context, *AddAddressRequest) (*empty.Empty, error)
+	ChangeAddress(context.Context, *ChangeAddressRequest) (*empty.Empty, error)
+	RemoveAddress(context.Context, *RemoveAddressRequest) (*empty.Empty, error)
+	ChangePhoneNumber(context.Context, *ChangePhoneNumberRequest) (*empty.Empty, error)
+	ConfirmPhoneNumber(context.Context, *ConfirmPhoneNumberRequest) (*empty.Empty, error)
+	Suspend(context.Context, *SuspendRequest) (*empty.Empty, error)
+	Reactivate(context.Context, *ReactivateRequest) (*empty.Empty, error)
 	Delete(context.Context, *DeleteRequest) (*empty.Empty, error)
+	Restore(context.Context, *RestoreRequest) (*empty.Empty, error)
+	ErasePersonalData(context.Context, *ErasePersonalDataRequest) (*empty.Empty, error)
 	RetrieveView(context.Context, *RetrieveViewRequest) (*RetrieveViewResponse, error)
+	RetrieveViewByEmailAddress(context.Context, *RetrieveViewByEmailAddressRequest) (*RetrieveViewResponse, error)
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+	RetrieveEventHistory(context.Context, *RetrieveEventHistoryRequest) (*RetrieveEventHistoryResponse, error)
+	ListCustomers(context.Context, *ListCustomersRequest) (*CustomerViewsResponse, error)
+	SearchCustomers(context.Context, *SearchCustomersRequest) (*CustomerViewsResponse, error)
+	WatchCustomer(*WatchCustomerRequest, Customer_WatchCustomerServer) error
 }
 
 // UnimplementedCustomerServer can be embedded to have forward compatible implementations.
@@ -571,17 +2351,68 @@ func (*UnimplementedCustomerServer) Register(ctx context.Context, req *RegisterR
 func (*UnimplementedCustomerServer) ConfirmEmailAddress(ctx context.Context, req *ConfirmEmailAddressRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmailAddress not implemented")
 }
+func (*UnimplementedCustomerServer) RequestEmailAddressConfirmationResend(ctx context.Context, req *RequestEmailAddressConfirmationResendRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmailAddressConfirmationResend not implemented")
+}
 func (*UnimplementedCustomerServer) ChangeEmailAddress(ctx context.Context, req *ChangeEmailAddressRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeEmailAddress not implemented")
 }
+func (*UnimplementedCustomerServer) CancelEmailAddressChange(ctx context.Context, req *CancelEmailAddressChangeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelEmailAddressChange not implemented")
+}
 func (*UnimplementedCustomerServer) ChangeName(ctx context.Context, req *ChangeNameRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeName not implemented")
 }
-func (*UnimplementedCustomerServer) Delete(ctx context.Context, req *DeleteRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (*UnimplementedCustomerServer) AddAddress(ctx context.Context, req *AddAddressRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAddress not implemented")
+}
+func (*UnimplementedCustomerServer) ChangeAddress(ctx context.Context, req *ChangeAddressRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAddress not implemented")
+}
+func (*UnimplementedCustomerServer) RemoveAddress(ctx context.Context, req *RemoveAddressRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAddress not implemented")
+}
+func (*UnimplementedCustomerServer) ChangePhoneNumber(ctx context.Context, req *ChangePhoneNumberRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePhoneNumber not implemented")
+}
+func (*UnimplementedCustomerServer) ConfirmPhoneNumber(ctx context.Context, req *ConfirmPhoneNumberRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPhoneNumber not implemented")
+}
+func (*UnimplementedCustomerServer) Suspend(ctx context.Context, req *SuspendRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suspend not implemented")
+}
+func (*UnimplementedCustomerServer) Reactivate(ctx context.Context, req *ReactivateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reactivate not implemented")
+}
+func (*UnimplementedCustomerServer) Delete(ctx context.Context, req *DeleteRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedCustomerServer) Restore(ctx context.Context, req *RestoreRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedCustomerServer) ErasePersonalData(ctx context.Context, req *ErasePersonalDataRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErasePersonalData not implemented")
+}
+func (*UnimplementedCustomerServer) RetrieveView(ctx context.Context, req *RetrieveViewRequest) (*RetrieveViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveView not implemented")
+}
+func (*UnimplementedCustomerServer) RetrieveViewByEmailAddress(ctx context.Context, req *RetrieveViewByEmailAddressRequest) (*RetrieveViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveViewByEmailAddress not implemented")
+}
+func (*UnimplementedCustomerServer) Export(ctx context.Context, req *ExportRequest) (*ExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (*UnimplementedCustomerServer) RetrieveEventHistory(ctx context.Context, req *RetrieveEventHistoryRequest) (*RetrieveEventHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveEventHistory not implemented")
+}
+func (*UnimplementedCustomerServer) ListCustomers(ctx context.Context, req *ListCustomersRequest) (*CustomerViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomers not implemented")
+}
+func (*UnimplementedCustomerServer) SearchCustomers(ctx context.Context, req *SearchCustomersRequest) (*CustomerViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCustomers not implemented")
 }
-func (*UnimplementedCustomerServer) RetrieveView(ctx context.Context, req *RetrieveViewRequest) (*RetrieveViewResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RetrieveView not implemented")
+func (*UnimplementedCustomerServer) WatchCustomer(req *WatchCustomerRequest, srv Customer_WatchCustomerServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCustomer not implemented")
 }
 
 func RegisterCustomerServer(s *grpc.Server, srv CustomerServer) {
@@ -624,6 +2455,24 @@ func _Customer_ConfirmEmailAddress_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_RequestEmailAddressConfirmationResend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmailAddressConfirmationResendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).RequestEmailAddressConfirmationResend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/RequestEmailAddressConfirmationResend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).RequestEmailAddressConfirmationResend(ctx, req.(*RequestEmailAddressConfirmationResendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Customer_ChangeEmailAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangeEmailAddressRequest)
 	if err := dec(in); err != nil {
@@ -642,6 +2491,24 @@ func _Customer_ChangeEmailAddress_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_CancelEmailAddressChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelEmailAddressChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).CancelEmailAddressChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/CancelEmailAddressChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).CancelEmailAddressChange(ctx, req.(*CancelEmailAddressChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Customer_ChangeName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangeNameRequest)
 	if err := dec(in); err != nil {
@@ -660,6 +2527,132 @@ func _Customer_ChangeName_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_AddAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).AddAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/AddAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).AddAddress(ctx, req.(*AddAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ChangeAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ChangeAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/ChangeAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ChangeAddress(ctx, req.(*ChangeAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_RemoveAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).RemoveAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/RemoveAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).RemoveAddress(ctx, req.(*RemoveAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ChangePhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePhoneNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ChangePhoneNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/ChangePhoneNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ChangePhoneNumber(ctx, req.(*ChangePhoneNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ConfirmPhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPhoneNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ConfirmPhoneNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/ConfirmPhoneNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ConfirmPhoneNumber(ctx, req.(*ConfirmPhoneNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_Suspend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).Suspend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/Suspend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).Suspend(ctx, req.(*SuspendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_Reactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).Reactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/Reactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).Reactivate(ctx, req.(*ReactivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Customer_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -678,6 +2671,42 @@ func _Customer_Delete_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ErasePersonalData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ErasePersonalDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ErasePersonalData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/ErasePersonalData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ErasePersonalData(ctx, req.(*ErasePersonalDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Customer_RetrieveView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RetrieveViewRequest)
 	if err := dec(in); err != nil {
@@ -696,6 +2725,117 @@ func _Customer_RetrieveView_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_RetrieveViewByEmailAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveViewByEmailAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).RetrieveViewByEmailAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/RetrieveViewByEmailAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).RetrieveViewByEmailAddress(ctx, req.(*RetrieveViewByEmailAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_RetrieveEventHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveEventHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).RetrieveEventHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/RetrieveEventHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).RetrieveEventHistory(ctx, req.(*RetrieveEventHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ListCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ListCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/ListCustomers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ListCustomers(ctx, req.(*ListCustomersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_SearchCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCustomersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).SearchCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/customergrpcproto.Customer/SearchCustomers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).SearchCustomers(ctx, req.(*SearchCustomersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_WatchCustomer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCustomerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CustomerServer).WatchCustomer(m, &customerWatchCustomerServer{stream})
+}
+
+type Customer_WatchCustomerServer interface {
+	Send(*HistoricEvent) error
+	grpc.ServerStream
+}
+
+type customerWatchCustomerServer struct {
+	grpc.ServerStream
+}
+
+func (x *customerWatchCustomerServer) Send(m *HistoricEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Customer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "customergrpcproto.Customer",
 	HandlerType: (*CustomerServer)(nil),
@@ -708,23 +2848,93 @@ var _Customer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ConfirmEmailAddress",
 			Handler:    _Customer_ConfirmEmailAddress_Handler,
 		},
+		{
+			MethodName: "RequestEmailAddressConfirmationResend",
+			Handler:    _Customer_RequestEmailAddressConfirmationResend_Handler,
+		},
 		{
 			MethodName: "ChangeEmailAddress",
 			Handler:    _Customer_ChangeEmailAddress_Handler,
 		},
+		{
+			MethodName: "CancelEmailAddressChange",
+			Handler:    _Customer_CancelEmailAddressChange_Handler,
+		},
 		{
 			MethodName: "ChangeName",
 			Handler:    _Customer_ChangeName_Handler,
 		},
+		{
+			MethodName: "AddAddress",
+			Handler:    _Customer_AddAddress_Handler,
+		},
+		{
+			MethodName: "ChangeAddress",
+			Handler:    _Customer_ChangeAddress_Handler,
+		},
+		{
+			MethodName: "RemoveAddress",
+			Handler:    _Customer_RemoveAddress_Handler,
+		},
+		{
+			MethodName: "ChangePhoneNumber",
+			Handler:    _Customer_ChangePhoneNumber_Handler,
+		},
+		{
+			MethodName: "ConfirmPhoneNumber",
+			Handler:    _Customer_ConfirmPhoneNumber_Handler,
+		},
+		{
+			MethodName: "Suspend",
+			Handler:    _Customer_Suspend_Handler,
+		},
+		{
+			MethodName: "Reactivate",
+			Handler:    _Customer_Reactivate_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _Customer_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _Customer_Restore_Handler,
+		},
+		{
+			MethodName: "ErasePersonalData",
+			Handler:    _Customer_ErasePersonalData_Handler,
+		},
 		{
 			MethodName: "RetrieveView",
 			Handler:    _Customer_RetrieveView_Handler,
 		},
+		{
+			MethodName: "RetrieveViewByEmailAddress",
+			Handler:    _Customer_RetrieveViewByEmailAddress_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Customer_Export_Handler,
+		},
+		{
+			MethodName: "RetrieveEventHistory",
+			Handler:    _Customer_RetrieveEventHistory_Handler,
+		},
+		{
+			MethodName: "ListCustomers",
+			Handler:    _Customer_ListCustomers_Handler,
+		},
+		{
+			MethodName: "SearchCustomers",
+			Handler:    _Customer_SearchCustomers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCustomer",
+			Handler:       _Customer_WatchCustomer_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "customer.proto",
 }
@@ -0,0 +1,401 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+// CustomerEventStore is a thread-safe in-memory implementation of the ports the postgres.CustomerEventStore
+// implements, so that the service and tests can run without a database. It behaves the same way regarding
+// concurrency conflicts, unique email addresses, idempotency keys and not found streams.
+// All changes of one call are atomic.
+type CustomerEventStore struct {
+	mutex                             sync.RWMutex
+	eventStreams                      map[string]es.EventStream
+	uniqueEmailAddresses              map[string]string
+	idempotencyKeys                   map[string]idempotencyKeyRecord
+	buildUniqueEmailAddressAssertions customer.ForBuildingUniqueEmailAddressAssertions
+	idempotencyKeyTTL                 time.Duration
+}
+
+type idempotencyKeyRecord struct {
+	commandName string
+	customerID  string
+	expiresAt   time.Time
+}
+
+func NewCustomerEventStore(
+	buildUniqueEmailAddressAssertions customer.ForBuildingUniqueEmailAddressAssertions,
+	idempotencyKeyTTL time.Duration,
+) *CustomerEventStore {
+
+	return &CustomerEventStore{
+		eventStreams:                      make(map[string]es.EventStream),
+		uniqueEmailAddresses:              make(map[string]string),
+		idempotencyKeys:                   make(map[string]idempotencyKeyRecord),
+		buildUniqueEmailAddressAssertions: buildUniqueEmailAddressAssertions,
+		idempotencyKeyTTL:                 idempotencyKeyTTL,
+	}
+}
+
+func (s *CustomerEventStore) RetrieveEventStream(_ context.Context, id value.CustomerID) (es.EventStream, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	eventStream, found := s.eventStreams[id.String()]
+	if !found {
+		err := errors.New("customer not found")
+		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, "customerEventStore.RetrieveEventStream")
+	}
+
+	return append(es.EventStream{}, eventStream...), nil
+}
+
+// RetrieveFullEventStream is the same as RetrieveEventStream, there are no snapshots in memory.
+func (s *CustomerEventStore) RetrieveFullEventStream(ctx context.Context, id value.CustomerID) (es.EventStream, error) {
+	return s.RetrieveEventStream(ctx, id)
+}
+
+func (s *CustomerEventStore) RetrieveEventStreamRange(
+	ctx context.Context,
+	id value.CustomerID,
+	fromVersion uint,
+	maxEvents uint,
+) (es.EventStream, error) {
+
+	eventStream, err := s.RetrieveEventStream(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "customerEventStore.RetrieveEventStreamRange")
+	}
+
+	eventStreamRange := es.EventStream{}
+
+	for _, event := range eventStream {
+		if uint(len(eventStreamRange)) == maxEvents {
+			break
+		}
+
+		if event.Meta().StreamVersion() >= fromVersion {
+			eventStreamRange = append(eventStreamRange, event)
+		}
+	}
+
+	return eventStreamRange, nil
+}
+
+func (s *CustomerEventStore) StartEventStream(ctx context.Context, customerRegistered domain.CustomerRegistered) error {
+	wrapWithMsg := "customerEventStore.StartEventStream"
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	recordedEvents := es.RecordedEvents{customerRegistered}
+
+	if err := s.assertIdempotencyKeyIsFree(ctx); err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	uniqueEmailAddresses, err := s.assertUniqueEmailAddresses(recordedEvents)
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if _, found := s.eventStreams[customerRegistered.CustomerID().String()]; found {
+		return shared.MarkAndWrapError(errors.New("found duplicate customer"), shared.ErrDuplicate, wrapWithMsg)
+	}
+
+	s.eventStreams[customerRegistered.CustomerID().String()] = es.EventStream{customerRegistered}
+	s.uniqueEmailAddresses = uniqueEmailAddresses
+	s.recordIdempotencyKeyFrom(ctx, customerRegistered.CustomerID())
+
+	return nil
+}
+
+func (s *CustomerEventStore) AppendToEventStream(
+	ctx context.Context,
+	recordedEvents es.RecordedEvents,
+	id value.CustomerID,
+) error {
+
+	wrapWithMsg := "customerEventStore.AppendToEventStream"
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.assertIdempotencyKeyIsFree(ctx); err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	uniqueEmailAddresses, err := s.assertUniqueEmailAddresses(recordedEvents)
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	eventStream := s.eventStreams[id.String()]
+	currentStreamVersion := uint(0)
+
+	if len(eventStream) > 0 {
+		currentStreamVersion = eventStream[len(eventStream)-1].Meta().StreamVersion()
+	}
+
+	for _, event := range recordedEvents {
+		if event.Meta().StreamVersion() <= currentStreamVersion {
+			err := errors.Newf("stream version [%d] already exists", event.Meta().StreamVersion())
+			return shared.MarkAndWrapError(err, shared.ErrConcurrencyConflict, wrapWithMsg)
+		}
+
+		currentStreamVersion = event.Meta().StreamVersion()
+	}
+
+	s.eventStreams[id.String()] = append(append(es.EventStream{}, eventStream...), recordedEvents...)
+	s.uniqueEmailAddresses = uniqueEmailAddresses
+	s.recordIdempotencyKeyFrom(ctx, id)
+
+	return nil
+}
+
+func (s *CustomerEventStore) PurgeEventStream(_ context.Context, id value.CustomerID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.eventStreams, id.String())
+
+	for emailAddress, customerID := range s.uniqueEmailAddresses {
+		if customerID == id.String() {
+			delete(s.uniqueEmailAddresses, emailAddress)
+		}
+	}
+
+	for key, record := range s.idempotencyKeys {
+		if record.customerID == id.String() {
+			delete(s.idempotencyKeys, key)
+		}
+	}
+
+	return nil
+}
+
+func (s *CustomerEventStore) RetrieveCustomerIDForIdempotencyKey(
+	_ context.Context,
+	idempotencyKey es.IdempotencyKey,
+) (value.CustomerID, error) {
+
+	wrapWithMsg := "customerEventStore.RetrieveCustomerIDForIdempotencyKey"
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	record, found := s.idempotencyKeys[idempotencyKey.Key()]
+	if !found || !record.expiresAt.After(time.Now()) {
+		return "", shared.MarkAndWrapError(errors.New("idempotency key not found"), shared.ErrNotFound, wrapWithMsg)
+	}
+
+	if record.commandName != idempotencyKey.CommandName() {
+		err := errors.Newf("idempotency key [%s] was already used for command [%s]", idempotencyKey.Key(), record.commandName)
+		return "", shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	return value.RebuildCustomerID(record.customerID), nil
+}
+
+func (s *CustomerEventStore) RetrieveCustomerIDsDeletedBefore(
+	_ context.Context,
+	deletedBefore time.Time,
+) ([]value.CustomerID, error) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var customerIDs []value.CustomerID
+
+	for customerID, eventStream := range s.eventStreams {
+		if deletedAt, isDeleted := deletedAtOf(eventStream); isDeleted && deletedAt.Before(deletedBefore) {
+			customerIDs = append(customerIDs, value.RebuildCustomerID(customerID))
+		}
+	}
+
+	return customerIDs, nil
+}
+
+func (s *CustomerEventStore) RetrieveUniqueEmailAddresses(_ context.Context, id value.CustomerID) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	emailAddresses := make([]string, 0)
+
+	for emailAddress, customerID := range s.uniqueEmailAddresses {
+		if customerID == id.String() {
+			emailAddresses = append(emailAddresses, emailAddress)
+		}
+	}
+
+	sort.Strings(emailAddresses)
+
+	return emailAddresses, nil
+}
+
+// RetrieveCustomerIDForEmailAddress also finds the Customer if the email address is only reserved for a pending change.
+func (s *CustomerEventStore) RetrieveCustomerIDForEmailAddress(
+	_ context.Context,
+	emailAddress value.EmailAddress,
+) (value.CustomerID, error) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	customerID, found := s.uniqueEmailAddresses[emailAddress.Canonical()]
+	if !found {
+		err := errors.New("email address not found")
+		return "", shared.MarkAndWrapError(err, shared.ErrNotFound, "customerEventStore.RetrieveCustomerIDForEmailAddress")
+	}
+
+	return value.RebuildCustomerID(customerID), nil
+}
+
+// RetrieveView builds the View directly from the EventStream, there is no need for a projection in memory.
+func (s *CustomerEventStore) RetrieveView(ctx context.Context, id value.CustomerID) (customer.View, error) {
+	eventStream, err := s.RetrieveEventStream(ctx, id)
+	if err != nil {
+		return customer.View{}, errors.Wrap(err, "customerEventStore.RetrieveView")
+	}
+
+	return customer.BuildViewFrom(eventStream), nil
+}
+
+// SearchViews builds the Views of all Customers directly from their EventStreams, same as RetrieveView.
+func (s *CustomerEventStore) SearchViews(_ context.Context, search customer.ViewSearch) ([]customer.View, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var customerViews []customer.View
+
+	for _, eventStream := range s.eventStreams {
+		customerView := customer.BuildViewFrom(eventStream)
+
+		if search.Matches(customerView) && search.IsAfterCursor(customerView) {
+			customerViews = append(customerViews, customerView)
+		}
+	}
+
+	sort.Slice(customerViews, func(i, j int) bool {
+		return search.SortsBefore(customerViews[i], customerViews[j])
+	})
+
+	if len(customerViews) > search.PageSize+1 {
+		customerViews = customerViews[:search.PageSize+1]
+	}
+
+	return customerViews, nil
+}
+
+func (s *CustomerEventStore) assertIdempotencyKeyIsFree(ctx context.Context) error {
+	idempotencyKey, ok := es.IdempotencyKeyFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	if record, found := s.idempotencyKeys[idempotencyKey.Key()]; found && record.expiresAt.After(time.Now()) {
+		err := errors.Newf("idempotency key [%s] was recorded meanwhile", idempotencyKey.Key())
+		return errors.Mark(err, shared.ErrConcurrencyConflict)
+	}
+
+	return nil
+}
+
+// recordIdempotencyKeyFrom also drops expired keys, there is no background worker in memory.
+func (s *CustomerEventStore) recordIdempotencyKeyFrom(ctx context.Context, id value.CustomerID) {
+	idempotencyKey, ok := es.IdempotencyKeyFrom(ctx)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+
+	for key, record := range s.idempotencyKeys {
+		if !record.expiresAt.After(now) {
+			delete(s.idempotencyKeys, key)
+		}
+	}
+
+	s.idempotencyKeys[idempotencyKey.Key()] = idempotencyKeyRecord{
+		commandName: idempotencyKey.CommandName(),
+		customerID:  id.String(),
+		expiresAt:   now.Add(s.idempotencyKeyTTL),
+	}
+}
+
+// assertUniqueEmailAddresses works on a copy, which only replaces the original once all changes were successful.
+func (s *CustomerEventStore) assertUniqueEmailAddresses(recordedEvents es.RecordedEvents) (map[string]string, error) {
+	uniqueEmailAddresses := make(map[string]string, len(s.uniqueEmailAddresses))
+
+	for emailAddress, customerID := range s.uniqueEmailAddresses {
+		uniqueEmailAddresses[emailAddress] = customerID
+	}
+
+	for _, assertion := range s.buildUniqueEmailAddressAssertions(recordedEvents...) {
+		customerID := assertion.CustomerID().String()
+
+		switch assertion.DesiredAction() {
+		case customer.ShouldAddUniqueEmailAddress:
+			if _, found := uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()]; found {
+				return nil, errors.Mark(errors.New("duplicate email address"), shared.ErrDuplicate)
+			}
+
+			uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()] = customerID
+		case customer.ShouldReplaceUniqueEmailAddress:
+			if owner, found := uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()]; found && owner != customerID {
+				return nil, errors.Mark(errors.New("duplicate email address"), shared.ErrDuplicate)
+			}
+
+			removeEmailAddressesOf(customerID, uniqueEmailAddresses)
+			uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()] = customerID
+		case customer.ShouldRemoveUniqueEmailAddress:
+			removeEmailAddressesOf(customerID, uniqueEmailAddresses)
+		case customer.ShouldReserveUniqueEmailAddress:
+			if owner, found := uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()]; found && owner != customerID {
+				return nil, errors.Mark(errors.New("duplicate email address"), shared.ErrDuplicate)
+			}
+
+			uniqueEmailAddresses[assertion.EmailAddressToAdd().Canonical()] = customerID
+		case customer.ShouldReleaseUniqueEmailAddress:
+			if owner := uniqueEmailAddresses[assertion.EmailAddressToRelease().Canonical()]; owner == customerID {
+				delete(uniqueEmailAddresses, assertion.EmailAddressToRelease().Canonical())
+			}
+		}
+	}
+
+	return uniqueEmailAddresses, nil
+}
+
+func removeEmailAddressesOf(customerID string, uniqueEmailAddresses map[string]string) {
+	for emailAddress, owner := range uniqueEmailAddresses {
+		if owner == customerID {
+			delete(uniqueEmailAddresses, emailAddress)
+		}
+	}
+}
+
+// deletedAtOf finds the latest CustomerDeleted event, unless the Customer was restored afterwards.
+func deletedAtOf(eventStream es.EventStream) (time.Time, bool) {
+	for i := len(eventStream) - 1; i >= 0; i-- {
+		switch event := eventStream[i].(type) {
+		case domain.CustomerRestored:
+			return time.Time{}, false
+		case domain.CustomerDeleted:
+			deletedAt, err := time.Parse(time.RFC3339Nano, event.Meta().OccurredAt())
+
+			return deletedAt, err == nil
+		}
+	}
+
+	return time.Time{}, false
+}
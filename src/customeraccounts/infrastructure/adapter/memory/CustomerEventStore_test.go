@@ -0,0 +1,376 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/memory"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCustomerEventStore(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+		store := memory.NewCustomerEventStore(customer.BuildUniqueEmailAddressAssertions, time.Hour)
+
+		customerID := value.GenerateCustomerID()
+		otherCustomerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		otherEmailAddress, err := value.BuildUnconfirmedEmailAddress("veronica@fisher.com")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+
+		customerRegistered := domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1)
+
+		Convey("When an EventStream is started", func() {
+			err = store.StartEventStream(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be retrievable", func() {
+				eventStream, err := store.RetrieveEventStream(ctx, customerID)
+				So(err, ShouldBeNil)
+				So(eventStream, ShouldResemble, es.EventStream{customerRegistered})
+
+				view, err := store.RetrieveView(ctx, customerID)
+				So(err, ShouldBeNil)
+				So(view.EmailAddress, ShouldEqual, emailAddress.String())
+			})
+
+			Convey("Then it should be found by its email address in different case", func() {
+				otherCaseEmailAddress, err := value.BuildUnconfirmedEmailAddress("Kevin@Ball.com")
+				So(err, ShouldBeNil)
+
+				foundCustomerID, err := store.RetrieveCustomerIDForEmailAddress(ctx, otherCaseEmailAddress)
+				So(err, ShouldBeNil)
+				So(foundCustomerID.Equals(customerID), ShouldBeTrue)
+
+				_, err = store.RetrieveCustomerIDForEmailAddress(ctx, otherEmailAddress)
+				So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+			})
+
+			Convey("Then a range of it should be retrievable", func() {
+				eventStream, err := store.RetrieveEventStreamRange(ctx, customerID, 1, 1)
+				So(err, ShouldBeNil)
+				So(eventStream, ShouldResemble, es.EventStream{customerRegistered})
+
+				eventStream, err = store.RetrieveEventStreamRange(ctx, customerID, 2, 1)
+				So(err, ShouldBeNil)
+				So(eventStream, ShouldBeEmpty)
+
+				_, err = store.RetrieveEventStreamRange(ctx, otherCustomerID, 1, 1)
+				So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+			})
+
+			Convey("and when another Customer registers", func() {
+				otherPersonName, err := value.BuildPersonName("Veronica", "Fisher")
+				So(err, ShouldBeNil)
+
+				err = store.StartEventStream(
+					ctx,
+					domain.BuildCustomerRegistered(otherCustomerID, otherEmailAddress, otherPersonName, es.GenerateMessageID(), 1),
+				)
+
+				So(err, ShouldBeNil)
+
+				Convey("Then both should be found by a search sorted by family name, one more than the page size", func() {
+					search, err := customer.BuildViewSearch(
+						customer.ViewSearchCriteria{SortBy: customer.SortByFamilyName, PageSize: 1},
+					)
+
+					So(err, ShouldBeNil)
+
+					views, err := store.SearchViews(ctx, search)
+					So(err, ShouldBeNil)
+					So(views, ShouldHaveLength, 2)
+					So(views[0].ID, ShouldEqual, customerID.String())
+					So(views[1].ID, ShouldEqual, otherCustomerID.String())
+				})
+
+				Convey("Then only the matching Customer should be found by a search with a filter", func() {
+					search, err := customer.BuildViewSearch(customer.ViewSearchCriteria{NameFragment: "fish"})
+					So(err, ShouldBeNil)
+
+					views, err := store.SearchViews(ctx, search)
+					So(err, ShouldBeNil)
+					So(views, ShouldHaveLength, 1)
+					So(views[0].ID, ShouldEqual, otherCustomerID.String())
+				})
+			})
+
+			Convey("and when the same EventStream is started again", func() {
+				err = store.StartEventStream(ctx, customerRegistered)
+
+				Convey("Then it should fail", func() {
+					So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when another Customer registers with the same email address", func() {
+				err = store.StartEventStream(
+					ctx,
+					domain.BuildCustomerRegistered(otherCustomerID, emailAddress, personName, es.GenerateMessageID(), 1),
+				)
+
+				Convey("Then it should fail", func() {
+					So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+
+					_, err = store.RetrieveEventStream(ctx, otherCustomerID)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when another Customer registers with the same email address in different case", func() {
+				differentCaseEmailAddress, err := value.BuildUnconfirmedEmailAddress("Kevin@Ball.com")
+				So(err, ShouldBeNil)
+
+				err = store.StartEventStream(
+					ctx,
+					domain.BuildCustomerRegistered(otherCustomerID, differentCaseEmailAddress, personName, es.GenerateMessageID(), 1),
+				)
+
+				Convey("Then it should fail", func() {
+					So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when an event with an already existing stream version is appended", func() {
+				err = store.AppendToEventStream(
+					ctx,
+					es.RecordedEvents{domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 1)},
+					customerID,
+				)
+
+				Convey("Then it should fail with a concurrency conflict", func() {
+					So(errors.Is(err, shared.ErrConcurrencyConflict), ShouldBeTrue)
+
+					eventStream, err := store.RetrieveEventStream(ctx, customerID)
+					So(err, ShouldBeNil)
+					So(eventStream, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("and when the email address is changed", func() {
+				err = store.AppendToEventStream(
+					ctx,
+					es.RecordedEvents{domain.BuildCustomerEmailAddressChanged(customerID, otherEmailAddress, es.GenerateMessageID(), 2)},
+					customerID,
+				)
+				So(err, ShouldBeNil)
+
+				Convey("Then another Customer should be able to register with the previous email address", func() {
+					err = store.StartEventStream(
+						ctx,
+						domain.BuildCustomerRegistered(otherCustomerID, emailAddress, personName, es.GenerateMessageID(), 1),
+					)
+					So(err, ShouldBeNil)
+				})
+			})
+
+			Convey("and when a change of the confirmed email address is requested", func() {
+				confirmedEmailAddress, err := value.ConfirmEmailAddressWithHash(emailAddress, emailAddress.ConfirmationHash(), 0)
+				So(err, ShouldBeNil)
+
+				err = store.AppendToEventStream(
+					ctx,
+					es.RecordedEvents{
+						domain.BuildCustomerEmailAddressConfirmed(customerID, confirmedEmailAddress, es.GenerateMessageID(), 2),
+						domain.BuildCustomerEmailAddressChangeRequested(customerID, otherEmailAddress, es.GenerateMessageID(), 3),
+					},
+					customerID,
+				)
+				So(err, ShouldBeNil)
+
+				Convey("Then both email addresses should be taken", func() {
+					err = store.StartEventStream(
+						ctx,
+						domain.BuildCustomerRegistered(otherCustomerID, otherEmailAddress, personName, es.GenerateMessageID(), 1),
+					)
+					So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+
+					err = store.StartEventStream(
+						ctx,
+						domain.BuildCustomerRegistered(otherCustomerID, emailAddress, personName, es.GenerateMessageID(), 1),
+					)
+					So(errors.Is(err, shared.ErrDuplicate), ShouldBeTrue)
+				})
+
+				Convey("Then both email addresses should be retrievable as unique email addresses of the Customer", func() {
+					uniqueEmailAddresses, err := store.RetrieveUniqueEmailAddresses(ctx, customerID)
+					So(err, ShouldBeNil)
+					So(uniqueEmailAddresses, ShouldResemble, []string{emailAddress.Canonical(), otherEmailAddress.Canonical()})
+
+					uniqueEmailAddresses, err = store.RetrieveUniqueEmailAddresses(ctx, otherCustomerID)
+					So(err, ShouldBeNil)
+					So(uniqueEmailAddresses, ShouldBeEmpty)
+				})
+
+				Convey("and when the change is cancelled", func() {
+					err = store.AppendToEventStream(
+						ctx,
+						es.RecordedEvents{
+							domain.BuildCustomerEmailAddressChangeCancelled(customerID, otherEmailAddress, es.GenerateMessageID(), 4),
+						},
+						customerID,
+					)
+					So(err, ShouldBeNil)
+
+					Convey("Then another Customer should be able to register with the pending email address", func() {
+						err = store.StartEventStream(
+							ctx,
+							domain.BuildCustomerRegistered(otherCustomerID, otherEmailAddress, personName, es.GenerateMessageID(), 1),
+						)
+						So(err, ShouldBeNil)
+					})
+				})
+
+				Convey("and when the change is confirmed", func() {
+					confirmedOtherEmailAddress, err := value.ConfirmEmailAddressWithHash(otherEmailAddress, otherEmailAddress.ConfirmationHash(), 0)
+					So(err, ShouldBeNil)
+
+					err = store.AppendToEventStream(
+						ctx,
+						es.RecordedEvents{
+							domain.BuildCustomerEmailAddressChangeConfirmed(customerID, confirmedOtherEmailAddress, es.GenerateMessageID(), 4),
+						},
+						customerID,
+					)
+					So(err, ShouldBeNil)
+
+					Convey("Then another Customer should be able to register with the previous email address", func() {
+						err = store.StartEventStream(
+							ctx,
+							domain.BuildCustomerRegistered(otherCustomerID, emailAddress, personName, es.GenerateMessageID(), 1),
+						)
+						So(err, ShouldBeNil)
+					})
+				})
+			})
+
+			Convey("and when the Customer is deleted", func() {
+				err = store.AppendToEventStream(
+					ctx,
+					es.RecordedEvents{domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 2)},
+					customerID,
+				)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should only be retrieved as deleted before a later time", func() {
+					customerIDs, err := store.RetrieveCustomerIDsDeletedBefore(ctx, time.Now().Add(-time.Hour))
+					So(err, ShouldBeNil)
+					So(customerIDs, ShouldBeEmpty)
+
+					customerIDs, err = store.RetrieveCustomerIDsDeletedBefore(ctx, time.Now().Add(time.Hour))
+					So(err, ShouldBeNil)
+					So(customerIDs, ShouldResemble, []value.CustomerID{customerID})
+				})
+
+				Convey("and when the personal data of the Customer is erased", func() {
+					err = store.AppendToEventStream(
+						ctx,
+						es.RecordedEvents{domain.BuildCustomerPersonalDataErased(customerID, es.GenerateMessageID(), 3)},
+						customerID,
+					)
+					So(err, ShouldBeNil)
+
+					Convey("Then it should still be retrieved as deleted", func() {
+						customerIDs, err := store.RetrieveCustomerIDsDeletedBefore(ctx, time.Now().Add(time.Hour))
+						So(err, ShouldBeNil)
+						So(customerIDs, ShouldResemble, []value.CustomerID{customerID})
+					})
+				})
+
+				Convey("and when the Customer is restored", func() {
+					err = store.AppendToEventStream(
+						ctx,
+						es.RecordedEvents{domain.BuildCustomerRestored(customerID, emailAddress, es.GenerateMessageID(), 3)},
+						customerID,
+					)
+					So(err, ShouldBeNil)
+
+					Convey("Then it should not be retrieved as deleted anymore", func() {
+						customerIDs, err := store.RetrieveCustomerIDsDeletedBefore(ctx, time.Now().Add(time.Hour))
+						So(err, ShouldBeNil)
+						So(customerIDs, ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("and when the EventStream is purged", func() {
+				err = store.PurgeEventStream(ctx, customerID)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should not be found", func() {
+					_, err = store.RetrieveEventStream(ctx, customerID)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+
+					_, err = store.RetrieveView(ctx, customerID)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+
+				Convey("and its email address should be free again", func() {
+					err = store.StartEventStream(
+						ctx,
+						domain.BuildCustomerRegistered(otherCustomerID, emailAddress, personName, es.GenerateMessageID(), 1),
+					)
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When an EventStream is started with an idempotency key", func() {
+			idempotencyKey := es.BuildIdempotencyKey("some-key", "Register")
+			err = store.StartEventStream(es.ContextWithIdempotencyKey(ctx, idempotencyKey), customerRegistered)
+			So(err, ShouldBeNil)
+
+			Convey("Then the Customer should be retrievable by the idempotency key", func() {
+				id, err := store.RetrieveCustomerIDForIdempotencyKey(ctx, idempotencyKey)
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, customerID)
+			})
+
+			Convey("Then the idempotency key should not match another command", func() {
+				_, err := store.RetrieveCustomerIDForIdempotencyKey(ctx, es.BuildIdempotencyKey("some-key", "Delete"))
+				So(errors.Is(err, shared.ErrInputIsInvalid), ShouldBeTrue)
+			})
+
+			Convey("and when events are appended with the same idempotency key", func() {
+				err = store.AppendToEventStream(
+					es.ContextWithIdempotencyKey(ctx, idempotencyKey),
+					es.RecordedEvents{domain.BuildCustomerDeleted(customerID, es.GenerateMessageID(), 2)},
+					customerID,
+				)
+
+				Convey("Then it should fail with a concurrency conflict", func() {
+					So(errors.Is(err, shared.ErrConcurrencyConflict), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when the EventStream is purged", func() {
+				err = store.PurgeEventStream(ctx, customerID)
+				So(err, ShouldBeNil)
+
+				Convey("Then the idempotency key should not be found", func() {
+					_, err = store.RetrieveCustomerIDForIdempotencyKey(ctx, idempotencyKey)
+					So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When a missing EventStream is retrieved", func() {
+			_, err = store.RetrieveEventStream(ctx, customerID)
+
+			Convey("Then it should fail", func() {
+				So(errors.Is(err, shared.ErrNotFound), ShouldBeTrue)
+			})
+		})
+	})
+}
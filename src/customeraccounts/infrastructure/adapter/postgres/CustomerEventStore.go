@@ -1,10 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
@@ -13,48 +17,133 @@ import (
 
 const streamPrefix = "customer"
 
-type forRetrievingEventStreams func(streamID es.StreamID, fromVersion uint, maxEvents uint, db *sql.DB) (es.EventStream, error)
-type forAppendingEventsToStreams func(streamID es.StreamID, events []es.DomainEvent, tx *sql.Tx) error
-type forPurgingEventStreams func(streamID es.StreamID, tx *sql.Tx) error
-type forAssertingUniqueEmailAddresses func(recordedEvents []es.DomainEvent, tx *sql.Tx) error
-type forPurgingUniqueEmailAddresses func(customerID value.CustomerID, tx *sql.Tx) error
+type forRetrievingEventStreams func(ctx context.Context, streamID es.StreamID, fromVersion uint, maxEvents uint, db *sql.DB) (es.EventStream, error)
+type forAppendingEventsToStreams func(ctx context.Context, streamID es.StreamID, events []es.DomainEvent, tx *sql.Tx) error
+type forAddingEventsToOutbox func(ctx context.Context, streamID es.StreamID, events []es.DomainEvent, tx *sql.Tx) error
+type forPurgingEventStreams func(ctx context.Context, streamID es.StreamID, tx *sql.Tx) error
+type forPurgingOutboxMessages func(ctx context.Context, streamID es.StreamID, tx *sql.Tx) error
+type forAssertingUniqueEmailAddresses func(ctx context.Context, recordedEvents []es.DomainEvent, tx *sql.Tx) error
+type forPurgingUniqueEmailAddresses func(ctx context.Context, customerID value.CustomerID, tx *sql.Tx) error
+type forRetrievingUniqueEmailAddresses func(ctx context.Context, customerID value.CustomerID, db *sql.DB) ([]string, error)
+type forRetrievingCustomerIDsForEmailAddresses func(ctx context.Context, emailAddress value.EmailAddress, db *sql.DB) (value.CustomerID, error)
+type forPurgingCustomerViews func(ctx context.Context, id value.CustomerID, tx *sql.Tx) error
+type forRetrievingSnapshots func(ctx context.Context, streamID es.StreamID, db *sql.DB) (es.DomainEvent, error)
+type forSavingSnapshots func(ctx context.Context, streamID es.StreamID, snapshot es.DomainEvent, db *sql.DB) error
+type forPurgingSnapshots func(ctx context.Context, streamID es.StreamID, tx *sql.Tx) error
+type forRecordingIdempotencyKeys func(ctx context.Context, idempotencyKey es.IdempotencyKey, streamID es.StreamID, tx *sql.Tx) error
+type forRetrievingStreamIDsForIdempotencyKeys func(ctx context.Context, idempotencyKey es.IdempotencyKey, db *sql.DB) (es.StreamID, error)
+type forPurgingIdempotencyKeys func(ctx context.Context, streamID es.StreamID, tx *sql.Tx) error
+type forRetrievingStreamIDsWithEventNotFollowedBy func(
+	ctx context.Context,
+	eventName string,
+	revokingEventName string,
+	occurredBefore time.Time,
+	db *sql.DB,
+) ([]es.StreamID, error)
 
 type CustomerEventStore struct {
 	db                       *sql.DB
 	retrieveEventStream      forRetrievingEventStreams
 	appendEventsToStream     forAppendingEventsToStreams
+	addEventsToOutbox        forAddingEventsToOutbox
 	purgeEventStream         forPurgingEventStreams
+	purgeOutboxMessages      forPurgingOutboxMessages
 	assertUniqueEmailAddress forAssertingUniqueEmailAddresses
 	purgeUniqueEmailAddress  forPurgingUniqueEmailAddresses
+	retrieveUniqueEmailAddr  forRetrievingUniqueEmailAddresses
+	retrieveIDForEmailAddr   forRetrievingCustomerIDsForEmailAddresses
+	purgeCustomerView        forPurgingCustomerViews
+	retrieveSnapshot         forRetrievingSnapshots
+	saveSnapshot             forSavingSnapshots
+	purgeSnapshot            forPurgingSnapshots
+	recordIdempotencyKey     forRecordingIdempotencyKeys
+	retrieveStreamIDForKey   forRetrievingStreamIDsForIdempotencyKeys
+	purgeIdempotencyKeys     forPurgingIdempotencyKeys
+	retrieveStreamIDsWith    forRetrievingStreamIDsWithEventNotFollowedBy
+	buildSnapshot            customer.ForBuildingSnapshots
+	snapshotInterval         uint
+	logger                   *shared.Logger
 }
 
 func NewCustomerEventStore(
 	db *sql.DB,
 	retrieveEventStream forRetrievingEventStreams,
 	appendEventsToStream forAppendingEventsToStreams,
+	addEventsToOutbox forAddingEventsToOutbox,
 	purgeEventStream forPurgingEventStreams,
+	purgeOutboxMessages forPurgingOutboxMessages,
 	assertUniqueEmailAddress forAssertingUniqueEmailAddresses,
 	purgeUniqueEmailAddress forPurgingUniqueEmailAddresses,
+	retrieveUniqueEmailAddr forRetrievingUniqueEmailAddresses,
+	retrieveIDForEmailAddr forRetrievingCustomerIDsForEmailAddresses,
+	purgeCustomerView forPurgingCustomerViews,
+	retrieveSnapshot forRetrievingSnapshots,
+	saveSnapshot forSavingSnapshots,
+	purgeSnapshot forPurgingSnapshots,
+	recordIdempotencyKey forRecordingIdempotencyKeys,
+	retrieveStreamIDForKey forRetrievingStreamIDsForIdempotencyKeys,
+	purgeIdempotencyKeys forPurgingIdempotencyKeys,
+	retrieveStreamIDsWith forRetrievingStreamIDsWithEventNotFollowedBy,
+	buildSnapshot customer.ForBuildingSnapshots,
+	snapshotInterval uint,
+	logger *shared.Logger,
 ) *CustomerEventStore {
 
 	return &CustomerEventStore{
 		db:                       db,
 		retrieveEventStream:      retrieveEventStream,
 		appendEventsToStream:     appendEventsToStream,
+		addEventsToOutbox:        addEventsToOutbox,
 		purgeEventStream:         purgeEventStream,
+		purgeOutboxMessages:      purgeOutboxMessages,
 		assertUniqueEmailAddress: assertUniqueEmailAddress,
 		purgeUniqueEmailAddress:  purgeUniqueEmailAddress,
+		retrieveUniqueEmailAddr:  retrieveUniqueEmailAddr,
+		retrieveIDForEmailAddr:   retrieveIDForEmailAddr,
+		purgeCustomerView:        purgeCustomerView,
+		retrieveSnapshot:         retrieveSnapshot,
+		saveSnapshot:             saveSnapshot,
+		purgeSnapshot:            purgeSnapshot,
+		recordIdempotencyKey:     recordIdempotencyKey,
+		retrieveStreamIDForKey:   retrieveStreamIDForKey,
+		purgeIdempotencyKeys:     purgeIdempotencyKeys,
+		retrieveStreamIDsWith:    retrieveStreamIDsWith,
+		buildSnapshot:            buildSnapshot,
+		snapshotInterval:         snapshotInterval,
+		logger:                   logger,
 	}
 }
 
-func (s *CustomerEventStore) RetrieveEventStream(id value.CustomerID) (es.EventStream, error) {
+// RetrieveEventStream starts with the snapshot of the Customer, if there is one.
+// A snapshot which can't be retrieved is ignored and the whole stream is replayed instead,
+// the broken snapshot is then replaced when saveSnapshotIfDue saves the next one.
+func (s *CustomerEventStore) RetrieveEventStream(ctx context.Context, id value.CustomerID) (es.EventStream, error) {
 	wrapWithMsg := "customerEventStore.RetrieveEventStream"
+	ctx = withCachedPersonalDataKeys(ctx)
+
+	streamID := s.streamID(id)
+
+	snapshot, err := s.retrieveSnapshot(ctx, streamID, s.db)
+	if err != nil {
+		s.logger.Warn().Msgf("ignoring the snapshot of stream [%s]: %s", streamID.String(), err)
+		snapshot = nil
+	}
+
+	var eventStream es.EventStream
+	fromVersion := uint(0)
+
+	if snapshot != nil {
+		eventStream = es.EventStream{snapshot}
+		fromVersion = snapshot.Meta().StreamVersion() + 1
+	}
 
-	eventStream, err := s.retrieveEventStream(s.streamID(id), 0, math.MaxUint32, s.db)
+	events, err := s.retrieveEventStream(ctx, streamID, fromVersion, math.MaxUint32, s.db)
 	if err != nil {
 		return nil, errors.Wrap(err, wrapWithMsg)
 	}
 
+	eventStream = append(eventStream, events...)
+
 	if len(eventStream) == 0 {
 		err := errors.New("customer not found")
 		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, wrapWithMsg)
@@ -63,26 +152,84 @@ func (s *CustomerEventStore) RetrieveEventStream(id value.CustomerID) (es.EventS
 	return eventStream, nil
 }
 
-func (s *CustomerEventStore) StartEventStream(customerRegistered domain.CustomerRegistered) error {
+// RetrieveFullEventStream ignores snapshots, so it is slower than RetrieveEventStream but contains the whole history.
+func (s *CustomerEventStore) RetrieveFullEventStream(ctx context.Context, id value.CustomerID) (es.EventStream, error) {
+	wrapWithMsg := "customerEventStore.RetrieveFullEventStream"
+	ctx = withCachedPersonalDataKeys(ctx)
+
+	eventStream, err := s.retrieveEventStream(ctx, s.streamID(id), 0, math.MaxUint32, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, wrapWithMsg)
+	}
+
+	if len(eventStream) == 0 {
+		err := errors.New("customer not found")
+		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, wrapWithMsg)
+	}
+
+	return eventStream, nil
+}
+
+func (s *CustomerEventStore) RetrieveEventStreamRange(
+	ctx context.Context,
+	id value.CustomerID,
+	fromVersion uint,
+	maxEvents uint,
+) (es.EventStream, error) {
+
+	wrapWithMsg := "customerEventStore.RetrieveEventStreamRange"
+	ctx = withCachedPersonalDataKeys(ctx)
+	streamID := s.streamID(id)
+
+	eventStream, err := s.retrieveEventStream(ctx, streamID, fromVersion, maxEvents, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, wrapWithMsg)
+	}
+
+	if len(eventStream) > 0 {
+		return eventStream, nil
+	}
+
+	// an empty range is only fine if the stream exists at all
+	firstEvent, err := s.retrieveEventStream(ctx, streamID, 0, 1, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, wrapWithMsg)
+	}
+
+	if len(firstEvent) == 0 {
+		err := errors.New("customer not found")
+		return nil, shared.MarkAndWrapError(err, shared.ErrNotFound, wrapWithMsg)
+	}
+
+	return es.EventStream{}, nil
+}
+
+func (s *CustomerEventStore) StartEventStream(ctx context.Context, customerRegistered domain.CustomerRegistered) error {
 	var err error
 	wrapWithMsg := "customerEventStore.StartEventStream"
+	ctx = withCachedPersonalDataKeys(ctx)
 
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
 
 	recordedEvents := []es.DomainEvent{customerRegistered}
+	streamID := s.streamID(customerRegistered.CustomerID())
 
-	if err = s.assertUniqueEmailAddress(recordedEvents, tx); err != nil {
+	if err = s.recordIdempotencyKeyFrom(ctx, streamID, tx); err != nil {
 		_ = tx.Rollback()
 
 		return errors.Wrap(err, wrapWithMsg)
 	}
 
-	streamID := s.streamID(customerRegistered.CustomerID())
+	if err = s.assertUniqueEmailAddress(ctx, recordedEvents, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
 
-	if err = s.appendEventsToStream(streamID, recordedEvents, tx); err != nil {
+	if err = s.appendEventsToStream(ctx, streamID, recordedEvents, tx); err != nil {
 		_ = tx.Rollback()
 
 		if errors.Is(err, shared.ErrConcurrencyConflict) {
@@ -92,29 +239,50 @@ func (s *CustomerEventStore) StartEventStream(customerRegistered domain.Customer
 		return errors.Wrap(err, wrapWithMsg)
 	}
 
+	if err = s.addEventsToOutbox(ctx, streamID, recordedEvents, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
 	if err = tx.Commit(); err != nil {
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
 
+	s.saveSnapshotIfDue(ctx, recordedEvents, customerRegistered.CustomerID())
+
 	return nil
 }
 
-func (s *CustomerEventStore) AppendToEventStream(recordedEvents es.RecordedEvents, id value.CustomerID) error {
+func (s *CustomerEventStore) AppendToEventStream(ctx context.Context, recordedEvents es.RecordedEvents, id value.CustomerID) error {
 	var err error
 	wrapWithMsg := "customerEventStore.AppendToEventStream"
+	ctx = withCachedPersonalDataKeys(ctx)
 
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
 
-	if err = s.assertUniqueEmailAddress(recordedEvents, tx); err != nil {
+	if err = s.recordIdempotencyKeyFrom(ctx, s.streamID(id), tx); err != nil {
 		_ = tx.Rollback()
 
 		return errors.Wrap(err, wrapWithMsg)
 	}
 
-	if err = s.appendEventsToStream(s.streamID(id), recordedEvents, tx); err != nil {
+	if err = s.assertUniqueEmailAddress(ctx, recordedEvents, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.appendEventsToStream(ctx, s.streamID(id), recordedEvents, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.addEventsToOutbox(ctx, s.streamID(id), recordedEvents, tx); err != nil {
 		_ = tx.Rollback()
 
 		return errors.Wrap(err, wrapWithMsg)
@@ -124,25 +292,51 @@ func (s *CustomerEventStore) AppendToEventStream(recordedEvents es.RecordedEvent
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
 
+	s.saveSnapshotIfDue(ctx, recordedEvents, id)
+
 	return nil
 }
 
-func (s *CustomerEventStore) PurgeEventStream(id value.CustomerID) error {
+func (s *CustomerEventStore) PurgeEventStream(ctx context.Context, id value.CustomerID) error {
 	var err error
 	wrapWithMsg := "customerEventStore.PurgeEventStream"
 
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
 
-	if err = s.purgeUniqueEmailAddress(id, tx); err != nil {
+	if err = s.purgeUniqueEmailAddress(ctx, id, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.purgeCustomerView(ctx, id, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.purgeSnapshot(ctx, s.streamID(id), tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.purgeIdempotencyKeys(ctx, s.streamID(id), tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = s.purgeOutboxMessages(ctx, s.streamID(id), tx); err != nil {
 		_ = tx.Rollback()
 
 		return errors.Wrap(err, wrapWithMsg)
 	}
 
-	if err = s.purgeEventStream(s.streamID(id), tx); err != nil {
+	if err = s.purgeEventStream(ctx, s.streamID(id), tx); err != nil {
 		_ = tx.Rollback()
 
 		return errors.Wrap(err, wrapWithMsg)
@@ -155,6 +349,98 @@ func (s *CustomerEventStore) PurgeEventStream(id value.CustomerID) error {
 	return nil
 }
 
+func (s *CustomerEventStore) RetrieveCustomerIDForIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey es.IdempotencyKey,
+) (value.CustomerID, error) {
+
+	streamID, err := s.retrieveStreamIDForKey(ctx, idempotencyKey, s.db)
+	if err != nil {
+		return "", errors.Wrap(err, "customerEventStore.RetrieveCustomerIDForIdempotencyKey")
+	}
+
+	return value.RebuildCustomerID(strings.TrimPrefix(streamID.String(), streamPrefix+"-")), nil
+}
+
+// RetrieveCustomerIDsDeletedBefore also finds Customers whose personal data was erased after they were deleted.
+func (s *CustomerEventStore) RetrieveCustomerIDsDeletedBefore(
+	ctx context.Context,
+	deletedBefore time.Time,
+) ([]value.CustomerID, error) {
+
+	streamIDs, err := s.retrieveStreamIDsWith(ctx, "CustomerDeleted", "CustomerRestored", deletedBefore, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "customerEventStore.RetrieveCustomerIDsDeletedBefore")
+	}
+
+	customerIDs := make([]value.CustomerID, 0, len(streamIDs))
+
+	for _, streamID := range streamIDs {
+		customerIDs = append(customerIDs, value.RebuildCustomerID(strings.TrimPrefix(streamID.String(), streamPrefix+"-")))
+	}
+
+	return customerIDs, nil
+}
+
+func (s *CustomerEventStore) RetrieveUniqueEmailAddresses(ctx context.Context, id value.CustomerID) ([]string, error) {
+	emailAddresses, err := s.retrieveUniqueEmailAddr(ctx, id, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "customerEventStore.RetrieveUniqueEmailAddresses")
+	}
+
+	return emailAddresses, nil
+}
+
+func (s *CustomerEventStore) RetrieveCustomerIDForEmailAddress(
+	ctx context.Context,
+	emailAddress value.EmailAddress,
+) (value.CustomerID, error) {
+
+	customerID, err := s.retrieveIDForEmailAddr(ctx, emailAddress, s.db)
+	if err != nil {
+		return "", errors.Wrap(err, "customerEventStore.RetrieveCustomerIDForEmailAddress")
+	}
+
+	return customerID, nil
+}
+
+// recordIdempotencyKeyFrom records the IdempotencyKey of the command, if the ctx contains one.
+func (s *CustomerEventStore) recordIdempotencyKeyFrom(ctx context.Context, streamID es.StreamID, tx *sql.Tx) error {
+	idempotencyKey, ok := es.IdempotencyKeyFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	return s.recordIdempotencyKey(ctx, idempotencyKey, streamID, tx)
+}
+
+// saveSnapshotIfDue saves a snapshot if one of the recordedEvents reached the snapshotInterval (0 disables snapshots).
+// Snapshots are just an optimization, so failing to save one must not fail the command after the events were committed.
+// This also means that no snapshot is saved if the ctx is done meanwhile, it will be saved with the next interval.
+func (s *CustomerEventStore) saveSnapshotIfDue(
+	ctx context.Context,
+	recordedEvents es.RecordedEvents,
+	id value.CustomerID,
+) {
+
+	if s.snapshotInterval == 0 {
+		return
+	}
+
+	for _, event := range recordedEvents {
+		if event.Meta().StreamVersion()%s.snapshotInterval == 0 {
+			eventStream, err := s.RetrieveEventStream(ctx, id)
+			if err != nil {
+				return
+			}
+
+			_ = s.saveSnapshot(ctx, s.streamID(id), s.buildSnapshot(eventStream), s.db)
+
+			return
+		}
+	}
+}
+
 func (s *CustomerEventStore) streamID(id value.CustomerID) es.StreamID {
 	return es.BuildStreamID(streamPrefix + "-" + id.String())
 }
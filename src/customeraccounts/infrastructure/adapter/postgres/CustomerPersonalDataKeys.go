@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+const personalDataKeySize = 32 // AES-256
+
+// CustomerPersonalDataKeys stores the keys the personal data of each Customer is encrypted with.
+// Deleting a key keeps a tombstone, so that no new key is created for a Customer whose personal data was erased.
+type CustomerPersonalDataKeys struct {
+	db            *sql.DB
+	keysTableName string
+}
+
+func NewCustomerPersonalDataKeys(db *sql.DB, keysTableName string) *CustomerPersonalDataKeys {
+	return &CustomerPersonalDataKeys{
+		db:            db,
+		keysTableName: keysTableName,
+	}
+}
+
+// RetrieveOrCreateKey fails with ErrNotFound if the key of the Customer was deleted.
+func (s *CustomerPersonalDataKeys) RetrieveOrCreateKey(ctx context.Context, customerID string) ([]byte, error) {
+	wrapWithMsg := "customerPersonalDataKeys.RetrieveOrCreateKey"
+
+	if cached, ok := cachedPersonalDataKeyFrom(ctx, customerID); ok && cached.hasKey() {
+		return cached.unwrap(wrapWithMsg)
+	}
+
+	key := make([]byte, personalDataKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	queryTemplate := `INSERT INTO %name% (customer_id, key, created_at) VALUES ($1, $2, now())
+						ON CONFLICT (customer_id) DO NOTHING`
+
+	query := strings.Replace(queryTemplate, "%name%", s.keysTableName, 1)
+
+	if _, err := s.db.ExecContext(ctx, query, customerID, key); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	retrieved, err := s.retrieveKey(ctx, customerID)
+	if err != nil {
+		return nil, errors.Wrap(err, wrapWithMsg)
+	}
+
+	cachePersonalDataKey(ctx, customerID, retrieved)
+
+	return retrieved.unwrap(wrapWithMsg)
+}
+
+// RetrieveKey fails with ErrNotFound if the key of the Customer was deleted.
+// It returns no key if the Customer never got one, e.g. because all her events were stored before keys existed.
+func (s *CustomerPersonalDataKeys) RetrieveKey(ctx context.Context, customerID string) ([]byte, error) {
+	wrapWithMsg := "customerPersonalDataKeys.RetrieveKey"
+
+	if cached, ok := cachedPersonalDataKeyFrom(ctx, customerID); ok {
+		return cached.unwrap(wrapWithMsg)
+	}
+
+	retrieved, err := s.retrieveKey(ctx, customerID)
+	if err != nil {
+		return nil, errors.Wrap(err, wrapWithMsg)
+	}
+
+	cachePersonalDataKey(ctx, customerID, retrieved)
+
+	return retrieved.unwrap(wrapWithMsg)
+}
+
+func (s *CustomerPersonalDataKeys) retrieveKey(ctx context.Context, customerID string) (personalDataKey, error) {
+	queryTemplate := `SELECT key FROM %name% WHERE customer_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", s.keysTableName, 1)
+
+	var key []byte
+
+	if err := s.db.QueryRowContext(ctx, query, customerID).Scan(&key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return personalDataKey{}, nil
+		}
+
+		return personalDataKey{}, errors.Mark(err, shared.ErrTechnical)
+	}
+
+	return personalDataKey{key: key, isStored: true}, nil
+}
+
+func (s *CustomerPersonalDataKeys) DeleteKey(ctx context.Context, id value.CustomerID) error {
+	queryTemplate := `INSERT INTO %name% (customer_id, key, created_at, erased_at) VALUES ($1, NULL, now(), now())
+						ON CONFLICT (customer_id) DO UPDATE SET key = NULL, erased_at = coalesce(%name%.erased_at, now())`
+
+	query := strings.Replace(queryTemplate, "%name%", s.keysTableName, 1)
+
+	if _, err := s.db.ExecContext(ctx, query, id.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "customerPersonalDataKeys.DeleteKey")
+	}
+
+	return nil
+}
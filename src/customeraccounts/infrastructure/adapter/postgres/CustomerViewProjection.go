@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+const viewColumns = `id, email_address, is_email_address_confirmed, pending_email_address, given_name, family_name,
+						is_deleted, status, addresses, phone_number, is_phone_number_confirmed, registered_at, version`
+
+type forRetrievingCustomerEventStreams func(ctx context.Context, id value.CustomerID) (es.EventStream, error)
+
+// CustomerViewProjection keeps the customer_views table up to date. ProjectEvent is meant to be driven by an
+// es.Subscription to the global event log. Instead of applying each event to the row, the View is rebuilt from
+// the (snapshotted) EventStream, so projecting is idempotent and the projection logic is the same as in the domain.
+type CustomerViewProjection struct {
+	db                          *sql.DB
+	viewsTableName              string
+	retrieveCustomerEventStream forRetrievingCustomerEventStreams
+}
+
+func NewCustomerViewProjection(
+	db *sql.DB,
+	viewsTableName string,
+	retrieveCustomerEventStream forRetrievingCustomerEventStreams,
+) *CustomerViewProjection {
+
+	return &CustomerViewProjection{
+		db:                          db,
+		viewsTableName:              viewsTableName,
+		retrieveCustomerEventStream: retrieveCustomerEventStream,
+	}
+}
+
+func (p *CustomerViewProjection) ProjectEvent(ctx context.Context, globalEvent es.GlobalEvent) error {
+	wrapWithMsg := "customerViewProjection.ProjectEvent"
+
+	if !strings.HasPrefix(globalEvent.StreamID().String(), streamPrefix+"-") {
+		return nil // not a Customer event
+	}
+
+	customerID := value.RebuildCustomerID(strings.TrimPrefix(globalEvent.StreamID().String(), streamPrefix+"-"))
+
+	eventStream, err := p.retrieveCustomerEventStream(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return p.deleteView(ctx, customerID) // the EventStream was purged meanwhile
+		}
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = p.saveView(ctx, customer.BuildViewFrom(eventStream)); err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	return nil
+}
+
+func (p *CustomerViewProjection) RetrieveView(ctx context.Context, id value.CustomerID) (customer.View, error) {
+	wrapWithMsg := "customerViewProjection.RetrieveView"
+
+	queryTemplate := `SELECT ` + viewColumns + ` FROM %name% WHERE id = $1`
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	view, err := scanView(p.db.QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return customer.View{}, shared.MarkAndWrapError(errors.New("customer not found"), shared.ErrNotFound, wrapWithMsg)
+		}
+
+		return customer.View{}, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return view, nil
+}
+
+// SearchViews uses keyset pagination: it continues after the (sort value, id) of the cursor, so that the pages
+// stay stable while Customers register. Strings are compared bytewise (COLLATE "C"), same as in customer.ViewSearch.
+func (p *CustomerViewProjection) SearchViews(ctx context.Context, search customer.ViewSearch) ([]customer.View, error) {
+	wrapWithMsg := "customerViewProjection.SearchViews"
+
+	sortColumn := map[string]string{
+		customer.SortByRegisteredAt: "registered_at",
+		customer.SortByEmailAddress: `email_address COLLATE "C"`,
+		customer.SortByFamilyName:   `family_name COLLATE "C"`,
+	}[search.SortBy]
+
+	comparison, direction := ">", "ASC"
+	if search.SortDescending {
+		comparison, direction = "<", "DESC"
+	}
+
+	conditions := []string{"NOT is_deleted"}
+	var args []interface{}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "$?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if search.EmailAddress != "" {
+		addCondition("canonical_email_address = $?", value.RebuildConfirmedEmailAddress(search.EmailAddress).Canonical())
+	}
+
+	if search.NameFragment != "" {
+		addCondition(`(given_name || ' ' || family_name) ILIKE $? ESCAPE '\'`, "%"+escapeLikePattern(search.NameFragment)+"%")
+	}
+
+	if search.IsEmailConfirmed != nil {
+		addCondition("is_email_address_confirmed = $?", *search.IsEmailConfirmed)
+	}
+
+	if !search.RegisteredFrom.IsZero() {
+		addCondition("registered_at >= $?", search.RegisteredFrom)
+	}
+
+	if !search.RegisteredBefore.IsZero() {
+		addCondition("registered_at < $?", search.RegisteredBefore)
+	}
+
+	if search.After != nil {
+		var sortValue interface{} = search.After.SortValue
+
+		if search.SortBy == customer.SortByRegisteredAt {
+			registeredAt, err := customer.ParseSortableTime(search.After.SortValue)
+			if err != nil {
+				return nil, shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+			}
+
+			sortValue = registeredAt
+		}
+
+		args = append(args, sortValue, search.After.ID)
+		conditions = append(
+			conditions,
+			fmt.Sprintf(`(%s, id COLLATE "C") %s ($%d, $%d)`, sortColumn, comparison, len(args)-1, len(args)),
+		)
+	}
+
+	args = append(args, search.PageSize+1)
+
+	queryTemplate := `SELECT ` + viewColumns + ` FROM %name%
+						WHERE ` + strings.Join(conditions, " AND ") + `
+						ORDER BY ` + sortColumn + ` ` + direction + `, id COLLATE "C" ` + direction + `
+						LIMIT $` + strconv.Itoa(len(args))
+
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	defer rows.Close()
+
+	var views []customer.View
+
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, wrapWithMsg)
+		}
+
+		views = append(views, view)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return views, nil
+}
+
+func (p *CustomerViewProjection) PurgeView(ctx context.Context, id value.CustomerID, tx *sql.Tx) error {
+	queryTemplate := `DELETE FROM %name% WHERE id = $1`
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, query, id.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "customerViewProjection.PurgeView")
+	}
+
+	return nil
+}
+
+// DeleteAllViews is needed to rebuild the projection from scratch, together with resetting its subscription.
+func (p *CustomerViewProjection) DeleteAllViews(ctx context.Context) error {
+	queryTemplate := `DELETE FROM %name%`
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	if _, err := p.db.ExecContext(ctx, query); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "customerViewProjection.DeleteAllViews")
+	}
+
+	return nil
+}
+
+// CanonicalizeEmailAddresses fills in the canonical email addresses which the database can't compute itself,
+// because the domain must be converted to punycode.
+func (p *CustomerViewProjection) CanonicalizeEmailAddresses(ctx context.Context) error {
+	wrapWithMsg := "customerViewProjection.CanonicalizeEmailAddresses"
+
+	queryTemplate := `SELECT id, email_address FROM %name% WHERE canonical_email_address = '' AND email_address <> ''`
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	defer rows.Close()
+
+	var views []customer.View
+
+	for rows.Next() {
+		var view customer.View
+
+		if err = rows.Scan(&view.ID, &view.EmailAddress); err != nil {
+			return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+		}
+
+		views = append(views, view)
+	}
+
+	if err = rows.Err(); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	rows.Close()
+
+	// the email address must still be the same, otherwise the projection already saved a newer canonical form
+	updateTemplate := `UPDATE %name% SET canonical_email_address = $3 WHERE id = $1 AND email_address = $2`
+	update := strings.Replace(updateTemplate, "%name%", p.viewsTableName, 1)
+
+	for _, view := range views {
+		if _, err = p.db.ExecContext(ctx, update, view.ID, view.EmailAddress, view.CanonicalEmailAddress()); err != nil {
+			return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+		}
+	}
+
+	return nil
+}
+
+func (p *CustomerViewProjection) saveView(ctx context.Context, view customer.View) error {
+	queryTemplate := `INSERT INTO %name%
+						(id, email_address, is_email_address_confirmed, pending_email_address, given_name, family_name,
+						 is_deleted, status, addresses, phone_number, is_phone_number_confirmed, registered_at, version,
+						 canonical_email_address, projected_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, now())
+						ON CONFLICT (id) DO UPDATE
+							SET email_address = EXCLUDED.email_address,
+								is_email_address_confirmed = EXCLUDED.is_email_address_confirmed,
+								pending_email_address = EXCLUDED.pending_email_address,
+								given_name = EXCLUDED.given_name,
+								family_name = EXCLUDED.family_name,
+								is_deleted = EXCLUDED.is_deleted,
+								status = EXCLUDED.status,
+								addresses = EXCLUDED.addresses,
+								phone_number = EXCLUDED.phone_number,
+								is_phone_number_confirmed = EXCLUDED.is_phone_number_confirmed,
+								registered_at = EXCLUDED.registered_at,
+								version = EXCLUDED.version,
+								canonical_email_address = EXCLUDED.canonical_email_address,
+								projected_at = EXCLUDED.projected_at
+							WHERE %name%.version < EXCLUDED.version`
+
+	query := strings.ReplaceAll(queryTemplate, "%name%", p.viewsTableName)
+
+	addresses, err := json.Marshal(view.Addresses)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, "saveView")
+	}
+
+	if view.Addresses == nil {
+		addresses = []byte("[]")
+	}
+
+	_, err = p.db.ExecContext(
+		ctx,
+		query,
+		view.ID,
+		view.EmailAddress,
+		view.IsEmailAddressConfirmed,
+		view.PendingEmailAddress,
+		view.GivenName,
+		view.FamilyName,
+		view.IsDeleted,
+		view.Status,
+		addresses,
+		view.PhoneNumber,
+		view.IsPhoneNumberConfirmed,
+		view.RegisteredAt,
+		view.Version,
+		view.CanonicalEmailAddress(),
+	)
+
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "saveView")
+	}
+
+	return nil
+}
+
+func (p *CustomerViewProjection) deleteView(ctx context.Context, id value.CustomerID) error {
+	queryTemplate := `DELETE FROM %name% WHERE id = $1`
+	query := strings.Replace(queryTemplate, "%name%", p.viewsTableName, 1)
+
+	if _, err := p.db.ExecContext(ctx, query, id.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "deleteView")
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanView expects the viewColumns, it passes sql.ErrNoRows through unwrapped.
+func scanView(row rowScanner) (customer.View, error) {
+	view := customer.View{}
+	var addresses []byte
+
+	err := row.Scan(
+		&view.ID,
+		&view.EmailAddress,
+		&view.IsEmailAddressConfirmed,
+		&view.PendingEmailAddress,
+		&view.GivenName,
+		&view.FamilyName,
+		&view.IsDeleted,
+		&view.Status,
+		&addresses,
+		&view.PhoneNumber,
+		&view.IsPhoneNumberConfirmed,
+		&view.RegisteredAt,
+		&view.Version,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return customer.View{}, err
+		}
+
+		return customer.View{}, shared.MarkAndWrapError(err, shared.ErrTechnical, "scanView")
+	}
+
+	if err = json.Unmarshal(addresses, &view.Addresses); err != nil {
+		return customer.View{}, shared.MarkAndWrapError(err, shared.ErrUnmarshalingFailed, "scanView")
+	}
+
+	if len(view.Addresses) == 0 {
+		view.Addresses = nil // same as in customer.BuildViewFrom()
+	}
+
+	view.RegisteredAt = view.RegisteredAt.UTC()
+
+	return view, nil
+}
+
+func escapeLikePattern(input string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(input)
+}
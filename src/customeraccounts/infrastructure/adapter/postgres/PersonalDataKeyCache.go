@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// personalDataKey is what is stored for a Customer: no row at all, a key, or a row whose key was deleted.
+type personalDataKey struct {
+	key      []byte
+	isStored bool
+}
+
+func (k personalDataKey) hasKey() bool {
+	return k.key != nil
+}
+
+func (k personalDataKey) unwrap(wrapWithMsg string) ([]byte, error) {
+	if k.isStored && k.key == nil {
+		return nil, shared.MarkAndWrapError(errors.New("personal data key was deleted"), shared.ErrNotFound, wrapWithMsg)
+	}
+
+	return k.key, nil
+}
+
+type personalDataKeyCacheKey struct{}
+
+type personalDataKeyCache struct {
+	mutex sync.Mutex
+	keys  map[string]personalDataKey
+}
+
+// withCachedPersonalDataKeys makes all key retrievals with the returned ctx share their results,
+// so that marshaling or unmarshaling all events of a stream retrieves the Customer's key only once.
+// It must only wrap a single operation on a stream, otherwise a key that was deleted meanwhile could still be used.
+func withCachedPersonalDataKeys(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(personalDataKeyCacheKey{}).(*personalDataKeyCache); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, personalDataKeyCacheKey{}, &personalDataKeyCache{keys: make(map[string]personalDataKey)})
+}
+
+func cachedPersonalDataKeyFrom(ctx context.Context, customerID string) (personalDataKey, bool) {
+	cache, ok := ctx.Value(personalDataKeyCacheKey{}).(*personalDataKeyCache)
+	if !ok {
+		return personalDataKey{}, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	key, ok := cache.keys[customerID]
+
+	return key, ok
+}
+
+func cachePersonalDataKey(ctx context.Context, customerID string, key personalDataKey) {
+	cache, ok := ctx.Value(personalDataKeyCacheKey{}).(*personalDataKeyCache)
+	if !ok {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.keys[customerID] = key
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 
@@ -29,7 +30,12 @@ func NewUniqueCustomerEmailAddresses(
 	}
 }
 
-func (s *UniqueCustomerEmailAddresses) AssertUniqueEmailAddress(recordedEvents []es.DomainEvent, tx *sql.Tx) error {
+func (s *UniqueCustomerEmailAddresses) AssertUniqueEmailAddress(
+	ctx context.Context,
+	recordedEvents []es.DomainEvent,
+	tx *sql.Tx,
+) error {
+
 	wrapWithMsg := "assertUniqueEmailAddresse"
 
 	assertions := s.buildUniqueEmailAddressAssertions(recordedEvents...)
@@ -37,15 +43,23 @@ func (s *UniqueCustomerEmailAddresses) AssertUniqueEmailAddress(recordedEvents [
 	for _, assertion := range assertions {
 		switch assertion.DesiredAction() {
 		case customer.ShouldAddUniqueEmailAddress:
-			if err := s.tryToAdd(assertion.EmailAddressToAdd(), assertion.CustomerID(), tx); err != nil {
+			if err := s.tryToAdd(ctx, assertion.EmailAddressToAdd(), assertion.CustomerID(), tx); err != nil {
 				return errors.Wrap(err, wrapWithMsg)
 			}
 		case customer.ShouldReplaceUniqueEmailAddress:
-			if err := s.tryToReplace(assertion.EmailAddressToAdd(), assertion.CustomerID(), tx); err != nil {
+			if err := s.tryToReplace(ctx, assertion.EmailAddressToAdd(), assertion.CustomerID(), tx); err != nil {
 				return errors.Wrap(err, wrapWithMsg)
 			}
 		case customer.ShouldRemoveUniqueEmailAddress:
-			if err := s.remove(assertion.CustomerID(), tx); err != nil {
+			if err := s.remove(ctx, assertion.CustomerID(), tx); err != nil {
+				return errors.Wrap(err, wrapWithMsg)
+			}
+		case customer.ShouldReserveUniqueEmailAddress:
+			if err := s.tryToReserve(ctx, assertion.EmailAddressToAdd(), assertion.CustomerID(), tx); err != nil {
+				return errors.Wrap(err, wrapWithMsg)
+			}
+		case customer.ShouldReleaseUniqueEmailAddress:
+			if err := s.release(ctx, assertion.EmailAddressToRelease(), assertion.CustomerID(), tx); err != nil {
 				return errors.Wrap(err, wrapWithMsg)
 			}
 		}
@@ -54,12 +68,278 @@ func (s *UniqueCustomerEmailAddresses) AssertUniqueEmailAddress(recordedEvents [
 	return nil
 }
 
-func (s *UniqueCustomerEmailAddresses) PurgeUniqueEmailAddress(customerID value.CustomerID, tx *sql.Tx) error {
-	return s.remove(customerID, tx)
+func (s *UniqueCustomerEmailAddresses) PurgeUniqueEmailAddress(
+	ctx context.Context,
+	customerID value.CustomerID,
+	tx *sql.Tx,
+) error {
+
+	return s.remove(ctx, customerID, tx)
+}
+
+func (s *UniqueCustomerEmailAddresses) RetrieveUniqueEmailAddresses(
+	ctx context.Context,
+	customerID value.CustomerID,
+	db *sql.DB,
+) ([]string, error) {
+
+	queryTemplate := `SELECT email_address FROM %tablename% WHERE customer_id = $1 ORDER BY email_address`
+	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	rows, err := db.QueryContext(ctx, query, customerID.String())
+	if err != nil {
+		return nil, s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	defer rows.Close()
+
+	emailAddresses := make([]string, 0)
+
+	for rows.Next() {
+		var emailAddress string
+
+		if err := rows.Scan(&emailAddress); err != nil {
+			return nil, s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		emailAddresses = append(emailAddresses, emailAddress)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	return emailAddresses, nil
+}
+
+// RetrieveCustomerIDForEmailAddress also finds the Customer if the email address is only reserved for a pending change.
+func (s *UniqueCustomerEmailAddresses) RetrieveCustomerIDForEmailAddress(
+	ctx context.Context,
+	emailAddress value.EmailAddress,
+	db *sql.DB,
+) (value.CustomerID, error) {
+
+	queryTemplate := `SELECT customer_id FROM %tablename% WHERE email_address = $1`
+	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	var customerID string
+
+	if err := db.QueryRowContext(ctx, query, emailAddress.Canonical()).Scan(&customerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.Mark(errors.New("email address not found"), shared.ErrNotFound)
+		}
+
+		return "", s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	return value.RebuildCustomerID(customerID), nil
+}
+
+// CanonicalizeEmailAddresses converts the email addresses which were stored before their canonical form existed.
+// If several Customers end up with the same canonical address, the Customer who registered first keeps it,
+// the others are listed in the unique_email_address_collisions table, so that they can be contacted.
+func (s *UniqueCustomerEmailAddresses) CanonicalizeEmailAddresses(
+	ctx context.Context,
+	eventStoreTableName string,
+	db *sql.DB,
+) error {
+
+	wrapWithMsg := "canonicalizeEmailAddresses"
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if err = s.canonicalizeEmailAddresses(ctx, eventStoreTableName, tx); err != nil {
+		_ = tx.Rollback()
+
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
+
+type storedEmailAddress struct {
+	emailAddress string
+	customerID   string
+}
+
+func (s *UniqueCustomerEmailAddresses) canonicalizeEmailAddresses(
+	ctx context.Context,
+	eventStoreTableName string,
+	tx *sql.Tx,
+) error {
+
+	lockTemplate := `LOCK TABLE %tablename% IN SHARE ROW EXCLUSIVE MODE`
+	lock := strings.Replace(lockTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, lock); err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	// only uppercase, untrimmed or non-ASCII addresses can differ from their canonical form
+	queryTemplate := `SELECT email_address, customer_id FROM %tablename%
+						WHERE email_address <> lower(btrim(email_address))
+							OR octet_length(email_address) <> char_length(email_address)
+						ORDER BY email_address`
+	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	defer rows.Close()
+
+	var canonicalEmailAddresses []string
+	storedByCanonical := make(map[string][]storedEmailAddress)
+
+	for rows.Next() {
+		var stored storedEmailAddress
+
+		if err = rows.Scan(&stored.emailAddress, &stored.customerID); err != nil {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		canonical := value.RebuildConfirmedEmailAddress(stored.emailAddress).Canonical()
+		if canonical == stored.emailAddress {
+			continue
+		}
+
+		if _, ok := storedByCanonical[canonical]; !ok {
+			canonicalEmailAddresses = append(canonicalEmailAddresses, canonical)
+		}
+
+		storedByCanonical[canonical] = append(storedByCanonical[canonical], stored)
+	}
+
+	if err = rows.Err(); err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	rows.Close()
+
+	for _, canonical := range canonicalEmailAddresses {
+		if err = s.resolveCanonicalEmailAddress(ctx, canonical, storedByCanonical[canonical], eventStoreTableName, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveCanonicalEmailAddress replaces the stored forms of the canonical email address by the canonical one.
+// A Customer who already has the canonical form keeps it, otherwise the Customer who registered first gets it.
+func (s *UniqueCustomerEmailAddresses) resolveCanonicalEmailAddress(
+	ctx context.Context,
+	canonical string,
+	storedForms []storedEmailAddress,
+	eventStoreTableName string,
+	tx *sql.Tx,
+) error {
+
+	ownerQueryTemplate := `SELECT customer_id FROM %tablename% WHERE email_address = $1`
+	ownerQuery := strings.Replace(ownerQueryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	var owner string
+	hasCanonicalForm := true
+
+	if err := tx.QueryRowContext(ctx, ownerQuery, canonical).Scan(&owner); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		hasCanonicalForm = false
+
+		if owner, err = s.firstRegisteredCustomerOf(ctx, storedForms, eventStoreTableName, tx); err != nil {
+			return err
+		}
+	}
+
+	deleteTemplate := `DELETE FROM %tablename% WHERE email_address = $1`
+	deleteQuery := strings.Replace(deleteTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	deleteCollisionQuery := `DELETE FROM unique_email_address_collisions WHERE email_address = $1 AND customer_id = $2`
+
+	insertCollisionQuery := `INSERT INTO unique_email_address_collisions (canonical_email_address, email_address, customer_id)
+								VALUES ($1, $2, $3)
+								ON CONFLICT DO NOTHING`
+
+	for _, stored := range storedForms {
+		if _, err := tx.ExecContext(ctx, deleteQuery, stored.emailAddress); err != nil {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		if _, err := tx.ExecContext(ctx, deleteCollisionQuery, stored.emailAddress, stored.customerID); err != nil {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		if stored.customerID == owner {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, insertCollisionQuery, canonical, stored.emailAddress, stored.customerID); err != nil {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+	}
+
+	if hasCanonicalForm {
+		if _, err := tx.ExecContext(ctx, deleteCollisionQuery, canonical, owner); err != nil {
+			return s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		return nil
+	}
+
+	insertTemplate := `INSERT INTO %tablename% VALUES ($1, $2)`
+	insertQuery := strings.Replace(insertTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, insertQuery, canonical, owner); err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	return nil
+}
+
+// firstRegisteredCustomerOf treats Customers without an event stream as registered last.
+func (s *UniqueCustomerEmailAddresses) firstRegisteredCustomerOf(
+	ctx context.Context,
+	storedForms []storedEmailAddress,
+	eventStoreTableName string,
+	tx *sql.Tx,
+) (string, error) {
+
+	queryTemplate := `SELECT min(id) FROM %tablename% WHERE stream_id = $1`
+	query := strings.Replace(queryTemplate, "%tablename%", eventStoreTableName, 1)
+
+	first := storedForms[0].customerID
+	var firstEventID sql.NullInt64
+
+	for _, stored := range storedForms {
+		var eventID sql.NullInt64
+
+		streamID := es.BuildStreamID(streamPrefix + "-" + stored.customerID)
+		if err := tx.QueryRowContext(ctx, query, streamID.String()).Scan(&eventID); err != nil {
+			return "", s.mapUniqueEmailAddressPostgresErrors(err)
+		}
+
+		if eventID.Valid && (!firstEventID.Valid || eventID.Int64 < firstEventID.Int64) {
+			first = stored.customerID
+			firstEventID = eventID
+		}
+	}
+
+	return first, nil
 }
 
 func (s *UniqueCustomerEmailAddresses) tryToAdd(
-	emailAddress value.UnconfirmedEmailAddress,
+	ctx context.Context,
+	emailAddress value.EmailAddress,
 	customerID value.CustomerID,
 	tx *sql.Tx,
 ) error {
@@ -67,9 +347,10 @@ func (s *UniqueCustomerEmailAddresses) tryToAdd(
 	queryTemplate := `INSERT INTO %tablename% VALUES ($1, $2)`
 	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
 
-	_, err := tx.Exec(
+	_, err := tx.ExecContext(
+		ctx,
 		query,
-		emailAddress.String(),
+		emailAddress.Canonical(),
 		customerID.String(),
 	)
 
@@ -80,19 +361,82 @@ func (s *UniqueCustomerEmailAddresses) tryToAdd(
 	return nil
 }
 
+// tryToReplace removes all other email addresses of the Customer, including a pending one.
 func (s *UniqueCustomerEmailAddresses) tryToReplace(
-	emailAddress value.UnconfirmedEmailAddress,
+	ctx context.Context,
+	emailAddress value.EmailAddress,
+	customerID value.CustomerID,
+	tx *sql.Tx,
+) error {
+
+	queryTemplate := `DELETE FROM %tablename% WHERE customer_id = $1 AND email_address <> $2`
+	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
+
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		customerID.String(),
+		emailAddress.Canonical(),
+	)
+
+	if err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	return s.tryToReserve(ctx, emailAddress, customerID, tx)
+}
+
+// tryToReserve succeeds if the email address is free or already belongs to the Customer.
+func (s *UniqueCustomerEmailAddresses) tryToReserve(
+	ctx context.Context,
+	emailAddress value.EmailAddress,
+	customerID value.CustomerID,
+	tx *sql.Tx,
+) error {
+
+	queryTemplate := `INSERT INTO %tablename% VALUES ($1, $2)
+						ON CONFLICT (email_address) DO UPDATE SET customer_id = EXCLUDED.customer_id
+						WHERE %tablename%.customer_id = EXCLUDED.customer_id`
+	query := strings.ReplaceAll(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName)
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		emailAddress.Canonical(),
+		customerID.String(),
+	)
+
+	if err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return s.mapUniqueEmailAddressPostgresErrors(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.Mark(errors.New("duplicate email address"), shared.ErrDuplicate)
+	}
+
+	return nil
+}
+
+func (s *UniqueCustomerEmailAddresses) release(
+	ctx context.Context,
+	emailAddress value.EmailAddress,
 	customerID value.CustomerID,
 	tx *sql.Tx,
 ) error {
 
-	queryTemplate := `UPDATE %tablename% set email_address = $1 where customer_id = $2`
+	queryTemplate := `DELETE FROM %tablename% WHERE customer_id = $1 AND email_address = $2`
 	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
 
-	_, err := tx.Exec(
+	_, err := tx.ExecContext(
+		ctx,
 		query,
-		emailAddress.String(),
 		customerID.String(),
+		emailAddress.Canonical(),
 	)
 
 	if err != nil {
@@ -103,6 +447,7 @@ func (s *UniqueCustomerEmailAddresses) tryToReplace(
 }
 
 func (s *UniqueCustomerEmailAddresses) remove(
+	ctx context.Context,
 	customerID value.CustomerID,
 	tx *sql.Tx,
 ) error {
@@ -110,7 +455,8 @@ func (s *UniqueCustomerEmailAddresses) remove(
 	queryTemplate := `DELETE FROM %tablename% where customer_id = $1`
 	query := strings.Replace(queryTemplate, "%tablename%", s.uniqueEmailAddressesTableName, 1)
 
-	_, err := tx.Exec(
+	_, err := tx.ExecContext(
+		ctx,
 		query,
 		customerID.String(),
 	)
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS status varchar(20) not null default 'active';
+
+UPDATE customer_views SET status = 'deleted' WHERE is_deleted;
+
+COMMIT;
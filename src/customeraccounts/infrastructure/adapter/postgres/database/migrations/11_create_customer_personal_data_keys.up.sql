@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS customer_personal_data_keys
+(
+    customer_id varchar(255) not null
+        CONSTRAINT customer_personal_data_keys_pk
+            PRIMARY KEY,
+    key bytea,
+    created_at timestamp with time zone not null,
+    erased_at timestamp with time zone
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS addresses jsonb not null default '[]';
+
+COMMIT;
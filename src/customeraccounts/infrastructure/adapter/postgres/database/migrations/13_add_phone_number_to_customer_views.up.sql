@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS phone_number varchar(16) not null default '',
+    ADD COLUMN IF NOT EXISTS is_phone_number_confirmed boolean not null default false;
+
+COMMIT;
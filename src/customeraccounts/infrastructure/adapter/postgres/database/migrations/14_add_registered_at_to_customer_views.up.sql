@@ -0,0 +1,29 @@
+BEGIN;
+
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS registered_at timestamp with time zone;
+
+UPDATE customer_views SET registered_at = eventstore.occurred_at
+    FROM eventstore
+    WHERE eventstore.stream_id = 'customer-' || customer_views.id
+      AND eventstore.stream_version = 1
+      AND customer_views.registered_at IS NULL;
+
+UPDATE customer_views SET registered_at = projected_at WHERE registered_at IS NULL;
+
+ALTER TABLE customer_views
+    ALTER COLUMN registered_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS customer_views_registered_at_idx
+    on customer_views (registered_at, id COLLATE "C");
+
+CREATE INDEX IF NOT EXISTS customer_views_email_address_idx
+    on customer_views (email_address COLLATE "C", id COLLATE "C");
+
+CREATE INDEX IF NOT EXISTS customer_views_family_name_idx
+    on customer_views (family_name COLLATE "C", id COLLATE "C");
+
+CREATE INDEX IF NOT EXISTS customer_views_lower_email_address_idx
+    on customer_views (lower(email_address));
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS outbox_pending_stream_idx
+    on outbox (stream_id, id)
+    WHERE delivered_at IS NULL AND dead_lettered_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS outbox_delivered_at_idx
+    on outbox (delivered_at)
+    WHERE delivered_at IS NOT NULL;
+
+COMMIT;
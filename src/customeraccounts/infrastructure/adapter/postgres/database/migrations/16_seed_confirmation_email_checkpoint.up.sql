@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Start the confirmation emails at the current end of the global event log, otherwise the first run would send
+-- an email for each email address which was ever registered or changed.
+INSERT INTO subscription_checkpoints (subscriber_id, transaction_id, event_id, updated_at)
+    SELECT 'customer-confirmation-emails', transaction_id, id, now()
+    FROM eventstore
+    ORDER BY transaction_id DESC, id DESC
+    LIMIT 1
+ON CONFLICT (subscriber_id) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- A relay claims the messages it is about to publish, so that no other service instance publishes them meanwhile.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS claimed_until timestamp with time zone;
+
+COMMIT;
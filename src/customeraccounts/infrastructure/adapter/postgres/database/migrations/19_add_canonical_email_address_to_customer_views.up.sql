@@ -0,0 +1,16 @@
+BEGIN;
+
+-- The email address filter matches by the canonical form, the same way as the unique email addresses.
+-- Addresses with an internationalized domain are converted to punycode by the application on startup.
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS canonical_email_address varchar(255) not null default '';
+
+UPDATE customer_views SET canonical_email_address = lower(btrim(email_address))
+    WHERE octet_length(email_address) = char_length(email_address);
+
+DROP INDEX IF EXISTS customer_views_lower_email_address_idx;
+
+CREATE INDEX IF NOT EXISTS customer_views_canonical_email_address_idx
+    on customer_views (canonical_email_address);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS snapshots
+(
+    stream_id varchar(255) not null
+        CONSTRAINT snapshots_pk
+            PRIMARY KEY,
+    stream_version integer not null,
+    format_version integer not null,
+    payload jsonb default '{}'::jsonb not null,
+    created_at timestamp with time zone not null
+);
+
+COMMIT;
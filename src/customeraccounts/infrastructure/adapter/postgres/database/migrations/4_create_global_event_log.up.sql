@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE eventstore
+    ADD COLUMN IF NOT EXISTS transaction_id bigint default txid_current() not null;
+
+CREATE INDEX IF NOT EXISTS global_position_idx
+    on eventstore (transaction_id, id);
+
+CREATE TABLE IF NOT EXISTS subscription_checkpoints
+(
+    subscriber_id varchar(255) not null
+        CONSTRAINT subscription_checkpoints_pk
+            PRIMARY KEY,
+    transaction_id bigint not null,
+    event_id bigint not null,
+    updated_at timestamp with time zone not null
+);
+
+COMMIT;
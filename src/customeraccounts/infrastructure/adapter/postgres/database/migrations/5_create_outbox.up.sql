@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id bigserial not null
+        CONSTRAINT outbox_pk
+            PRIMARY KEY,
+    stream_id varchar(255) not null,
+    stream_version integer not null,
+    event_name varchar(255) not null,
+    payload jsonb default '{}'::jsonb not null,
+    occurred_at timestamp with time zone not null,
+    attempts integer default 0 not null,
+    last_error text,
+    next_attempt_at timestamp with time zone not null,
+    delivered_at timestamp with time zone,
+    dead_lettered_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx
+    on outbox (next_attempt_at, id)
+    WHERE delivered_at IS NULL AND dead_lettered_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS customer_views
+(
+    id varchar(255) not null
+        CONSTRAINT customer_views_pk
+            PRIMARY KEY,
+    email_address varchar(255) not null,
+    is_email_address_confirmed boolean not null,
+    given_name varchar(255) not null,
+    family_name varchar(255) not null,
+    is_deleted boolean not null,
+    version integer not null,
+    projected_at timestamp with time zone not null
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    idempotency_key varchar(255) not null
+        CONSTRAINT idempotency_keys_pk
+            PRIMARY KEY,
+    command_name varchar(255) not null,
+    stream_id varchar(255) not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_stream_id_idx
+    on idempotency_keys (stream_id);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx
+    on idempotency_keys (expires_at);
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+-- Email addresses are unique by their canonical (lowercase) form from now on.
+-- Existing addresses which only differ in case are collected here, so that they can be resolved manually.
+CREATE TABLE IF NOT EXISTS unique_email_address_collisions
+(
+    canonical_email_address VARCHAR(255) NOT NULL,
+    email_address VARCHAR(255) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    CONSTRAINT unique_email_address_collisions_pk
+        PRIMARY KEY (canonical_email_address, customer_id)
+);
+
+INSERT INTO unique_email_address_collisions (canonical_email_address, email_address, customer_id)
+SELECT lower(btrim(email_address)), email_address, customer_id
+FROM unique_email_addresses
+WHERE lower(btrim(email_address)) IN (
+    SELECT lower(btrim(email_address))
+    FROM unique_email_addresses
+    GROUP BY lower(btrim(email_address))
+    HAVING count(*) > 1
+)
+ON CONFLICT DO NOTHING;
+
+-- Colliding addresses are left untouched, all others are converted to their canonical form.
+-- Internationalized domains are converted to punycode and the collisions are resolved by the application on startup.
+UPDATE unique_email_addresses
+SET email_address = lower(btrim(email_address))
+WHERE email_address <> lower(btrim(email_address))
+  AND lower(btrim(email_address)) NOT IN (SELECT canonical_email_address FROM unique_email_address_collisions);
+
+COMMIT;
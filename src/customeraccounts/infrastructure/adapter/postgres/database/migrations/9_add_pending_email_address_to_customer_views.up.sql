@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE customer_views
+    ADD COLUMN IF NOT EXISTS pending_email_address varchar(255) not null default '';
+
+COMMIT;
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	customergrpc "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,7 +28,7 @@ func CustomHTTPError(
 	const fallback = `{"error": "failed to marshal error message"}`
 
 	w.Header().Set("Content-type", marshaler.ContentType())
-	w.WriteHeader(runtime.HTTPStatusFromCode(status.Code(err)))
+	w.WriteHeader(httpStatusFrom(err))
 
 	jErr := json.NewEncoder(w).Encode(
 		errorBody{
@@ -37,3 +40,22 @@ func CustomHTTPError(
 		_, _ = w.Write([]byte(fallback)) // useless to handle an error happening while writing a fallback error
 	}
 }
+
+// httpStatusFrom maps a mismatching expected version (e.g. from If-Match) to 409, all other errors as by default.
+func httpStatusFrom(err error) int {
+	st := status.Convert(err)
+
+	if st.Code() == codes.FailedPrecondition {
+		for _, detail := range st.Details() {
+			if preconditionFailure, ok := detail.(*errdetails.PreconditionFailure); ok {
+				for _, violation := range preconditionFailure.GetViolations() {
+					if violation.GetType() == customergrpc.VersionMismatchViolationType {
+						return http.StatusConflict
+					}
+				}
+			}
+		}
+	}
+
+	return runtime.HTTPStatusFromCode(st.Code())
+}
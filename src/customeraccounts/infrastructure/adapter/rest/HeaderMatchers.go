@@ -0,0 +1,30 @@
+package customerrest
+
+import (
+	"net/textproto"
+
+	customergrpc "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// IncomingHeaderMatcher forwards the If-Match header, so that it can serve as the expected version of commands,
+// and the Idempotency-Key header, so that retries of commands can be recognized.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case "If-Match":
+		return customergrpc.IfMatchMetadataKey, true
+	case "Idempotency-Key":
+		return customergrpc.IdempotencyKeyMetadataKey, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher returns the version of a Customer as ETag header, all other metadata as by default.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == customergrpc.ETagMetadataKey {
+		return "ETag", true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,56 @@
+package sms
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// FileSender is a local stand-in for a text message gateway which appends each message as one line of json to a file.
+type FileSender struct {
+	mutex    sync.Mutex
+	filePath string
+}
+
+func NewFileSender(filePath string) *FileSender {
+	return &FileSender{
+		filePath: filePath,
+	}
+}
+
+func (s *FileSender) SendConfirmationTextMessage(
+	_ context.Context,
+	confirmationTextMessage application.ConfirmationTextMessage,
+) error {
+
+	wrapWithMsg := "fileSender.SendConfirmationTextMessage"
+
+	line, err := jsoniter.ConfigFastest.Marshal(renderConfirmationTextMessage(confirmationTextMessage))
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if err = file.Close(); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package sms
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+)
+
+// InMemorySender is a local stand-in for a text message gateway which keeps all sent messages, e.g. for tests.
+type InMemorySender struct {
+	mutex    sync.RWMutex
+	messages []Message
+}
+
+func NewInMemorySender() *InMemorySender {
+	return &InMemorySender{}
+}
+
+func (s *InMemorySender) SendConfirmationTextMessage(
+	_ context.Context,
+	confirmationTextMessage application.ConfirmationTextMessage,
+) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.messages = append(s.messages, renderConfirmationTextMessage(confirmationTextMessage))
+
+	return nil
+}
+
+func (s *InMemorySender) SentMessages() []Message {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]Message{}, s.messages...)
+}
@@ -0,0 +1,20 @@
+package sms
+
+import (
+	"fmt"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+)
+
+// Message is a rendered text message, independent of how it is delivered.
+type Message struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+func renderConfirmationTextMessage(confirmationTextMessage application.ConfirmationTextMessage) Message {
+	return Message{
+		To:   confirmationTextMessage.PhoneNumber,
+		Body: fmt.Sprintf("Your confirmation code is %s", confirmationTextMessage.ConfirmationCode),
+	}
+}
@@ -0,0 +1,57 @@
+package sms_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/sms"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSenders(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		ctx := context.Background()
+
+		confirmationTextMessage := application.ConfirmationTextMessage{
+			CustomerID:       "64bcf656-da30-4f5a-b0b5-aead60965aa3",
+			PhoneNumber:      "+493012345678",
+			ConfirmationCode: "123456",
+		}
+
+		Convey("When a confirmation text message is sent with the InMemorySender", func() {
+			sender := sms.NewInMemorySender()
+			err := sender.SendConfirmationTextMessage(ctx, confirmationTextMessage)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should contain the confirmation code", func() {
+				messages := sender.SentMessages()
+				So(messages, ShouldHaveLength, 1)
+				So(messages[0].To, ShouldEqual, confirmationTextMessage.PhoneNumber)
+				So(messages[0].Body, ShouldContainSubstring, confirmationTextMessage.ConfirmationCode)
+			})
+		})
+
+		Convey("When confirmation text messages are sent with the FileSender", func() {
+			filePath := filepath.Join(t.TempDir(), "text-messages.jsonl")
+			sender := sms.NewFileSender(filePath)
+
+			err := sender.SendConfirmationTextMessage(ctx, confirmationTextMessage)
+			So(err, ShouldBeNil)
+			err = sender.SendConfirmationTextMessage(ctx, confirmationTextMessage)
+			So(err, ShouldBeNil)
+
+			Convey("Then each message should be appended as one line of json", func() {
+				content, err := os.ReadFile(filePath)
+				So(err, ShouldBeNil)
+
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				So(lines, ShouldHaveLength, 2)
+				So(lines[0], ShouldContainSubstring, `"to":"+493012345678"`)
+			})
+		})
+	})
+}
@@ -3,12 +3,13 @@ package serialization
 import "github.com/AntonStoeckl/go-iddd/src/shared/es"
 
 type CustomerRegisteredForJSON struct {
-	CustomerID       string              `json:"customerID"`
-	EmailAddress     string              `json:"emailAddress"`
-	ConfirmationHash string              `json:"confirmationHash"`
-	PersonGivenName  string              `json:"personGivenName"`
-	PersonFamilyName string              `json:"personFamilyName"`
-	Meta             es.EventMetaForJSON `json:"meta"`
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	PersonGivenName           string              `json:"personGivenName"`
+	PersonFamilyName          string              `json:"personFamilyName"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
 }
 
 type CustomerEmailAddressConfirmedForJSON struct {
@@ -24,11 +25,42 @@ type CustomerEmailAddressConfirmationFailedForJSON struct {
 	Meta             es.EventMetaForJSON `json:"meta"`
 }
 
+type CustomerEmailAddressConfirmationResendRequestedForJSON struct {
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
 type CustomerEmailAddressChangedForJSON struct {
-	CustomerID       string              `json:"customerID"`
-	EmailAddress     string              `json:"emailAddress"`
-	ConfirmationHash string              `json:"confirmationHash"`
-	Meta             es.EventMetaForJSON `json:"meta"`
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerEmailAddressChangeRequestedForJSON struct {
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerEmailAddressChangeCancelledForJSON struct {
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerEmailAddressChangeConfirmedForJSON struct {
+	CustomerID   string              `json:"customerID"`
+	EmailAddress string              `json:"emailAddress"`
+	Meta         es.EventMetaForJSON `json:"meta"`
 }
 
 type CustomerNameChangedForJSON struct {
@@ -38,7 +70,79 @@ type CustomerNameChangedForJSON struct {
 	Meta       es.EventMetaForJSON `json:"meta"`
 }
 
+type CustomerSuspendedForJSON struct {
+	CustomerID string              `json:"customerID"`
+	Reason     string              `json:"reason"`
+	Meta       es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerReactivatedForJSON struct {
+	CustomerID string              `json:"customerID"`
+	Meta       es.EventMetaForJSON `json:"meta"`
+}
+
 type CustomerDeletedForJSON struct {
 	CustomerID string              `json:"customerID"`
 	Meta       es.EventMetaForJSON `json:"meta"`
 }
+
+type CustomerPersonalDataErasedForJSON struct {
+	CustomerID string              `json:"customerID"`
+	Meta       es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerRestoredForJSON struct {
+	CustomerID                string              `json:"customerID"`
+	EmailAddress              string              `json:"emailAddress"`
+	ConfirmationHash          string              `json:"confirmationHash,omitempty"`
+	ConfirmationHashCreatedAt string              `json:"confirmationHashCreatedAt,omitempty"`
+	IsEmailAddressConfirmed   bool                `json:"isEmailAddressConfirmed"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerAddressAddedForJSON struct {
+	CustomerID  string              `json:"customerID"`
+	AddressKind string              `json:"addressKind"`
+	Street      string              `json:"street"`
+	PostalCode  string              `json:"postalCode"`
+	City        string              `json:"city"`
+	CountryCode string              `json:"countryCode"`
+	Meta        es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerAddressChangedForJSON struct {
+	CustomerID  string              `json:"customerID"`
+	AddressKind string              `json:"addressKind"`
+	Street      string              `json:"street"`
+	PostalCode  string              `json:"postalCode"`
+	City        string              `json:"city"`
+	CountryCode string              `json:"countryCode"`
+	Meta        es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerAddressRemovedForJSON struct {
+	CustomerID  string              `json:"customerID"`
+	AddressKind string              `json:"addressKind"`
+	Meta        es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerPhoneNumberChangedForJSON struct {
+	CustomerID                string              `json:"customerID"`
+	PhoneNumber               string              `json:"phoneNumber"`
+	ConfirmationCode          string              `json:"confirmationCode"`
+	ConfirmationCodeCreatedAt string              `json:"confirmationCodeCreatedAt,omitempty"`
+	Meta                      es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerPhoneNumberConfirmedForJSON struct {
+	CustomerID  string              `json:"customerID"`
+	PhoneNumber string              `json:"phoneNumber"`
+	Meta        es.EventMetaForJSON `json:"meta"`
+}
+
+type CustomerPhoneNumberConfirmationFailedForJSON struct {
+	CustomerID       string              `json:"customerID"`
+	ConfirmationCode string              `json:"confirmationCode"`
+	Reason           string              `json:"reason"`
+	Meta             es.EventMetaForJSON `json:"meta"`
+}
@@ -0,0 +1,46 @@
+package serialization
+
+import "github.com/AntonStoeckl/go-iddd/src/shared/es"
+
+type CustomerSnapshotForJSON struct {
+	CustomerID                string                      `json:"customerID"`
+	EmailAddress              string                      `json:"emailAddress"`
+	ConfirmationHash          string                      `json:"confirmationHash,omitempty"`
+	ConfirmationHashCreatedAt string                      `json:"confirmationHashCreatedAt,omitempty"`
+	IsEmailAddressConfirmed   bool                        `json:"isEmailAddressConfirmed"`
+	PersonGivenName           string                      `json:"personGivenName"`
+	PersonFamilyName          string                      `json:"personFamilyName"`
+	RegisteredAt              string                      `json:"registeredAt,omitempty"`
+	IsDeleted                 bool                        `json:"isDeleted"`
+	DeletedAt                 string                      `json:"deletedAt,omitempty"`
+	IsPersonalDataErased      bool                        `json:"isPersonalDataErased,omitempty"`
+	IsSuspended               bool                        `json:"isSuspended,omitempty"`
+	SuspensionReason          string                      `json:"suspensionReason,omitempty"`
+	PendingEmailAddress       *PendingEmailAddressForJSON `json:"pendingEmailAddress,omitempty"`
+	ConfirmationFailedAt      []string                    `json:"confirmationFailedAt,omitempty"`
+	Addresses                 []AddressForJSON            `json:"addresses,omitempty"`
+	PhoneNumber               *PhoneNumberForJSON         `json:"phoneNumber,omitempty"`
+	Meta                      es.EventMetaForJSON         `json:"meta"`
+}
+
+type PendingEmailAddressForJSON struct {
+	EmailAddress              string `json:"emailAddress"`
+	ConfirmationHash          string `json:"confirmationHash"`
+	ConfirmationHashCreatedAt string `json:"confirmationHashCreatedAt,omitempty"`
+}
+
+type AddressForJSON struct {
+	AddressKind string `json:"addressKind"`
+	Street      string `json:"street"`
+	PostalCode  string `json:"postalCode"`
+	City        string `json:"city"`
+	CountryCode string `json:"countryCode"`
+}
+
+type PhoneNumberForJSON struct {
+	PhoneNumber               string   `json:"phoneNumber"`
+	ConfirmationCode          string   `json:"confirmationCode,omitempty"`
+	ConfirmationCodeCreatedAt string   `json:"confirmationCodeCreatedAt,omitempty"`
+	IsPhoneNumberConfirmed    bool     `json:"isPhoneNumberConfirmed"`
+	ConfirmationFailedAt      []string `json:"confirmationFailedAt,omitempty"`
+}
@@ -0,0 +1,349 @@
+package serialization
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ErasedPersonalData replaces the personal data of a Customer once her key was deleted (crypto-shredding).
+	ErasedPersonalData = "[erased]"
+
+	encryptedPersonalDataPrefix = "encrypted:"
+)
+
+// personalDataFields are the json fields of Customer events and snapshots which contain personal data,
+// also inside of nested objects like the pendingEmailAddress or phoneNumber of a snapshot and arrays of objects like its addresses.
+var personalDataFields = map[string]bool{
+	"emailAddress":     true,
+	"personGivenName":  true,
+	"personFamilyName": true,
+	"givenName":        true,
+	"familyName":       true,
+	"suspensionReason": true,
+	"street":           true,
+	"postalCode":       true,
+	"city":             true,
+	"phoneNumber":      true,
+}
+
+// personalDataFieldsOfEvents are json fields which only contain personal data in some Customer events,
+// e.g. the reason of a suspension, while the reason why a confirmation failed is technical.
+var personalDataFieldsOfEvents = map[string]map[string]bool{
+	"CustomerSuspended": {"reason": true},
+}
+
+// ForRetrievingPersonalDataKeys must fail with ErrNotFound if the key of the Customer was deleted.
+// It returns no key if the Customer never got one, because her personal data was stored before it was encrypted.
+// It is called with the ctx of the caller for each event with personal data, so it should cache the keys of an operation.
+type ForRetrievingPersonalDataKeys func(ctx context.Context, customerID string) ([]byte, error)
+
+// EncryptingPersonalData encrypts the personal data in the json of marshal with the Customer's key.
+// If her key was deleted already, the personal data is replaced with ErasedPersonalData right away.
+func EncryptingPersonalData(
+	marshal es.MarshalDomainEvent,
+	retrieveOrCreateKey ForRetrievingPersonalDataKeys,
+) es.MarshalDomainEvent {
+
+	return func(ctx context.Context, event es.DomainEvent) ([]byte, error) {
+		payload, err := marshal(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+
+		return transformPersonalData(
+			ctx,
+			payload,
+			isPersonalDataOf(event.Meta().EventName()),
+			retrieveOrCreateKey,
+			encryptPersonalData,
+		)
+	}
+}
+
+// DecryptingPersonalData decrypts the personal data in the json before it is passed to unmarshal.
+// If the Customer's key was deleted, the personal data is replaced with ErasedPersonalData.
+// Personal data which was stored before it was encrypted is passed as it is.
+func DecryptingPersonalData(
+	unmarshal es.UnmarshalDomainEvent,
+	retrieveKey ForRetrievingPersonalDataKeys,
+) es.UnmarshalDomainEvent {
+
+	return func(ctx context.Context, name string, payload []byte, streamVersion uint) (es.DomainEvent, error) {
+		transformed, err := transformPersonalData(ctx, payload, isEncryptedOrPersonalDataOf(name), retrieveKey, decryptPersonalData)
+		if err != nil {
+			return nil, err
+		}
+
+		return unmarshal(ctx, name, transformed, streamVersion)
+	}
+}
+
+func EncryptingPersonalDataInSnapshots(
+	marshal es.MarshalSnapshot,
+	retrieveOrCreateKey ForRetrievingPersonalDataKeys,
+) es.MarshalSnapshot {
+
+	return es.MarshalSnapshot(EncryptingPersonalData(es.MarshalDomainEvent(marshal), retrieveOrCreateKey))
+}
+
+func DecryptingPersonalDataInSnapshots(
+	unmarshal es.UnmarshalSnapshot,
+	retrieveKey ForRetrievingPersonalDataKeys,
+) es.UnmarshalSnapshot {
+
+	return func(ctx context.Context, payload []byte, streamVersion uint) (es.DomainEvent, error) {
+		transformed, err := transformPersonalData(ctx, payload, isEncryptedOrPersonalDataOf(""), retrieveKey, decryptPersonalData)
+		if err != nil {
+			return nil, err
+		}
+
+		return unmarshal(ctx, transformed, streamVersion)
+	}
+}
+
+// DecryptingPersonalDataInOutboxMessages decrypts the personal data of a message right before it is published,
+// so that the outbox only stores it encrypted and deleting the Customer's key also erases it there.
+func DecryptingPersonalDataInOutboxMessages(
+	publish es.ForPublishingOutboxMessages,
+	retrieveKey ForRetrievingPersonalDataKeys,
+) es.ForPublishingOutboxMessages {
+
+	return func(ctx context.Context, message es.OutboxMessage) error {
+		payload, err := transformPersonalData(
+			ctx,
+			message.Payload(),
+			isEncryptedOrPersonalDataOf(message.EventName()),
+			retrieveKey,
+			decryptPersonalData,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		decryptedMessage := es.RebuildOutboxMessage(
+			message.ID(),
+			message.StreamID().String(),
+			message.StreamVersion(),
+			message.EventName(),
+			message.OccurredAt(),
+			payload,
+			message.Attempts(),
+		)
+
+		return publish(ctx, decryptedMessage)
+	}
+}
+
+type personalDataTransformation func(value string, key []byte, customerID string) (string, error)
+
+type personalDataPredicate func(fieldName string, value string) bool
+
+func isPersonalDataOf(eventName string) personalDataPredicate {
+	return func(fieldName string, _ string) bool {
+		return personalDataFields[fieldName] || personalDataFieldsOfEvents[eventName][fieldName]
+	}
+}
+
+// isEncryptedOrPersonalDataOf also matches fields which were encrypted while they were considered to be personal data.
+func isEncryptedOrPersonalDataOf(eventName string) personalDataPredicate {
+	return func(fieldName string, value string) bool {
+		return strings.HasPrefix(value, encryptedPersonalDataPrefix) || isPersonalDataOf(eventName)(fieldName, value)
+	}
+}
+
+// transformPersonalData only retrieves the key if the json contains personal data.
+func transformPersonalData(
+	ctx context.Context,
+	payload []byte,
+	isPersonalData personalDataPredicate,
+	retrieveKey ForRetrievingPersonalDataKeys,
+	transform personalDataTransformation,
+) ([]byte, error) {
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, errors.Wrap(err, "transformPersonalData")
+	}
+
+	var customerID string
+	_ = json.Unmarshal(fields["customerID"], &customerID)
+
+	var key []byte
+	var keyIsRetrieved, keyIsDeleted bool
+
+	withKey := func(value string) (string, error) {
+		if !keyIsRetrieved {
+			var err error
+
+			if key, err = retrieveKey(ctx, customerID); err != nil {
+				if !errors.Is(err, shared.ErrNotFound) {
+					return "", err
+				}
+
+				keyIsDeleted = true
+			}
+
+			keyIsRetrieved = true
+		}
+
+		if keyIsDeleted {
+			return ErasedPersonalData, nil
+		}
+
+		if key == nil {
+			return value, nil
+		}
+
+		return transform(value, key, customerID)
+	}
+
+	if err := transformPersonalDataFields(fields, isPersonalData, withKey); err != nil {
+		return nil, errors.Wrap(err, "transformPersonalData")
+	}
+
+	transformed, _ := json.Marshal(fields) // err intentionally ignored - it was valid json before
+
+	return transformed, nil
+}
+
+func transformPersonalDataFields(
+	fields map[string]json.RawMessage,
+	isPersonalData personalDataPredicate,
+	transform func(value string) (string, error),
+) error {
+
+	for name, field := range fields {
+		switch {
+		case len(field) > 0 && field[0] == '{':
+			var nestedFields map[string]json.RawMessage
+
+			if err := json.Unmarshal(field, &nestedFields); err != nil {
+				return err
+			}
+
+			if err := transformPersonalDataFields(nestedFields, isPersonalData, transform); err != nil {
+				return err
+			}
+
+			fields[name], _ = json.Marshal(nestedFields)
+		case len(field) > 0 && field[0] == '[':
+			var nestedObjects []json.RawMessage
+
+			if err := json.Unmarshal(field, &nestedObjects); err != nil {
+				return err
+			}
+
+			for i, nestedObject := range nestedObjects {
+				if len(nestedObject) == 0 || nestedObject[0] != '{' {
+					continue
+				}
+
+				var nestedFields map[string]json.RawMessage
+
+				if err := json.Unmarshal(nestedObject, &nestedFields); err != nil {
+					return err
+				}
+
+				if err := transformPersonalDataFields(nestedFields, isPersonalData, transform); err != nil {
+					return err
+				}
+
+				nestedObjects[i], _ = json.Marshal(nestedFields)
+			}
+
+			fields[name], _ = json.Marshal(nestedObjects)
+		case len(field) > 0 && field[0] == '"':
+			var value string
+
+			if err := json.Unmarshal(field, &value); err != nil {
+				return err
+			}
+
+			if value == "" || !isPersonalData(name, value) {
+				continue
+			}
+
+			transformed, err := transform(value)
+			if err != nil {
+				return err
+			}
+
+			fields[name], _ = json.Marshal(transformed)
+		}
+	}
+
+	return nil
+}
+
+// encryptPersonalData uses the customerID as additional data, so that the ciphertext can't be moved to another Customer.
+func encryptPersonalData(value string, key []byte, customerID string) (string, error) {
+	gcm, err := buildGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return "", shared.MarkAndWrapError(err, shared.ErrTechnical, "encryptPersonalData")
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(customerID))
+
+	return encryptedPersonalDataPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptPersonalData(value string, key []byte, customerID string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPersonalDataPrefix) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPersonalDataPrefix))
+	if err != nil {
+		return "", errors.Mark(errors.Wrap(err, "decryptPersonalData"), shared.ErrUnmarshalingFailed)
+	}
+
+	gcm, err := buildGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.Mark(errors.New("decryptPersonalData: ciphertext is too short"), shared.ErrUnmarshalingFailed)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(customerID))
+	if err != nil {
+		return "", errors.Mark(errors.Wrap(err, "decryptPersonalData"), shared.ErrUnmarshalingFailed)
+	}
+
+	return string(plaintext), nil
+}
+
+func buildGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, "buildGCM")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, "buildGCM")
+	}
+
+	return gcm, nil
+}
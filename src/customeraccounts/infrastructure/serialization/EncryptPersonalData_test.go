@@ -0,0 +1,391 @@
+package serialization
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEncryptPersonalData(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		customerID := value.GenerateCustomerID()
+		emailAddress, err := value.BuildUnconfirmedEmailAddress("kevin@ball.com")
+		So(err, ShouldBeNil)
+		pendingEmailAddress, err := value.BuildUnconfirmedEmailAddress("latoya@ball.net")
+		So(err, ShouldBeNil)
+		personName, err := value.BuildPersonName("Kevin", "Ball")
+		So(err, ShouldBeNil)
+		address, err := value.BuildAddress("Main Street 1", "10115", "Berlin", "DE")
+		So(err, ShouldBeNil)
+		phoneNumber, err := value.BuildUnconfirmedPhoneNumber("+49 30 12345678")
+		So(err, ShouldBeNil)
+
+		ctx := context.Background()
+		keys := make(map[string][]byte)
+
+		retrieveOrCreateKey := func(ctx context.Context, customerID string) ([]byte, error) {
+			if key, found := keys[customerID]; found {
+				if key == nil {
+					return nil, errors.Mark(errors.New("personal data key not found"), shared.ErrNotFound)
+				}
+
+				return key, nil
+			}
+
+			key := make([]byte, 32)
+			_, _ = rand.Read(key)
+			keys[customerID] = key
+
+			return key, nil
+		}
+
+		retrieveKey := func(ctx context.Context, customerID string) ([]byte, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if key, found := keys[customerID]; found && key == nil {
+				return nil, errors.Mark(errors.New("personal data key was deleted"), shared.ErrNotFound)
+			}
+
+			return keys[customerID], nil
+		}
+
+		deleteKey := func() {
+			keys[customerID.String()] = nil
+		}
+
+		marshalCustomerEvent := EncryptingPersonalData(MarshalCustomerEvent, retrieveOrCreateKey)
+		unmarshalCustomerEvent := DecryptingPersonalData(UnmarshalCustomerEvent, retrieveKey)
+
+		marshalCustomerSnapshot := EncryptingPersonalDataInSnapshots(
+			MarshalCustomerSnapshot,
+			retrieveOrCreateKey,
+		)
+
+		unmarshalCustomerSnapshot := DecryptingPersonalDataInSnapshots(
+			UnmarshalCustomerSnapshot,
+			retrieveKey,
+		)
+
+		customerRegistered := domain.BuildCustomerRegistered(customerID, emailAddress, personName, es.GenerateMessageID(), 1)
+
+		snapshot := domain.BuildCustomerSnapshot(
+			customerID,
+			emailAddress,
+			personName,
+			time.Now().UTC(),
+			false,
+			time.Time{},
+			false,
+			false,
+			"",
+			&pendingEmailAddress,
+			nil,
+			map[value.AddressKind]value.Address{value.AddressKindBilling: address},
+			phoneNumber,
+			nil,
+			es.GenerateMessageID(),
+			2,
+		)
+
+		Convey("When CustomerRegistered is marshaled", func() {
+			json, err := marshalCustomerEvent(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			Convey("Then its personal data should be encrypted", func() {
+				So(string(json), ShouldContainSubstring, customerID.String())
+				So(string(json), ShouldNotContainSubstring, emailAddress.String())
+				So(string(json), ShouldNotContainSubstring, personName.GivenName())
+				So(string(json), ShouldNotContainSubstring, personName.FamilyName())
+			})
+
+			Convey("and when it is unmarshaled", func() {
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), json, 1)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should resemble the original event", func() {
+					So(unmarshaledEvent, ShouldResemble, customerRegistered)
+				})
+			})
+
+			Convey("and when the key is deleted and it is unmarshaled", func() {
+				deleteKey()
+
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), json, 1)
+				So(err, ShouldBeNil)
+
+				Convey("Then its personal data should be replaced with placeholders", func() {
+					event, ok := unmarshaledEvent.(domain.CustomerRegistered)
+					So(ok, ShouldBeTrue)
+					So(event.CustomerID().Equals(customerID), ShouldBeTrue)
+					So(event.EmailAddress().String(), ShouldEqual, ErasedPersonalData)
+					So(event.PersonName().GivenName(), ShouldEqual, ErasedPersonalData)
+					So(event.PersonName().FamilyName(), ShouldEqual, ErasedPersonalData)
+					So(event.EmailAddress().ConfirmationHash().Equals(emailAddress.ConfirmationHash()), ShouldBeTrue)
+				})
+			})
+
+			Convey("and when it is unmarshaled for another Customer", func() {
+				otherCustomerID := value.GenerateCustomerID()
+				keys[otherCustomerID.String()] = keys[customerID.String()]
+				tamperedJSON := []byte(strings.ReplaceAll(string(json), customerID.String(), otherCustomerID.String()))
+
+				_, err = unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), tamperedJSON, 1)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldBeError)
+					So(errors.Is(err, shared.ErrUnmarshalingFailed), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When CustomerNameChanged is marshaled", func() {
+			changedPersonName, err := value.BuildPersonName("Latoya", "Gallagher")
+			So(err, ShouldBeNil)
+			nameChanged := domain.BuildCustomerNameChanged(customerID, changedPersonName, es.GenerateMessageID(), 2)
+
+			json, err := marshalCustomerEvent(ctx, nameChanged)
+			So(err, ShouldBeNil)
+
+			Convey("Then its personal data should be encrypted", func() {
+				So(string(json), ShouldNotContainSubstring, changedPersonName.GivenName())
+				So(string(json), ShouldNotContainSubstring, changedPersonName.FamilyName())
+			})
+
+			Convey("and when it is unmarshaled", func() {
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, nameChanged.Meta().EventName(), json, 2)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should resemble the original event", func() {
+					So(unmarshaledEvent, ShouldResemble, nameChanged)
+				})
+			})
+		})
+
+		Convey("When CustomerSuspended and CustomerEmailAddressConfirmationFailed are marshaled", func() {
+			suspensionReason, err := value.BuildSuspensionReason("Kevin asked for it on the phone")
+			So(err, ShouldBeNil)
+			customerSuspended := domain.BuildCustomerSuspended(customerID, suspensionReason, es.GenerateMessageID(), 2)
+
+			confirmationFailed := domain.BuildCustomerEmailAddressConfirmationFailed(
+				customerID,
+				value.GenerateConfirmationHash(emailAddress.String()),
+				errors.New("wrong confirmation hash supplied"),
+				es.GenerateMessageID(),
+				3,
+			)
+
+			suspendedJSON, err := marshalCustomerEvent(ctx, customerSuspended)
+			So(err, ShouldBeNil)
+			confirmationFailedJSON, err := marshalCustomerEvent(ctx, confirmationFailed)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the reason of the suspension should be encrypted", func() {
+				So(string(suspendedJSON), ShouldNotContainSubstring, suspensionReason.String())
+				So(string(confirmationFailedJSON), ShouldContainSubstring, confirmationFailed.FailureReason().Error())
+			})
+
+			Convey("and when the reason of the failed confirmation was stored encrypted and it is unmarshaled", func() {
+				key, err := retrieveOrCreateKey(ctx, customerID.String())
+				So(err, ShouldBeNil)
+				encryptedReason, err := encryptPersonalData(confirmationFailed.FailureReason().Error(), key, customerID.String())
+				So(err, ShouldBeNil)
+				storedJSON := strings.Replace(
+					string(confirmationFailedJSON),
+					confirmationFailed.FailureReason().Error(),
+					encryptedReason,
+					1,
+				)
+
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, confirmationFailed.Meta().EventName(), []byte(storedJSON), 3)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should be decrypted", func() {
+					failed, ok := unmarshaledEvent.(domain.CustomerEmailAddressConfirmationFailed)
+					So(ok, ShouldBeTrue)
+					So(failed.FailureReason().Error(), ShouldEqual, confirmationFailed.FailureReason().Error())
+				})
+			})
+
+			Convey("and when the key is deleted and they are unmarshaled", func() {
+				deleteKey()
+
+				unmarshaledSuspended, err := unmarshalCustomerEvent(ctx, customerSuspended.Meta().EventName(), suspendedJSON, 2)
+				So(err, ShouldBeNil)
+				unmarshaledConfirmationFailed, err := unmarshalCustomerEvent(
+					ctx,
+					confirmationFailed.Meta().EventName(),
+					confirmationFailedJSON,
+					3,
+				)
+				So(err, ShouldBeNil)
+
+				Convey("Then only the reason of the suspension should be replaced with a placeholder", func() {
+					suspended, ok := unmarshaledSuspended.(domain.CustomerSuspended)
+					So(ok, ShouldBeTrue)
+					So(suspended.Reason().String(), ShouldEqual, ErasedPersonalData)
+
+					failed, ok := unmarshaledConfirmationFailed.(domain.CustomerEmailAddressConfirmationFailed)
+					So(ok, ShouldBeTrue)
+					So(failed.FailureReason().Error(), ShouldEqual, confirmationFailed.FailureReason().Error())
+				})
+			})
+		})
+
+		Convey("When CustomerRegistered is unmarshaled after the caller's ctx was cancelled", func() {
+			json, err := marshalCustomerEvent(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err = unmarshalCustomerEvent(cancelledCtx, customerRegistered.Meta().EventName(), json, 1)
+
+			Convey("Then it should fail because the key is retrieved with that ctx", func() {
+				So(errors.Is(err, context.Canceled), ShouldBeTrue)
+			})
+		})
+
+		Convey("When CustomerRegistered is marshaled after the key was deleted", func() {
+			deleteKey()
+
+			json, err := marshalCustomerEvent(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			Convey("Then its personal data should be replaced with placeholders right away", func() {
+				So(string(json), ShouldNotContainSubstring, emailAddress.String())
+				So(string(json), ShouldContainSubstring, ErasedPersonalData)
+			})
+		})
+
+		Convey("When CustomerRegistered was stored before personal data was encrypted", func() {
+			json, err := MarshalCustomerEvent(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			Convey("and when it is unmarshaled while the Customer has no key", func() {
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), json, 1)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should resemble the original event", func() {
+					So(unmarshaledEvent, ShouldResemble, customerRegistered)
+				})
+			})
+
+			Convey("and when it is unmarshaled after a key was created for the Customer", func() {
+				_, _ = retrieveOrCreateKey(ctx, customerID.String())
+
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), json, 1)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should resemble the original event", func() {
+					So(unmarshaledEvent, ShouldResemble, customerRegistered)
+				})
+			})
+
+			Convey("and when the key is deleted and it is unmarshaled", func() {
+				deleteKey()
+
+				unmarshaledEvent, err := unmarshalCustomerEvent(ctx, customerRegistered.Meta().EventName(), json, 1)
+				So(err, ShouldBeNil)
+
+				Convey("Then its personal data should be replaced with placeholders", func() {
+					event, ok := unmarshaledEvent.(domain.CustomerRegistered)
+					So(ok, ShouldBeTrue)
+					So(event.EmailAddress().String(), ShouldEqual, ErasedPersonalData)
+				})
+			})
+		})
+
+		Convey("When CustomerRegistered is added to the outbox", func() {
+			json, err := marshalCustomerEvent(ctx, customerRegistered)
+			So(err, ShouldBeNil)
+
+			message := es.RebuildOutboxMessage(
+				1,
+				"customer-"+customerID.String(),
+				1,
+				customerRegistered.Meta().EventName(),
+				customerRegistered.Meta().OccurredAt(),
+				json,
+				0,
+			)
+
+			publisher := es.NewInMemoryPublisher()
+			publish := DecryptingPersonalDataInOutboxMessages(publisher.Publish, retrieveKey)
+
+			Convey("and when it is published", func() {
+				err = publish(ctx, message)
+				So(err, ShouldBeNil)
+
+				Convey("Then the published message should contain the decrypted personal data", func() {
+					So(publisher.PublishedMessages(), ShouldHaveLength, 1)
+					So(string(publisher.PublishedMessages()[0].Payload()), ShouldContainSubstring, emailAddress.String())
+					So(publisher.PublishedMessages()[0].ID(), ShouldEqual, message.ID())
+				})
+			})
+
+			Convey("and when the key is deleted and it is published", func() {
+				deleteKey()
+
+				err = publish(ctx, message)
+				So(err, ShouldBeNil)
+
+				Convey("Then the published message should only contain placeholders", func() {
+					So(string(publisher.PublishedMessages()[0].Payload()), ShouldNotContainSubstring, emailAddress.String())
+					So(string(publisher.PublishedMessages()[0].Payload()), ShouldContainSubstring, ErasedPersonalData)
+				})
+			})
+		})
+
+		Convey("When a CustomerSnapshot with a pending email address, an address and a phone number is marshaled", func() {
+			json, err := marshalCustomerSnapshot(ctx, snapshot)
+			So(err, ShouldBeNil)
+
+			Convey("Then its personal data should be encrypted", func() {
+				So(string(json), ShouldNotContainSubstring, emailAddress.String())
+				So(string(json), ShouldNotContainSubstring, pendingEmailAddress.String())
+				So(string(json), ShouldNotContainSubstring, personName.GivenName())
+				So(string(json), ShouldNotContainSubstring, address.Street())
+				So(string(json), ShouldNotContainSubstring, address.City())
+				So(string(json), ShouldContainSubstring, address.CountryCode())
+				So(string(json), ShouldNotContainSubstring, phoneNumber.String())
+			})
+
+			Convey("and when it is unmarshaled", func() {
+				unmarshaledSnapshot, err := unmarshalCustomerSnapshot(ctx, json, 2)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should resemble the original snapshot", func() {
+					So(unmarshaledSnapshot, ShouldResemble, snapshot)
+				})
+			})
+
+			Convey("and when the key is deleted and it is unmarshaled", func() {
+				deleteKey()
+
+				unmarshaledSnapshot, err := unmarshalCustomerSnapshot(ctx, json, 2)
+				So(err, ShouldBeNil)
+
+				Convey("Then its personal data should be replaced with placeholders", func() {
+					actualSnapshot, ok := unmarshaledSnapshot.(domain.CustomerSnapshot)
+					So(ok, ShouldBeTrue)
+					So(actualSnapshot.EmailAddress().String(), ShouldEqual, ErasedPersonalData)
+					So(actualSnapshot.PendingEmailAddress().String(), ShouldEqual, ErasedPersonalData)
+					So(actualSnapshot.PersonName().GivenName(), ShouldEqual, ErasedPersonalData)
+					So(actualSnapshot.Addresses()[value.AddressKindBilling].Street(), ShouldEqual, ErasedPersonalData)
+					So(actualSnapshot.PhoneNumber().String(), ShouldEqual, ErasedPersonalData)
+				})
+			})
+		})
+	})
+}
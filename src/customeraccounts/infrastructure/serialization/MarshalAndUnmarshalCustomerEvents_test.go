@@ -1,8 +1,10 @@
 package serialization
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
@@ -16,13 +18,18 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 	customerID := value.GenerateCustomerID()
 	emailAddressInput := "john@doe.com"
 	confirmationHash := value.GenerateConfirmationHash(emailAddressInput)
-	unconfirmedEmailAddress := value.RebuildUnconfirmedEmailAddress(emailAddressInput, confirmationHash.String())
+	confirmationHashCreatedAt := time.Now().UTC()
+	unconfirmedEmailAddress := value.RebuildUnconfirmedEmailAddress(emailAddressInput, confirmationHash.String(), confirmationHashCreatedAt)
 	confirmedEmailAddress := value.RebuildConfirmedEmailAddress(emailAddressInput)
 	changedEmailAddressInput := "john.frank@doe.com"
 	changedConfirmationHash := value.GenerateConfirmationHash(changedEmailAddressInput)
-	changedEmailAddress := value.RebuildUnconfirmedEmailAddress(changedEmailAddressInput, changedConfirmationHash.String())
+	changedEmailAddress := value.RebuildUnconfirmedEmailAddress(changedEmailAddressInput, changedConfirmationHash.String(), confirmationHashCreatedAt)
 	personName := value.RebuildPersonName("John", "Doe")
 	newPersonName := value.RebuildPersonName("John Frank", "Doe")
+	address := value.RebuildAddress("Main Street 1", "10115", "Berlin", "DE")
+	changedAddress := value.RebuildAddress("Side Street 2", "80331", "Munich", "DE")
+	phoneNumber := value.RebuildUnconfirmedPhoneNumber("+493012345678", "123456", time.Now().UTC())
+	confirmedPhoneNumber := value.RebuildConfirmedPhoneNumber("+493012345678")
 	failureReason := "wrong confirmation hash supplied"
 	causationID := es.GenerateMessageID()
 
@@ -43,6 +50,13 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 
 	streamVersion++
 
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerEmailAddressConfirmationResendRequested(customerID, unconfirmedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
 	myEvents = append(
 		myEvents,
 		domain.BuildCustomerEmailAddressChanged(customerID, changedEmailAddress, causationID, streamVersion),
@@ -50,6 +64,27 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 
 	streamVersion++
 
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerEmailAddressChangeRequested(customerID, changedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerEmailAddressChangeCancelled(customerID, changedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerEmailAddressChangeConfirmed(customerID, confirmedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
 	myEvents = append(
 		myEvents,
 		domain.BuildCustomerNameChanged(customerID, newPersonName, causationID, streamVersion),
@@ -57,21 +92,91 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 
 	streamVersion++
 
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerSuspended(customerID, value.RebuildSuspensionReason("suspicion of fraud"), causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerAddressAdded(customerID, value.AddressKindBilling, address, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerAddressChanged(customerID, value.AddressKindBilling, changedAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerAddressRemoved(customerID, value.AddressKindBilling, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerPhoneNumberChanged(customerID, phoneNumber, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerPhoneNumberConfirmed(customerID, confirmedPhoneNumber, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerReactivated(customerID, causationID, streamVersion),
+	)
+
+	streamVersion++
+
 	myEvents = append(
 		myEvents,
 		domain.BuildCustomerDeleted(customerID, causationID, streamVersion),
 	)
 
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerRestored(customerID, confirmedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerRestored(customerID, unconfirmedEmailAddress, causationID, streamVersion),
+	)
+
+	streamVersion++
+
+	myEvents = append(
+		myEvents,
+		domain.BuildCustomerPersonalDataErased(customerID, causationID, streamVersion),
+	)
+
 	for idx, event := range myEvents {
 		originalEvent := event
 		streamVersion = uint(idx + 1)
 		eventName := originalEvent.Meta().EventName()
 
 		Convey(fmt.Sprintf("When %s is marshaled and unmarshaled", eventName), t, func() {
-			json, err := MarshalCustomerEvent(originalEvent)
+			json, err := MarshalCustomerEvent(context.Background(), originalEvent)
 			So(err, ShouldBeNil)
 
-			unmarshaledEvent, err := UnmarshalCustomerEvent(originalEvent.Meta().EventName(), json, streamVersion)
+			unmarshaledEvent, err := UnmarshalCustomerEvent(context.Background(), originalEvent.Meta().EventName(), json, streamVersion)
 			So(err, ShouldBeNil)
 
 			Convey(fmt.Sprintf("Then the unmarshaled %s should resemble the original %s", eventName, eventName), func() {
@@ -94,10 +199,10 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 
 		oEventName := originalEvent.Meta().EventName()
 
-		json, err := MarshalCustomerEvent(originalEvent)
+		json, err := MarshalCustomerEvent(context.Background(), originalEvent)
 		So(err, ShouldBeNil)
 
-		unmarshaledEvent, err := UnmarshalCustomerEvent(originalEvent.Meta().EventName(), json, streamVersion)
+		unmarshaledEvent, err := UnmarshalCustomerEvent(context.Background(), originalEvent.Meta().EventName(), json, streamVersion)
 		So(err, ShouldBeNil)
 
 		uEventName := unmarshaledEvent.Meta().EventName()
@@ -110,6 +215,57 @@ func TestMarshalAndUnmarshalCustomerEvents(t *testing.T) {
 			assertEventMetaResembles(originalEvent, unmarshaledEvent)
 		})
 	})
+
+	Convey("When CustomerPhoneNumberConfirmationFailed is marshaled and unmarshaled", t, func() {
+		originalEvent := domain.BuildCustomerPhoneNumberConfirmationFailed(
+			customerID,
+			value.RebuildConfirmationCode("654321"),
+			errors.Mark(errors.New("wrong confirmation code supplied"), shared.ErrDomainConstraintsViolation),
+			causationID,
+			streamVersion,
+		)
+
+		oEventName := originalEvent.Meta().EventName()
+
+		json, err := MarshalCustomerEvent(context.Background(), originalEvent)
+		So(err, ShouldBeNil)
+
+		unmarshaledEvent, err := UnmarshalCustomerEvent(context.Background(), originalEvent.Meta().EventName(), json, streamVersion)
+		So(err, ShouldBeNil)
+
+		uEventName := unmarshaledEvent.Meta().EventName()
+
+		Convey(fmt.Sprintf("Then the unmarshaled %s should resemble the original %s", oEventName, uEventName), func() {
+			unmarshaledEvent, ok := unmarshaledEvent.(domain.CustomerPhoneNumberConfirmationFailed)
+			So(ok, ShouldBeTrue)
+			So(unmarshaledEvent.CustomerID().Equals(originalEvent.CustomerID()), ShouldBeTrue)
+			So(unmarshaledEvent.ConfirmationCode().Equals(originalEvent.ConfirmationCode()), ShouldBeTrue)
+			assertEventMetaResembles(originalEvent, unmarshaledEvent)
+		})
+	})
+}
+
+func TestUnmarshalCustomerEvent_WithoutConfirmationHashCreatedAt(t *testing.T) {
+	Convey("When a CustomerRegistered event recorded before confirmation hashes had a creation time is unmarshaled", t, func() {
+		json := []byte(`{
+			"customerID": "12345",
+			"emailAddress": "john@doe.com",
+			"confirmationHash": "some_hash",
+			"personGivenName": "John",
+			"personFamilyName": "Doe",
+			"meta": {"eventName": "CustomerRegistered", "occurredAt": "2020-01-01T00:00:00Z", "messageID": "1", "causationID": "2"}
+		}`)
+
+		unmarshaledEvent, err := UnmarshalCustomerEvent(context.Background(), "CustomerRegistered", json, 1)
+		So(err, ShouldBeNil)
+
+		Convey("Then its confirmation hash creation time should be zero", func() {
+			event, ok := unmarshaledEvent.(domain.CustomerRegistered)
+			So(ok, ShouldBeTrue)
+			So(event.EmailAddress().ConfirmationHash().String(), ShouldEqual, "some_hash")
+			So(event.EmailAddress().ConfirmationHashCreatedAt().IsZero(), ShouldBeTrue)
+		})
+	})
 }
 
 func assertEventMetaResembles(originalEvent, unmarshaledEvent es.DomainEvent) {
@@ -126,7 +282,7 @@ func assertEventMetaResembles(originalEvent, unmarshaledEvent es.DomainEvent) {
 
 func TestMarshalCustomerEvent_WithUnknownEvent(t *testing.T) {
 	Convey("When an unknown event is marshaled", t, func() {
-		_, err := MarshalCustomerEvent(SomeEvent{})
+		_, err := MarshalCustomerEvent(context.Background(), SomeEvent{})
 
 		Convey("Then it should fail", func() {
 			So(errors.Is(err, shared.ErrMarshalingFailed), ShouldBeTrue)
@@ -136,7 +292,7 @@ func TestMarshalCustomerEvent_WithUnknownEvent(t *testing.T) {
 
 func TestUnmarshalCustomerEvent_WithUnknownEvent(t *testing.T) {
 	Convey("When an unknown event is unmarshaled", t, func() {
-		_, err := UnmarshalCustomerEvent("unknown", []byte{}, 1)
+		_, err := UnmarshalCustomerEvent(context.Background(), "unknown", []byte{}, 1)
 
 		Convey("Then it should fail", func() {
 			So(errors.Is(err, shared.ErrUnmarshalingFailed), ShouldBeTrue)
@@ -0,0 +1,99 @@
+package serialization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMarshalAndUnmarshalCustomerSnapshot(t *testing.T) {
+	customerID := value.GenerateCustomerID()
+	emailAddressInput := "john@doe.com"
+	confirmationHash := value.GenerateConfirmationHash(emailAddressInput)
+	unconfirmedEmailAddress := value.RebuildUnconfirmedEmailAddress(emailAddressInput, confirmationHash.String(), time.Now().UTC())
+	confirmedEmailAddress := value.RebuildConfirmedEmailAddress(emailAddressInput)
+	pendingEmailAddress := value.RebuildUnconfirmedEmailAddress("john@new.com", confirmationHash.String(), time.Now().UTC())
+	personName := value.RebuildPersonName("John", "Doe")
+	suspensionReason := value.RebuildSuspensionReason("suspicion of fraud")
+	registeredAt := time.Now().UTC()
+	deletedAt := time.Now().UTC()
+	addresses := map[value.AddressKind]value.Address{
+		value.AddressKindBilling:  value.RebuildAddress("Main Street 1", "10115", "Berlin", "DE"),
+		value.AddressKindShipping: value.RebuildAddress("Side Street 2", "80331", "Munich", "DE"),
+	}
+	unconfirmedPhoneNumber := value.RebuildUnconfirmedPhoneNumber("+493012345678", "123456", time.Now().UTC())
+	confirmedPhoneNumber := value.RebuildConfirmedPhoneNumber("+493012345678")
+	streamVersion := uint(7)
+
+	snapshots := map[string]domain.CustomerSnapshot{
+		"with an unconfirmed email address": domain.BuildCustomerSnapshot(
+			customerID, unconfirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"with failed confirmation attempts": domain.BuildCustomerSnapshot(
+			customerID, unconfirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, []time.Time{time.Now().UTC()}, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"with a confirmed email address": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"with a pending email address": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", &pendingEmailAddress, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"with addresses": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, nil, addresses, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"with an unconfirmed phone number": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, nil, nil, unconfirmedPhoneNumber, []time.Time{time.Now().UTC()}, es.GenerateMessageID(), streamVersion,
+		),
+		"with a confirmed phone number": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, false, "", nil, nil, nil, confirmedPhoneNumber, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"of a suspended Customer": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, false, time.Time{}, false, true, suspensionReason, nil, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"of a deleted Customer": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, true, deletedAt, false, false, "", nil, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+		"of a Customer whose personal data was erased": domain.BuildCustomerSnapshot(
+			customerID, confirmedEmailAddress, personName, registeredAt, true, deletedAt, true, false, "", nil, nil, nil, nil, nil, es.GenerateMessageID(), streamVersion,
+		),
+	}
+
+	for description, snapshot := range snapshots {
+		originalSnapshot := snapshot
+
+		Convey("When a CustomerSnapshot "+description+" is marshaled and unmarshaled", t, func() {
+			json, err := MarshalCustomerSnapshot(context.Background(), originalSnapshot)
+			So(err, ShouldBeNil)
+
+			unmarshaledSnapshot, err := UnmarshalCustomerSnapshot(context.Background(), json, streamVersion)
+			So(err, ShouldBeNil)
+
+			Convey("Then the unmarshaled CustomerSnapshot should resemble the original", func() {
+				So(unmarshaledSnapshot, ShouldResemble, originalSnapshot)
+			})
+		})
+	}
+
+	Convey("When an unknown snapshot is marshaled", t, func() {
+		_, err := MarshalCustomerSnapshot(context.Background(), SomeEvent{})
+
+		Convey("Then it should fail", func() {
+			So(errors.Is(err, shared.ErrMarshalingFailed), ShouldBeTrue)
+		})
+	})
+
+	Convey("When invalid json is unmarshaled", t, func() {
+		_, err := UnmarshalCustomerSnapshot(context.Background(), []byte("{"), streamVersion)
+
+		Convey("Then it should fail", func() {
+			So(errors.Is(err, shared.ErrUnmarshalingFailed), ShouldBeTrue)
+		})
+	})
+}
@@ -1,7 +1,11 @@
 package serialization
 
 import (
+	"context"
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
 	"github.com/cockroachdb/errors"
@@ -11,7 +15,7 @@ import (
 // MarshalCustomerEvent marshals every known Customer event to json.
 // It intentionally ignores marshaling errors, because they can't happen with the data types we are using.
 // We have a rich test suite which would catch such issues.
-func MarshalCustomerEvent(event es.DomainEvent) ([]byte, error) {
+func MarshalCustomerEvent(_ context.Context, event es.DomainEvent) ([]byte, error) {
 	var err error
 	var json []byte
 
@@ -22,12 +26,40 @@ func MarshalCustomerEvent(event es.DomainEvent) ([]byte, error) {
 		json = marshalCustomerEmailAddressConfirmed(actualEvent)
 	case domain.CustomerEmailAddressConfirmationFailed:
 		json = marshalCustomerEmailAddressConfirmationFailed(actualEvent)
+	case domain.CustomerEmailAddressConfirmationResendRequested:
+		json = marshalCustomerEmailAddressConfirmationResendRequested(actualEvent)
 	case domain.CustomerEmailAddressChanged:
 		json = marshalCustomerEmailAddressChanged(actualEvent)
+	case domain.CustomerEmailAddressChangeRequested:
+		json = marshalCustomerEmailAddressChangeRequested(actualEvent)
+	case domain.CustomerEmailAddressChangeCancelled:
+		json = marshalCustomerEmailAddressChangeCancelled(actualEvent)
+	case domain.CustomerEmailAddressChangeConfirmed:
+		json = marshalCustomerEmailAddressChangeConfirmed(actualEvent)
 	case domain.CustomerNameChanged:
 		json = marshalCustomerNameChanged(actualEvent)
+	case domain.CustomerSuspended:
+		json = marshalCustomerSuspended(actualEvent)
+	case domain.CustomerAddressAdded:
+		json = marshalCustomerAddressAdded(actualEvent)
+	case domain.CustomerAddressChanged:
+		json = marshalCustomerAddressChanged(actualEvent)
+	case domain.CustomerAddressRemoved:
+		json = marshalCustomerAddressRemoved(actualEvent)
+	case domain.CustomerPhoneNumberChanged:
+		json = marshalCustomerPhoneNumberChanged(actualEvent)
+	case domain.CustomerPhoneNumberConfirmed:
+		json = marshalCustomerPhoneNumberConfirmed(actualEvent)
+	case domain.CustomerPhoneNumberConfirmationFailed:
+		json = marshalCustomerPhoneNumberConfirmationFailed(actualEvent)
+	case domain.CustomerReactivated:
+		json = marshalCustomerReactivated(actualEvent)
 	case domain.CustomerDeleted:
 		json = marshalCustomerDeleted(actualEvent)
+	case domain.CustomerRestored:
+		json = marshalCustomerRestored(actualEvent)
+	case domain.CustomerPersonalDataErased:
+		json = marshalCustomerPersonalDataErased(actualEvent)
 	default:
 		err = errors.Wrapf(errors.New("event is unknown"), "marshalCustomerEvent [%s] failed", event.Meta().EventName())
 		return nil, errors.Mark(err, shared.ErrMarshalingFailed)
@@ -39,12 +71,13 @@ func MarshalCustomerEvent(event es.DomainEvent) ([]byte, error) {
 func marshalCustomerRegistered(event domain.CustomerRegistered) []byte {
 
 	data := CustomerRegisteredForJSON{
-		CustomerID:       event.CustomerID().String(),
-		EmailAddress:     event.EmailAddress().String(),
-		ConfirmationHash: event.EmailAddress().ConfirmationHash().String(),
-		PersonGivenName:  event.PersonName().GivenName(),
-		PersonFamilyName: event.PersonName().FamilyName(),
-		Meta:             marshalEventMeta(event),
+		CustomerID:                event.CustomerID().String(),
+		EmailAddress:              event.EmailAddress().String(),
+		ConfirmationHash:          event.EmailAddress().ConfirmationHash().String(),
+		ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(event.EmailAddress().ConfirmationHashCreatedAt()),
+		PersonGivenName:           event.PersonName().GivenName(),
+		PersonFamilyName:          event.PersonName().FamilyName(),
+		Meta:                      marshalEventMeta(event),
 	}
 
 	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
@@ -77,12 +110,70 @@ func marshalCustomerEmailAddressConfirmationFailed(event domain.CustomerEmailAdd
 	return json
 }
 
+func marshalCustomerEmailAddressConfirmationResendRequested(
+	event domain.CustomerEmailAddressConfirmationResendRequested,
+) []byte {
+
+	data := CustomerEmailAddressConfirmationResendRequestedForJSON{
+		CustomerID:                event.CustomerID().String(),
+		EmailAddress:              event.EmailAddress().String(),
+		ConfirmationHash:          event.EmailAddress().ConfirmationHash().String(),
+		ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(event.EmailAddress().ConfirmationHashCreatedAt()),
+		Meta:                      marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
 func marshalCustomerEmailAddressChanged(event domain.CustomerEmailAddressChanged) []byte {
 	data := CustomerEmailAddressChangedForJSON{
-		CustomerID:       event.CustomerID().String(),
-		EmailAddress:     event.EmailAddress().String(),
-		ConfirmationHash: event.EmailAddress().ConfirmationHash().String(),
-		Meta:             marshalEventMeta(event),
+		CustomerID:                event.CustomerID().String(),
+		EmailAddress:              event.EmailAddress().String(),
+		ConfirmationHash:          event.EmailAddress().ConfirmationHash().String(),
+		ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(event.EmailAddress().ConfirmationHashCreatedAt()),
+		Meta:                      marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerEmailAddressChangeRequested(event domain.CustomerEmailAddressChangeRequested) []byte {
+	data := CustomerEmailAddressChangeRequestedForJSON{
+		CustomerID:                event.CustomerID().String(),
+		EmailAddress:              event.EmailAddress().String(),
+		ConfirmationHash:          event.EmailAddress().ConfirmationHash().String(),
+		ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(event.EmailAddress().ConfirmationHashCreatedAt()),
+		Meta:                      marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerEmailAddressChangeCancelled(event domain.CustomerEmailAddressChangeCancelled) []byte {
+	data := CustomerEmailAddressChangeCancelledForJSON{
+		CustomerID:                event.CustomerID().String(),
+		EmailAddress:              event.EmailAddress().String(),
+		ConfirmationHash:          event.EmailAddress().ConfirmationHash().String(),
+		ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(event.EmailAddress().ConfirmationHashCreatedAt()),
+		Meta:                      marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerEmailAddressChangeConfirmed(event domain.CustomerEmailAddressChangeConfirmed) []byte {
+	data := CustomerEmailAddressChangeConfirmedForJSON{
+		CustomerID:   event.CustomerID().String(),
+		EmailAddress: event.EmailAddress().String(),
+		Meta:         marshalEventMeta(event),
 	}
 
 	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
@@ -103,6 +194,112 @@ func marshalCustomerNameChanged(event domain.CustomerNameChanged) []byte {
 	return json
 }
 
+func marshalCustomerSuspended(event domain.CustomerSuspended) []byte {
+	data := CustomerSuspendedForJSON{
+		CustomerID: event.CustomerID().String(),
+		Reason:     event.Reason().String(),
+		Meta:       marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerAddressAdded(event domain.CustomerAddressAdded) []byte {
+	data := CustomerAddressAddedForJSON{
+		CustomerID:  event.CustomerID().String(),
+		AddressKind: event.AddressKind().String(),
+		Street:      event.Address().Street(),
+		PostalCode:  event.Address().PostalCode(),
+		City:        event.Address().City(),
+		CountryCode: event.Address().CountryCode(),
+		Meta:        marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerAddressChanged(event domain.CustomerAddressChanged) []byte {
+	data := CustomerAddressChangedForJSON{
+		CustomerID:  event.CustomerID().String(),
+		AddressKind: event.AddressKind().String(),
+		Street:      event.Address().Street(),
+		PostalCode:  event.Address().PostalCode(),
+		City:        event.Address().City(),
+		CountryCode: event.Address().CountryCode(),
+		Meta:        marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerAddressRemoved(event domain.CustomerAddressRemoved) []byte {
+	data := CustomerAddressRemovedForJSON{
+		CustomerID:  event.CustomerID().String(),
+		AddressKind: event.AddressKind().String(),
+		Meta:        marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerPhoneNumberChanged(event domain.CustomerPhoneNumberChanged) []byte {
+	data := CustomerPhoneNumberChangedForJSON{
+		CustomerID:                event.CustomerID().String(),
+		PhoneNumber:               event.PhoneNumber().String(),
+		ConfirmationCode:          event.PhoneNumber().ConfirmationCode().String(),
+		ConfirmationCodeCreatedAt: marshalConfirmationHashCreatedAt(event.PhoneNumber().ConfirmationCodeCreatedAt()),
+		Meta:                      marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerPhoneNumberConfirmed(event domain.CustomerPhoneNumberConfirmed) []byte {
+	data := CustomerPhoneNumberConfirmedForJSON{
+		CustomerID:  event.CustomerID().String(),
+		PhoneNumber: event.PhoneNumber().String(),
+		Meta:        marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerPhoneNumberConfirmationFailed(event domain.CustomerPhoneNumberConfirmationFailed) []byte {
+	data := CustomerPhoneNumberConfirmationFailedForJSON{
+		CustomerID:       event.CustomerID().String(),
+		ConfirmationCode: event.ConfirmationCode().String(),
+		Reason:           event.FailureReason().Error(),
+		Meta:             marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerReactivated(event domain.CustomerReactivated) []byte {
+	data := CustomerReactivatedForJSON{
+		CustomerID: event.CustomerID().String(),
+		Meta:       marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
 func marshalCustomerDeleted(event domain.CustomerDeleted) []byte {
 	data := CustomerDeletedForJSON{
 		CustomerID: event.CustomerID().String(),
@@ -114,6 +311,37 @@ func marshalCustomerDeleted(event domain.CustomerDeleted) []byte {
 	return json
 }
 
+func marshalCustomerPersonalDataErased(event domain.CustomerPersonalDataErased) []byte {
+	data := CustomerPersonalDataErasedForJSON{
+		CustomerID: event.CustomerID().String(),
+		Meta:       marshalEventMeta(event),
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
+func marshalCustomerRestored(event domain.CustomerRestored) []byte {
+	data := CustomerRestoredForJSON{
+		CustomerID:   event.CustomerID().String(),
+		EmailAddress: event.EmailAddress().String(),
+		Meta:         marshalEventMeta(event),
+	}
+
+	switch emailAddress := event.EmailAddress().(type) {
+	case value.ConfirmedEmailAddress:
+		data.IsEmailAddressConfirmed = true
+	case value.UnconfirmedEmailAddress:
+		data.ConfirmationHash = emailAddress.ConfirmationHash().String()
+		data.ConfirmationHashCreatedAt = marshalConfirmationHashCreatedAt(emailAddress.ConfirmationHashCreatedAt())
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json
+}
+
 func marshalEventMeta(event es.DomainEvent) es.EventMetaForJSON {
 	return es.EventMetaForJSON{
 		EventName:   event.Meta().EventName(),
@@ -122,3 +350,11 @@ func marshalEventMeta(event es.DomainEvent) es.EventMetaForJSON {
 		CausationID: event.Meta().CausationID(),
 	}
 }
+
+func marshalConfirmationHashCreatedAt(createdAt time.Time) string {
+	if createdAt.IsZero() {
+		return ""
+	}
+
+	return createdAt.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,106 @@
+package serialization
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// MarshalCustomerSnapshot marshals a CustomerSnapshot to json.
+// It intentionally ignores marshaling errors, same as MarshalCustomerEvent.
+func MarshalCustomerSnapshot(_ context.Context, snapshot es.DomainEvent) ([]byte, error) {
+	actualSnapshot, ok := snapshot.(domain.CustomerSnapshot)
+	if !ok {
+		err := errors.Wrapf(errors.New("snapshot is unknown"), "marshalCustomerSnapshot [%s] failed", snapshot.Meta().EventName())
+		return nil, errors.Mark(err, shared.ErrMarshalingFailed)
+	}
+
+	data := CustomerSnapshotForJSON{
+		CustomerID:           actualSnapshot.CustomerID().String(),
+		EmailAddress:         actualSnapshot.EmailAddress().String(),
+		PersonGivenName:      actualSnapshot.PersonName().GivenName(),
+		PersonFamilyName:     actualSnapshot.PersonName().FamilyName(),
+		IsDeleted:            actualSnapshot.IsDeleted(),
+		IsPersonalDataErased: actualSnapshot.IsPersonalDataErased(),
+		IsSuspended:          actualSnapshot.IsSuspended(),
+		SuspensionReason:     actualSnapshot.SuspensionReason().String(),
+		Meta:                 marshalEventMeta(actualSnapshot),
+	}
+
+	if pendingEmailAddress := actualSnapshot.PendingEmailAddress(); pendingEmailAddress != nil {
+		data.PendingEmailAddress = &PendingEmailAddressForJSON{
+			EmailAddress:              pendingEmailAddress.String(),
+			ConfirmationHash:          pendingEmailAddress.ConfirmationHash().String(),
+			ConfirmationHashCreatedAt: marshalConfirmationHashCreatedAt(pendingEmailAddress.ConfirmationHashCreatedAt()),
+		}
+	}
+
+	if registeredAt := actualSnapshot.RegisteredAt(); !registeredAt.IsZero() {
+		data.RegisteredAt = registeredAt.Format(time.RFC3339Nano)
+	}
+
+	if deletedAt := actualSnapshot.DeletedAt(); !deletedAt.IsZero() {
+		data.DeletedAt = deletedAt.Format(time.RFC3339Nano)
+	}
+
+	for _, failedAt := range actualSnapshot.RecentConfirmationFailures() {
+		data.ConfirmationFailedAt = append(data.ConfirmationFailedAt, failedAt.Format(time.RFC3339Nano))
+	}
+
+	for addressKind, address := range actualSnapshot.Addresses() {
+		data.Addresses = append(
+			data.Addresses,
+			AddressForJSON{
+				AddressKind: addressKind.String(),
+				Street:      address.Street(),
+				PostalCode:  address.PostalCode(),
+				City:        address.City(),
+				CountryCode: address.CountryCode(),
+			},
+		)
+	}
+
+	sort.Slice(data.Addresses, func(i, j int) bool {
+		return data.Addresses[i].AddressKind < data.Addresses[j].AddressKind
+	})
+
+	switch phoneNumber := actualSnapshot.PhoneNumber().(type) {
+	case value.ConfirmedPhoneNumber:
+		data.PhoneNumber = &PhoneNumberForJSON{
+			PhoneNumber:            phoneNumber.String(),
+			IsPhoneNumberConfirmed: true,
+		}
+	case value.UnconfirmedPhoneNumber:
+		data.PhoneNumber = &PhoneNumberForJSON{
+			PhoneNumber:               phoneNumber.String(),
+			ConfirmationCode:          phoneNumber.ConfirmationCode().String(),
+			ConfirmationCodeCreatedAt: marshalConfirmationHashCreatedAt(phoneNumber.ConfirmationCodeCreatedAt()),
+		}
+
+		for _, failedAt := range actualSnapshot.RecentPhoneNumberConfirmationFailures() {
+			data.PhoneNumber.ConfirmationFailedAt = append(
+				data.PhoneNumber.ConfirmationFailedAt,
+				failedAt.Format(time.RFC3339Nano),
+			)
+		}
+	}
+
+	switch emailAddress := actualSnapshot.EmailAddress().(type) {
+	case value.ConfirmedEmailAddress:
+		data.IsEmailAddressConfirmed = true
+	case value.UnconfirmedEmailAddress:
+		data.ConfirmationHash = emailAddress.ConfirmationHash().String()
+		data.ConfirmationHashCreatedAt = marshalConfirmationHashCreatedAt(emailAddress.ConfirmationHashCreatedAt())
+	}
+
+	json, _ := jsoniter.ConfigFastest.Marshal(data) // err intentionally ignored - see top comment
+
+	return json, nil
+}
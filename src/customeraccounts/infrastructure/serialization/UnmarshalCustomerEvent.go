@@ -1,6 +1,9 @@
 package serialization
 
 import (
+	"context"
+	"time"
+
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
@@ -12,6 +15,7 @@ import (
 // It intentionally ignores unmarshaling errors, which could only happen if we would store invalid json to the EventStore.
 // We have a rich test suite which would catch such issues.
 func UnmarshalCustomerEvent(
+	_ context.Context,
 	name string,
 	payload []byte,
 	streamVersion uint,
@@ -26,12 +30,40 @@ func UnmarshalCustomerEvent(
 		event = unmarshalCustomerEmailAddressConfirmedFromJSON(payload, streamVersion)
 	case "CustomerEmailAddressConfirmationFailed":
 		event = unmarshalCustomerEmailAddressConfirmationFailedFromJSON(payload, streamVersion)
+	case "CustomerEmailAddressConfirmationResendRequested":
+		event = unmarshalCustomerEmailAddressConfirmationResendRequestedFromJSON(payload, streamVersion)
 	case "CustomerEmailAddressChanged":
 		event = unmarshalCustomerEmailAddressChangedFromJSON(payload, streamVersion)
+	case "CustomerEmailAddressChangeRequested":
+		event = unmarshalCustomerEmailAddressChangeRequestedFromJSON(payload, streamVersion)
+	case "CustomerEmailAddressChangeCancelled":
+		event = unmarshalCustomerEmailAddressChangeCancelledFromJSON(payload, streamVersion)
+	case "CustomerEmailAddressChangeConfirmed":
+		event = unmarshalCustomerEmailAddressChangeConfirmedFromJSON(payload, streamVersion)
 	case "CustomerNameChanged":
 		event = unmarshalCustomerNameChangedFromJSON(payload, streamVersion)
+	case "CustomerSuspended":
+		event = unmarshalCustomerSuspendedFromJSON(payload, streamVersion)
+	case "CustomerAddressAdded":
+		event = unmarshalCustomerAddressAddedFromJSON(payload, streamVersion)
+	case "CustomerAddressChanged":
+		event = unmarshalCustomerAddressChangedFromJSON(payload, streamVersion)
+	case "CustomerAddressRemoved":
+		event = unmarshalCustomerAddressRemovedFromJSON(payload, streamVersion)
+	case "CustomerPhoneNumberChanged":
+		event = unmarshalCustomerPhoneNumberChangedFromJSON(payload, streamVersion)
+	case "CustomerPhoneNumberConfirmed":
+		event = unmarshalCustomerPhoneNumberConfirmedFromJSON(payload, streamVersion)
+	case "CustomerPhoneNumberConfirmationFailed":
+		event = unmarshalCustomerPhoneNumberConfirmationFailedFromJSON(payload, streamVersion)
+	case "CustomerReactivated":
+		event = unmarshalCustomerReactivatedFromJSON(payload, streamVersion)
 	case "CustomerDeleted":
 		event = unmarshalCustomerDeletedFromJSON(payload, streamVersion)
+	case "CustomerRestored":
+		event = unmarshalCustomerRestoredFromJSON(payload, streamVersion)
+	case "CustomerPersonalDataErased":
+		event = unmarshalCustomerPersonalDataErasedFromJSON(payload, streamVersion)
 	default:
 		err := errors.Wrapf(errors.New("event is unknown"), "unmarshalCustomerEvent [%s] failed", name)
 		return nil, errors.Mark(err, shared.ErrUnmarshalingFailed)
@@ -53,6 +85,7 @@ func unmarshalCustomerRegisteredFromJSON(
 		unmarshaledData.CustomerID,
 		unmarshaledData.EmailAddress,
 		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
 		unmarshaledData.PersonGivenName,
 		unmarshaledData.PersonFamilyName,
 		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
@@ -98,6 +131,26 @@ func unmarshalCustomerEmailAddressConfirmationFailedFromJSON(
 	return event
 }
 
+func unmarshalCustomerEmailAddressConfirmationResendRequestedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerEmailAddressConfirmationResendRequested {
+
+	unmarshaledData := &CustomerEmailAddressConfirmationResendRequestedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerEmailAddressConfirmationResendRequested(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
+		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
 func unmarshalCustomerEmailAddressChangedFromJSON(
 	data []byte,
 	streamVersion uint,
@@ -111,6 +164,65 @@ func unmarshalCustomerEmailAddressChangedFromJSON(
 		unmarshaledData.CustomerID,
 		unmarshaledData.EmailAddress,
 		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerEmailAddressChangeRequestedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerEmailAddressChangeRequested {
+
+	unmarshaledData := &CustomerEmailAddressChangeRequestedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerEmailAddressChangeRequested(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
+		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerEmailAddressChangeCancelledFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerEmailAddressChangeCancelled {
+
+	unmarshaledData := &CustomerEmailAddressChangeCancelledForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerEmailAddressChangeCancelled(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
+		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerEmailAddressChangeConfirmedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerEmailAddressChangeConfirmed {
+
+	unmarshaledData := &CustomerEmailAddressChangeConfirmedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerEmailAddressChangeConfirmed(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
 		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
 	)
 
@@ -136,6 +248,160 @@ func unmarshalCustomerNameChangedFromJSON(
 	return event
 }
 
+func unmarshalCustomerSuspendedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerSuspended {
+
+	unmarshaledData := &CustomerSuspendedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerSuspended(
+		unmarshaledData.CustomerID,
+		unmarshaledData.Reason,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerAddressAddedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerAddressAdded {
+
+	unmarshaledData := &CustomerAddressAddedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerAddressAdded(
+		unmarshaledData.CustomerID,
+		unmarshaledData.AddressKind,
+		unmarshaledData.Street,
+		unmarshaledData.PostalCode,
+		unmarshaledData.City,
+		unmarshaledData.CountryCode,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerAddressChangedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerAddressChanged {
+
+	unmarshaledData := &CustomerAddressChangedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerAddressChanged(
+		unmarshaledData.CustomerID,
+		unmarshaledData.AddressKind,
+		unmarshaledData.Street,
+		unmarshaledData.PostalCode,
+		unmarshaledData.City,
+		unmarshaledData.CountryCode,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerAddressRemovedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerAddressRemoved {
+
+	unmarshaledData := &CustomerAddressRemovedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerAddressRemoved(
+		unmarshaledData.CustomerID,
+		unmarshaledData.AddressKind,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerPhoneNumberChangedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerPhoneNumberChanged {
+
+	unmarshaledData := &CustomerPhoneNumberChangedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerPhoneNumberChanged(
+		unmarshaledData.CustomerID,
+		unmarshaledData.PhoneNumber,
+		unmarshaledData.ConfirmationCode,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationCodeCreatedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerPhoneNumberConfirmedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerPhoneNumberConfirmed {
+
+	unmarshaledData := &CustomerPhoneNumberConfirmedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerPhoneNumberConfirmed(
+		unmarshaledData.CustomerID,
+		unmarshaledData.PhoneNumber,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerPhoneNumberConfirmationFailedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerPhoneNumberConfirmationFailed {
+
+	unmarshaledData := &CustomerPhoneNumberConfirmationFailedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerPhoneNumberConfirmationFailed(
+		unmarshaledData.CustomerID,
+		unmarshaledData.ConfirmationCode,
+		unmarshaledData.Reason,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerReactivatedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerReactivated {
+
+	unmarshaledData := &CustomerReactivatedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerReactivated(
+		unmarshaledData.CustomerID,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
 func unmarshalCustomerDeletedFromJSON(
 	data []byte,
 	streamVersion uint,
@@ -153,6 +419,44 @@ func unmarshalCustomerDeletedFromJSON(
 	return event
 }
 
+func unmarshalCustomerPersonalDataErasedFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerPersonalDataErased {
+
+	unmarshaledData := &CustomerPersonalDataErasedForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerPersonalDataErased(
+		unmarshaledData.CustomerID,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
+func unmarshalCustomerRestoredFromJSON(
+	data []byte,
+	streamVersion uint,
+) domain.CustomerRestored {
+
+	unmarshaledData := &CustomerRestoredForJSON{}
+
+	_ = jsoniter.ConfigFastest.Unmarshal(data, unmarshaledData) // err intentionally ignored - see top comment
+
+	event := domain.RebuildCustomerRestored(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
+		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshaledData.IsEmailAddressConfirmed,
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return event
+}
+
 func unmarshalEventMeta(meta es.EventMetaForJSON, streamVersion uint) es.EventMeta {
 	return es.RebuildEventMeta(
 		meta.EventName,
@@ -162,3 +466,17 @@ func unmarshalEventMeta(meta es.EventMetaForJSON, streamVersion uint) es.EventMe
 		streamVersion,
 	)
 }
+
+// unmarshalConfirmationHashCreatedAt returns the zero time for events recorded before hashes had a creation time.
+func unmarshalConfirmationHashCreatedAt(createdAt string) time.Time {
+	if createdAt == "" {
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
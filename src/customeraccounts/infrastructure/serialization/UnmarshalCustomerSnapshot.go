@@ -0,0 +1,102 @@
+package serialization
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// UnmarshalCustomerSnapshot unmarshals a CustomerSnapshot.
+// Other than for events, invalid json is reported, so that a broken snapshot is ignored and the whole stream is replayed.
+func UnmarshalCustomerSnapshot(_ context.Context, payload []byte, streamVersion uint) (es.DomainEvent, error) {
+	unmarshaledData := &CustomerSnapshotForJSON{}
+
+	if err := jsoniter.ConfigFastest.Unmarshal(payload, unmarshaledData); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, "unmarshalCustomerSnapshot failed"), shared.ErrUnmarshalingFailed)
+	}
+
+	pendingEmailAddress := PendingEmailAddressForJSON{}
+	if unmarshaledData.PendingEmailAddress != nil {
+		pendingEmailAddress = *unmarshaledData.PendingEmailAddress
+	}
+
+	phoneNumber := PhoneNumberForJSON{}
+	if unmarshaledData.PhoneNumber != nil {
+		phoneNumber = *unmarshaledData.PhoneNumber
+	}
+
+	snapshot := domain.RebuildCustomerSnapshot(
+		unmarshaledData.CustomerID,
+		unmarshaledData.EmailAddress,
+		unmarshaledData.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(unmarshaledData.ConfirmationHashCreatedAt),
+		unmarshaledData.IsEmailAddressConfirmed,
+		unmarshaledData.PersonGivenName,
+		unmarshaledData.PersonFamilyName,
+		unmarshalTimestamp(unmarshaledData.RegisteredAt),
+		unmarshaledData.IsDeleted,
+		unmarshalTimestamp(unmarshaledData.DeletedAt),
+		unmarshaledData.IsPersonalDataErased,
+		unmarshaledData.IsSuspended,
+		unmarshaledData.SuspensionReason,
+		pendingEmailAddress.EmailAddress,
+		pendingEmailAddress.ConfirmationHash,
+		unmarshalConfirmationHashCreatedAt(pendingEmailAddress.ConfirmationHashCreatedAt),
+		unmarshalConfirmationFailures(unmarshaledData.ConfirmationFailedAt),
+		unmarshalAddresses(unmarshaledData.Addresses),
+		phoneNumber.PhoneNumber,
+		phoneNumber.ConfirmationCode,
+		unmarshalConfirmationHashCreatedAt(phoneNumber.ConfirmationCodeCreatedAt),
+		phoneNumber.IsPhoneNumberConfirmed,
+		unmarshalConfirmationFailures(phoneNumber.ConfirmationFailedAt),
+		unmarshalEventMeta(unmarshaledData.Meta, streamVersion),
+	)
+
+	return snapshot, nil
+}
+
+func unmarshalConfirmationFailures(confirmationFailedAt []string) []time.Time {
+	var confirmationFailures []time.Time
+
+	for _, failedAt := range confirmationFailedAt {
+		if parsed, err := time.Parse(time.RFC3339Nano, failedAt); err == nil {
+			confirmationFailures = append(confirmationFailures, parsed)
+		}
+	}
+
+	return confirmationFailures
+}
+
+func unmarshalAddresses(addressesForJSON []AddressForJSON) map[value.AddressKind]value.Address {
+	if len(addressesForJSON) == 0 {
+		return nil
+	}
+
+	addresses := make(map[value.AddressKind]value.Address, len(addressesForJSON))
+
+	for _, address := range addressesForJSON {
+		addresses[value.RebuildAddressKind(address.AddressKind)] = value.RebuildAddress(
+			address.Street,
+			address.PostalCode,
+			address.City,
+			address.CountryCode,
+		)
+	}
+
+	return addresses
+}
+
+func unmarshalTimestamp(timestamp string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
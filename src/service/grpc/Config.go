@@ -2,6 +2,8 @@ package grpc
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/cockroachdb/errors"
@@ -15,14 +17,52 @@ type Config struct {
 	GRPC struct {
 		HostAndPort string
 	}
+	Customer struct {
+		SnapshotInterval              uint
+		IdempotencyKeyTTL             time.Duration
+		ConfirmationHashTTL           time.Duration
+		ConfirmationResendInterval    time.Duration
+		ConfirmationMaxFailedAttempts uint
+		ConfirmationLockoutDuration   time.Duration
+		PhoneConfirmationCodeTTL      time.Duration
+		RestoreGracePeriod            time.Duration
+	}
+	Outbox struct {
+		PublisherFilePath string
+	}
+	Email struct {
+		FromAddress     string
+		SMTPHostAndPort string
+		SMTPUsername    string
+		SMTPPassword    string
+		FilePath        string
+	}
+	SMS struct {
+		FilePath string
+	}
 }
 
 // ConfigExpectedEnvKeys - This is also used by Config_test.go to check that all keys exist in Env,
 // so always add new keys here!
 var ConfigExpectedEnvKeys = map[string]string{
-	"postgresDSN":                    "POSTGRES_DSN",
-	"postgresMigrationsPathCustomer": "POSTGRES_MIGRATIONS_PATH_CUSTOMER",
-	"grpcHostAndPort":                "GRPC_HOST_AND_PORT",
+	"postgresDSN":                           "POSTGRES_DSN",
+	"postgresMigrationsPathCustomer":        "POSTGRES_MIGRATIONS_PATH_CUSTOMER",
+	"grpcHostAndPort":                       "GRPC_HOST_AND_PORT",
+	"customerSnapshotInterval":              "CUSTOMER_SNAPSHOT_INTERVAL",
+	"customerIdempotencyKeyTTL":             "CUSTOMER_IDEMPOTENCY_KEY_TTL",
+	"customerConfirmationHashTTL":           "CUSTOMER_CONFIRMATION_HASH_TTL",
+	"customerConfirmationResendInterval":    "CUSTOMER_CONFIRMATION_RESEND_INTERVAL",
+	"customerConfirmationMaxFailedAttempts": "CUSTOMER_CONFIRMATION_MAX_FAILED_ATTEMPTS",
+	"customerConfirmationLockoutDuration":   "CUSTOMER_CONFIRMATION_LOCKOUT_DURATION",
+	"customerPhoneConfirmationCodeTTL":      "CUSTOMER_PHONE_CONFIRMATION_CODE_TTL",
+	"customerRestoreGracePeriod":            "CUSTOMER_RESTORE_GRACE_PERIOD",
+	"outboxPublisherFilePath":               "OUTBOX_PUBLISHER_FILE_PATH",
+	"emailFromAddress":                      "EMAIL_FROM_ADDRESS",
+	"emailSMTPHostAndPort":                  "EMAIL_SMTP_HOST_AND_PORT",
+	"emailSMTPUsername":                     "EMAIL_SMTP_USERNAME",
+	"emailSMTPPassword":                     "EMAIL_SMTP_PASSWORD",
+	"emailFilePath":                         "EMAIL_FILE_PATH",
+	"smsFilePath":                           "SMS_FILE_PATH",
 }
 
 func MustBuildConfigFromEnv(logger *shared.Logger) *Config {
@@ -42,6 +82,66 @@ func MustBuildConfigFromEnv(logger *shared.Logger) *Config {
 		logger.Panic().Msgf(msg, err)
 	}
 
+	if conf.Customer.SnapshotInterval, err = conf.uintFromEnv(ConfigExpectedEnvKeys["customerSnapshotInterval"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.IdempotencyKeyTTL, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerIdempotencyKeyTTL"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.ConfirmationHashTTL, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerConfirmationHashTTL"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.ConfirmationResendInterval, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerConfirmationResendInterval"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.ConfirmationMaxFailedAttempts, err = conf.uintFromEnv(ConfigExpectedEnvKeys["customerConfirmationMaxFailedAttempts"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.ConfirmationLockoutDuration, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerConfirmationLockoutDuration"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.PhoneConfirmationCodeTTL, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerPhoneConfirmationCodeTTL"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Customer.RestoreGracePeriod, err = conf.durationFromEnv(ConfigExpectedEnvKeys["customerRestoreGracePeriod"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Outbox.PublisherFilePath, err = conf.stringFromEnv(ConfigExpectedEnvKeys["outboxPublisherFilePath"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Email.FromAddress, err = conf.stringFromEnv(ConfigExpectedEnvKeys["emailFromAddress"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Email.SMTPHostAndPort, err = conf.stringFromEnv(ConfigExpectedEnvKeys["emailSMTPHostAndPort"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Email.SMTPUsername, err = conf.stringFromEnv(ConfigExpectedEnvKeys["emailSMTPUsername"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Email.SMTPPassword, err = conf.stringFromEnv(ConfigExpectedEnvKeys["emailSMTPPassword"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.Email.FilePath, err = conf.stringFromEnv(ConfigExpectedEnvKeys["emailFilePath"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
+	if conf.SMS.FilePath, err = conf.stringFromEnv(ConfigExpectedEnvKeys["smsFilePath"]); err != nil {
+		logger.Panic().Msgf(msg, err)
+	}
+
 	return conf
 }
 
@@ -53,3 +153,31 @@ func (conf Config) stringFromEnv(envKey string) (string, error) {
 
 	return envVal, nil
 }
+
+func (conf Config) uintFromEnv(envKey string) (uint, error) {
+	envVal, ok := os.LookupEnv(envKey)
+	if !ok {
+		return 0, errors.Mark(errors.Newf("config value [%s] missing in env", envKey), shared.ErrTechnical)
+	}
+
+	uintEnvVal, err := strconv.ParseUint(envVal, 10, 32)
+	if err != nil {
+		return 0, errors.Mark(errors.Newf("config value [%s] is not convertable to unsigned integer", envKey), shared.ErrTechnical)
+	}
+
+	return uint(uintEnvVal), nil
+}
+
+func (conf Config) durationFromEnv(envKey string) (time.Duration, error) {
+	envVal, ok := os.LookupEnv(envKey)
+	if !ok {
+		return 0, errors.Mark(errors.Newf("config value [%s] missing in env", envKey), shared.ErrTechnical)
+	}
+
+	durationEnvVal, err := time.ParseDuration(envVal)
+	if err != nil {
+		return 0, errors.Mark(errors.Newf("config value [%s] is not convertable to a duration", envKey), shared.ErrTechnical)
+	}
+
+	return durationEnvVal, nil
+}
@@ -1,13 +1,20 @@
 package grpc
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer/value"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/email"
 	customergrpc "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc"
 	customergrpcproto "github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/grpc/proto"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/memory"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/postgres"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/sms"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/serialization"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/AntonStoeckl/go-iddd/src/shared/es"
@@ -19,8 +26,51 @@ import (
 const (
 	eventStoreTableName           = "eventstore"
 	uniqueEmailAddressesTableName = "unique_email_addresses"
+	snapshotsTableName            = "snapshots"
+	outboxTableName               = "outbox"
+	outboxRelayBatchSize          = 100
+	outboxRelayMaxAttempts        = 10
+	outboxRelayRetryBackoff       = time.Second
+	outboxRelayMaxRetryBackoff    = time.Hour
+	outboxRelayPollInterval       = time.Second
+	outboxRelayClaimDuration      = time.Minute
+	outboxDeliveredRetention      = 24 * time.Hour
+	checkpointsTableName          = "subscription_checkpoints"
+	customerViewsTableName        = "customer_views"
+	customerViewProjectorID       = "customer-view-projection"
+	customerViewProjectorBatch    = 100
+	customerViewProjectorPoll     = 500 * time.Millisecond
+	idempotencyKeysTableName      = "idempotency_keys"
+	personalDataKeysTableName     = "customer_personal_data_keys"
+	confirmationEmailSubscriberID = "customer-confirmation-emails"
+	confirmationEmailBatch        = 10
+	confirmationEmailPoll         = time.Second
+	confirmationEmailMaxAttempts  = 3
+	confirmationEmailRetryBackoff = time.Second
+
+	confirmationTextMessageSubscriberID = "customer-confirmation-text-messages"
+	confirmationTextMessageBatch        = 10
+	confirmationTextMessagePoll         = time.Second
+	confirmationTextMessageMaxAttempts  = 3
+	confirmationTextMessageRetryBackoff = time.Second
+
+	customerWatchPollInterval = time.Second
 )
 
+// CustomerEventStore is implemented by the postgres and the in-memory adapter.
+type CustomerEventStore interface {
+	RetrieveEventStream(ctx context.Context, id value.CustomerID) (es.EventStream, error)
+	RetrieveFullEventStream(ctx context.Context, id value.CustomerID) (es.EventStream, error)
+	RetrieveEventStreamRange(ctx context.Context, id value.CustomerID, fromVersion uint, maxEvents uint) (es.EventStream, error)
+	StartEventStream(ctx context.Context, customerRegistered domain.CustomerRegistered) error
+	AppendToEventStream(ctx context.Context, recordedEvents es.RecordedEvents, id value.CustomerID) error
+	PurgeEventStream(ctx context.Context, id value.CustomerID) error
+	RetrieveCustomerIDForIdempotencyKey(ctx context.Context, idempotencyKey es.IdempotencyKey) (value.CustomerID, error)
+	RetrieveCustomerIDsDeletedBefore(ctx context.Context, deletedBefore time.Time) ([]value.CustomerID, error)
+	RetrieveUniqueEmailAddresses(ctx context.Context, id value.CustomerID) ([]string, error)
+	RetrieveCustomerIDForEmailAddress(ctx context.Context, emailAddress value.EmailAddress) (value.CustomerID, error)
+}
+
 type DIOption func(container *DIContainer) error
 
 func UsePostgresDBConn(dbConn *sql.DB) DIOption {
@@ -35,6 +85,15 @@ func UsePostgresDBConn(dbConn *sql.DB) DIOption {
 	}
 }
 
+// UseInMemoryCustomerEventStore runs the service without a database. There is no outbox relay and no
+// customer view projector in this mode, Customer views are built directly from the in-memory EventStreams.
+func UseInMemoryCustomerEventStore() DIOption {
+	return func(container *DIContainer) error {
+		container.infra.useInMemoryEventStore = true
+		return nil
+	}
+}
+
 func WithMarshalCustomerEvents(fn es.MarshalDomainEvent) DIOption {
 	return func(container *DIContainer) error {
 		container.dependency.marshalCustomerEvent = fn
@@ -49,6 +108,20 @@ func WithUnmarshalCustomerEvents(fn es.UnmarshalDomainEvent) DIOption {
 	}
 }
 
+func WithMarshalCustomerSnapshots(fn es.MarshalSnapshot) DIOption {
+	return func(container *DIContainer) error {
+		container.dependency.marshalCustomerSnapshot = fn
+		return nil
+	}
+}
+
+func WithUnmarshalCustomerSnapshots(fn es.UnmarshalSnapshot) DIOption {
+	return func(container *DIContainer) error {
+		container.dependency.unmarshalCustomerSnapshot = fn
+		return nil
+	}
+}
+
 func WithBuildUniqueEmailAddressAssertions(fn customer.ForBuildingUniqueEmailAddressAssertions) DIOption {
 	return func(container *DIContainer) error {
 		container.dependency.buildUniqueEmailAddressAssertions = fn
@@ -56,6 +129,27 @@ func WithBuildUniqueEmailAddressAssertions(fn customer.ForBuildingUniqueEmailAdd
 	}
 }
 
+func WithPublishOutboxMessages(fn es.ForPublishingOutboxMessages) DIOption {
+	return func(container *DIContainer) error {
+		container.dependency.publishOutboxMessage = fn
+		return nil
+	}
+}
+
+func WithSendConfirmationEmails(fn application.ForSendingConfirmationEmails) DIOption {
+	return func(container *DIContainer) error {
+		container.dependency.sendConfirmationEmail = fn
+		return nil
+	}
+}
+
+func WithSendConfirmationTextMessages(fn application.ForSendingConfirmationTextMessages) DIOption {
+	return func(container *DIContainer) error {
+		container.dependency.sendConfirmationTextMessage = fn
+		return nil
+	}
+}
+
 func ReplaceGRPCCustomerServer(server customergrpcproto.CustomerServer) DIOption {
 	return func(container *DIContainer) error {
 		if server == nil {
@@ -70,22 +164,44 @@ func ReplaceGRPCCustomerServer(server customergrpcproto.CustomerServer) DIOption
 
 type DIContainer struct {
 	config *Config
+	logger *shared.Logger
 
 	infra struct {
-		pgDBConn *sql.DB
+		pgDBConn              *sql.DB
+		useInMemoryEventStore bool
 	}
 
 	dependency struct {
 		marshalCustomerEvent              es.MarshalDomainEvent
 		unmarshalCustomerEvent            es.UnmarshalDomainEvent
+		marshalCustomerSnapshot           es.MarshalSnapshot
+		unmarshalCustomerSnapshot         es.UnmarshalSnapshot
 		buildUniqueEmailAddressAssertions customer.ForBuildingUniqueEmailAddressAssertions
+		publishOutboxMessage              es.ForPublishingOutboxMessages
+		sendConfirmationEmail             application.ForSendingConfirmationEmails
+		sendConfirmationTextMessage       application.ForSendingConfirmationTextMessages
 	}
 
 	service struct {
 		eventStore             *es.EventStore
+		snapshotStore          *es.SnapshotStore
+		outbox                 *es.Outbox
+		outboxRelay            *es.OutboxRelay
+		checkpointStore        *es.CheckpointStore
+		idempotencyKeyStore    *es.IdempotencyKeyStore
+		personalDataKeys       *postgres.CustomerPersonalDataKeys
+		customerViewProjection *postgres.CustomerViewProjection
+		customerViewProjector  *es.Subscription
+		confirmationEmails     *application.CustomerConfirmationEmailHandler
+		confirmationEmailer    *es.Subscription
+		confirmationTexts      *application.CustomerConfirmationTextMessageHandler
+		confirmationTexter     *es.Subscription
 		customerEventStore     *postgres.CustomerEventStore
+		inMemoryEventStore     *memory.CustomerEventStore
 		customerCommandHandler *application.CustomerCommandHandler
 		customerQueryHandler   *application.CustomerQueryHandler
+		customerPurger         *application.CustomerPurger
+		customerStreamNotifier *es.StreamNotifier
 		grpcCustomerServer     customergrpcproto.CustomerServer
 		grpcServer             *grpc.Server
 	}
@@ -94,11 +210,39 @@ type DIContainer struct {
 func MustBuildDIContainer(config *Config, logger *shared.Logger, opts ...DIOption) *DIContainer {
 	container := &DIContainer{}
 	container.config = config
+	container.logger = logger
 
 	/*** Define default dependencies ***/
 	container.dependency.marshalCustomerEvent = serialization.MarshalCustomerEvent
 	container.dependency.unmarshalCustomerEvent = serialization.UnmarshalCustomerEvent
+	container.dependency.marshalCustomerSnapshot = serialization.MarshalCustomerSnapshot
+	container.dependency.unmarshalCustomerSnapshot = serialization.UnmarshalCustomerSnapshot
 	container.dependency.buildUniqueEmailAddressAssertions = customer.BuildUniqueEmailAddressAssertions
+	container.dependency.publishOutboxMessage = es.NewInMemoryPublisher().Publish
+
+	if config.Outbox.PublisherFilePath != "" {
+		container.dependency.publishOutboxMessage = es.NewFilePublisher(config.Outbox.PublisherFilePath).Publish
+	}
+
+	switch {
+	case config.Email.SMTPHostAndPort != "":
+		container.dependency.sendConfirmationEmail = email.NewSMTPSender(
+			config.Email.FromAddress,
+			config.Email.SMTPHostAndPort,
+			config.Email.SMTPUsername,
+			config.Email.SMTPPassword,
+		).SendConfirmationEmail
+	case config.Email.FilePath != "":
+		container.dependency.sendConfirmationEmail = email.NewFileSender(config.Email.FromAddress, config.Email.FilePath).SendConfirmationEmail
+	default:
+		container.dependency.sendConfirmationEmail = email.NewInMemorySender(config.Email.FromAddress).SendConfirmationEmail
+	}
+
+	if config.SMS.FilePath != "" {
+		container.dependency.sendConfirmationTextMessage = sms.NewFileSender(config.SMS.FilePath).SendConfirmationTextMessage
+	} else {
+		container.dependency.sendConfirmationTextMessage = sms.NewInMemorySender().SendConfirmationTextMessage
+	}
 
 	/*** Apply options for infra, dependencies, services ***/
 	for _, opt := range opts {
@@ -113,8 +257,27 @@ func MustBuildDIContainer(config *Config, logger *shared.Logger, opts ...DIOptio
 }
 
 func (container *DIContainer) init() {
+	if container.infra.useInMemoryEventStore {
+		_ = container.getInMemoryCustomerEventStore()
+		_ = container.GetCustomerCommandHandler()
+		_ = container.GetCustomerQueryHandler()
+		_ = container.getGRPCCustomerServer()
+		_ = container.GetGRPCServer()
+
+		return
+	}
+
 	_ = container.getEventStore()
+	_ = container.getSnapshotStore()
+	_ = container.getOutbox()
+	_ = container.GetOutboxRelay()
+	_ = container.getCheckpointStore()
+	_ = container.GetIdempotencyKeyStore()
 	_ = container.GetCustomerEventStore()
+	_ = container.GetCustomerViewProjection()
+	_ = container.GetCustomerViewProjector()
+	_ = container.GetConfirmationEmailer()
+	_ = container.GetConfirmationTexter()
 	_ = container.GetCustomerCommandHandler()
 	_ = container.GetCustomerQueryHandler()
 	_ = container.getGRPCCustomerServer()
@@ -129,15 +292,140 @@ func (container *DIContainer) getEventStore() *es.EventStore {
 	if container.service.eventStore == nil {
 		container.service.eventStore = es.NewEventStore(
 			eventStoreTableName,
-			container.dependency.marshalCustomerEvent,
-			container.dependency.unmarshalCustomerEvent,
+			serialization.EncryptingPersonalData(
+				container.dependency.marshalCustomerEvent,
+				container.getPersonalDataKeys().RetrieveOrCreateKey,
+			),
+			serialization.DecryptingPersonalData(
+				container.dependency.unmarshalCustomerEvent,
+				container.getPersonalDataKeys().RetrieveKey,
+			),
 		)
 	}
 
 	return container.service.eventStore
 }
 
-func (container *DIContainer) GetCustomerEventStore() *postgres.CustomerEventStore {
+func (container *DIContainer) getSnapshotStore() *es.SnapshotStore {
+	if container.service.snapshotStore == nil {
+		container.service.snapshotStore = es.NewSnapshotStore(
+			snapshotsTableName,
+			customer.SnapshotFormatVersion,
+			serialization.EncryptingPersonalDataInSnapshots(
+				container.dependency.marshalCustomerSnapshot,
+				container.getPersonalDataKeys().RetrieveOrCreateKey,
+			),
+			serialization.DecryptingPersonalDataInSnapshots(
+				container.dependency.unmarshalCustomerSnapshot,
+				container.getPersonalDataKeys().RetrieveKey,
+			),
+		)
+	}
+
+	return container.service.snapshotStore
+}
+
+func (container *DIContainer) getPersonalDataKeys() *postgres.CustomerPersonalDataKeys {
+	if container.service.personalDataKeys == nil {
+		container.service.personalDataKeys = postgres.NewCustomerPersonalDataKeys(
+			container.infra.pgDBConn,
+			personalDataKeysTableName,
+		)
+	}
+
+	return container.service.personalDataKeys
+}
+
+// getOutbox encrypts the personal data like the event store does, it is decrypted when the messages are published.
+func (container *DIContainer) getOutbox() *es.Outbox {
+	if container.service.outbox == nil {
+		container.service.outbox = es.NewOutbox(
+			outboxTableName,
+			serialization.EncryptingPersonalData(
+				container.dependency.marshalCustomerEvent,
+				container.getPersonalDataKeys().RetrieveOrCreateKey,
+			),
+		)
+	}
+
+	return container.service.outbox
+}
+
+// GetOutboxRelay returns nil when the in-memory event store is used.
+func (container *DIContainer) GetOutboxRelay() *es.OutboxRelay {
+	if container.infra.useInMemoryEventStore {
+		return nil
+	}
+
+	if container.service.outboxRelay == nil {
+		container.service.outboxRelay = es.NewOutboxRelay(
+			container.infra.pgDBConn,
+			container.getOutbox().ClaimPendingMessages,
+			container.getOutbox().MarkMessageAsDelivered,
+			container.getOutbox().MarkMessageAsFailed,
+			container.getOutbox().PruneDeliveredMessages,
+			serialization.DecryptingPersonalDataInOutboxMessages(
+				container.dependency.publishOutboxMessage,
+				container.getPersonalDataKeys().RetrieveKey,
+			),
+			outboxRelayBatchSize,
+			outboxRelayMaxAttempts,
+			outboxRelayRetryBackoff,
+			outboxRelayMaxRetryBackoff,
+			outboxRelayPollInterval,
+			outboxRelayClaimDuration,
+			outboxDeliveredRetention,
+			container.logger,
+		)
+	}
+
+	return container.service.outboxRelay
+}
+
+func (container *DIContainer) getCheckpointStore() *es.CheckpointStore {
+	if container.service.checkpointStore == nil {
+		container.service.checkpointStore = es.NewCheckpointStore(checkpointsTableName)
+	}
+
+	return container.service.checkpointStore
+}
+
+// GetIdempotencyKeyStore returns nil when the in-memory event store is used.
+func (container *DIContainer) GetIdempotencyKeyStore() *es.IdempotencyKeyStore {
+	if container.infra.useInMemoryEventStore {
+		return nil
+	}
+
+	if container.service.idempotencyKeyStore == nil {
+		container.service.idempotencyKeyStore = es.NewIdempotencyKeyStore(
+			idempotencyKeysTableName,
+			container.config.Customer.IdempotencyKeyTTL,
+		)
+	}
+
+	return container.service.idempotencyKeyStore
+}
+
+func (container *DIContainer) GetCustomerEventStore() CustomerEventStore {
+	if container.infra.useInMemoryEventStore {
+		return container.getInMemoryCustomerEventStore()
+	}
+
+	return container.getPostgresCustomerEventStore()
+}
+
+func (container *DIContainer) getInMemoryCustomerEventStore() *memory.CustomerEventStore {
+	if container.service.inMemoryEventStore == nil {
+		container.service.inMemoryEventStore = memory.NewCustomerEventStore(
+			container.dependency.buildUniqueEmailAddressAssertions,
+			container.config.Customer.IdempotencyKeyTTL,
+		)
+	}
+
+	return container.service.inMemoryEventStore
+}
+
+func (container *DIContainer) getPostgresCustomerEventStore() *postgres.CustomerEventStore {
 	if container.service.customerEventStore == nil {
 		uniqueCustomerEmailAddresses := postgres.NewUniqueCustomerEmailAddresses(
 			uniqueEmailAddressesTableName,
@@ -148,46 +436,322 @@ func (container *DIContainer) GetCustomerEventStore() *postgres.CustomerEventSto
 			container.infra.pgDBConn,
 			container.getEventStore().RetrieveEventStream,
 			container.getEventStore().AppendEventsToStream,
+			container.getOutbox().AddToOutbox,
 			container.getEventStore().PurgeEventStream,
+			container.getOutbox().PurgeMessages,
 			uniqueCustomerEmailAddresses.AssertUniqueEmailAddress,
 			uniqueCustomerEmailAddresses.PurgeUniqueEmailAddress,
+			uniqueCustomerEmailAddresses.RetrieveUniqueEmailAddresses,
+			uniqueCustomerEmailAddresses.RetrieveCustomerIDForEmailAddress,
+			func(ctx context.Context, id value.CustomerID, tx *sql.Tx) error { // lazy, the projection depends on the event store
+				return container.GetCustomerViewProjection().PurgeView(ctx, id, tx)
+			},
+			container.getSnapshotStore().RetrieveSnapshot,
+			container.getSnapshotStore().SaveSnapshot,
+			container.getSnapshotStore().PurgeSnapshot,
+			container.GetIdempotencyKeyStore().RecordIdempotencyKey,
+			container.GetIdempotencyKeyStore().RetrieveStreamIDForIdempotencyKey,
+			container.GetIdempotencyKeyStore().PurgeIdempotencyKeys,
+			container.getEventStore().RetrieveStreamIDsWithEventNotFollowedBy,
+			customer.BuildSnapshotFrom,
+			container.config.Customer.SnapshotInterval,
+			container.logger,
 		)
 	}
 
 	return container.service.customerEventStore
 }
 
+func (container *DIContainer) GetCustomerViewProjection() *postgres.CustomerViewProjection {
+	if container.service.customerViewProjection == nil {
+		container.service.customerViewProjection = postgres.NewCustomerViewProjection(
+			container.infra.pgDBConn,
+			customerViewsTableName,
+			container.getPostgresCustomerEventStore().RetrieveEventStream,
+		)
+	}
+
+	return container.service.customerViewProjection
+}
+
+// GetCustomerViewProjector returns nil when the in-memory event store is used.
+func (container *DIContainer) GetCustomerViewProjector() *es.Subscription {
+	if container.infra.useInMemoryEventStore {
+		return nil
+	}
+
+	if container.service.customerViewProjector == nil {
+		container.service.customerViewProjector = es.NewSubscription(
+			customerViewProjectorID,
+			container.infra.pgDBConn,
+			container.getEventStore().RetrieveGlobalEventStream,
+			container.getCheckpointStore().RetrieveCheckpoint,
+			container.getCheckpointStore().SaveCheckpoint,
+			container.getCheckpointStore().ResetCheckpoint,
+			container.getEventStore().CountGlobalEventsAfter,
+			container.GetCustomerViewProjection().ProjectEvent,
+			customerViewProjectorBatch,
+			customerViewProjectorPoll,
+			container.logger,
+		)
+	}
+
+	return container.service.customerViewProjector
+}
+
+func (container *DIContainer) getConfirmationEmails() *application.CustomerConfirmationEmailHandler {
+	if container.service.confirmationEmails == nil {
+		container.service.confirmationEmails = application.NewCustomerConfirmationEmailHandler(
+			container.dependency.sendConfirmationEmail,
+			confirmationEmailMaxAttempts,
+			confirmationEmailRetryBackoff,
+		)
+	}
+
+	return container.service.confirmationEmails
+}
+
+// GetConfirmationEmailer returns nil when the in-memory event store is used.
+func (container *DIContainer) GetConfirmationEmailer() *es.Subscription {
+	if container.infra.useInMemoryEventStore {
+		return nil
+	}
+
+	if container.service.confirmationEmailer == nil {
+		container.service.confirmationEmailer = es.NewSubscription(
+			confirmationEmailSubscriberID,
+			container.infra.pgDBConn,
+			container.getEventStore().RetrieveGlobalEventStream,
+			container.getCheckpointStore().RetrieveCheckpoint,
+			container.getCheckpointStore().SaveCheckpoint,
+			container.getCheckpointStore().ResetCheckpoint,
+			container.getEventStore().CountGlobalEventsAfter,
+			func(ctx context.Context, globalEvent es.GlobalEvent) error {
+				return container.getConfirmationEmails().HandleEvent(ctx, globalEvent.Event())
+			},
+			confirmationEmailBatch,
+			confirmationEmailPoll,
+			container.logger,
+		)
+	}
+
+	return container.service.confirmationEmailer
+}
+
+func (container *DIContainer) getConfirmationTexts() *application.CustomerConfirmationTextMessageHandler {
+	if container.service.confirmationTexts == nil {
+		container.service.confirmationTexts = application.NewCustomerConfirmationTextMessageHandler(
+			container.dependency.sendConfirmationTextMessage,
+			confirmationTextMessageMaxAttempts,
+			confirmationTextMessageRetryBackoff,
+		)
+	}
+
+	return container.service.confirmationTexts
+}
+
+// GetConfirmationTexter returns nil when the in-memory event store is used.
+func (container *DIContainer) GetConfirmationTexter() *es.Subscription {
+	if container.infra.useInMemoryEventStore {
+		return nil
+	}
+
+	if container.service.confirmationTexter == nil {
+		container.service.confirmationTexter = es.NewSubscription(
+			confirmationTextMessageSubscriberID,
+			container.infra.pgDBConn,
+			container.getEventStore().RetrieveGlobalEventStream,
+			container.getCheckpointStore().RetrieveCheckpoint,
+			container.getCheckpointStore().SaveCheckpoint,
+			container.getCheckpointStore().ResetCheckpoint,
+			container.getEventStore().CountGlobalEventsAfter,
+			func(ctx context.Context, globalEvent es.GlobalEvent) error {
+				return container.getConfirmationTexts().HandleEvent(ctx, globalEvent.Event())
+			},
+			confirmationTextMessageBatch,
+			confirmationTextMessagePoll,
+			container.logger,
+		)
+	}
+
+	return container.service.confirmationTexter
+}
+
 func (container *DIContainer) GetCustomerCommandHandler() *application.CustomerCommandHandler {
 	if container.service.customerCommandHandler == nil {
+		startEventStream := container.GetCustomerEventStore().StartEventStream
+		appendToEventStream := container.GetCustomerEventStore().AppendToEventStream
+
+		if container.infra.useInMemoryEventStore {
+			startEventStream, appendToEventStream = container.sendingConfirmationsDirectly(startEventStream, appendToEventStream)
+		}
+
+		appendToEventStream = container.notifyingCustomerWatchers(appendToEventStream)
+
+		deletePersonalDataKey := container.getPersonalDataKeys().DeleteKey
+
+		if container.infra.useInMemoryEventStore {
+			// the in-memory event store does not serialize the events, so the personal data is not encrypted there
+			deletePersonalDataKey = func(ctx context.Context, id value.CustomerID) error {
+				return nil
+			}
+		}
+
 		container.service.customerCommandHandler = application.NewCustomerCommandHandler(
 			container.GetCustomerEventStore().RetrieveEventStream,
-			container.GetCustomerEventStore().StartEventStream,
-			container.GetCustomerEventStore().AppendToEventStream,
+			startEventStream,
+			appendToEventStream,
+			container.GetCustomerEventStore().RetrieveCustomerIDForIdempotencyKey,
+			deletePersonalDataKey,
+			container.config.Customer.ConfirmationHashTTL,
+			container.config.Customer.ConfirmationResendInterval,
+			container.config.Customer.ConfirmationMaxFailedAttempts,
+			container.config.Customer.ConfirmationLockoutDuration,
+			container.config.Customer.PhoneConfirmationCodeTTL,
+			container.config.Customer.RestoreGracePeriod,
 		)
 	}
 
 	return container.service.customerCommandHandler
 }
 
+func (container *DIContainer) getCustomerStreamNotifier() *es.StreamNotifier {
+	if container.service.customerStreamNotifier == nil {
+		container.service.customerStreamNotifier = es.NewStreamNotifier()
+	}
+
+	return container.service.customerStreamNotifier
+}
+
+// notifyingCustomerWatchers wakes up the watchers of a Customer in this process once events were appended to her stream.
+func (container *DIContainer) notifyingCustomerWatchers(
+	appendToEventStream application.ForAppendingToCustomerEventStreams,
+) application.ForAppendingToCustomerEventStreams {
+
+	return func(ctx context.Context, recordedEvents es.RecordedEvents, id value.CustomerID) error {
+		if err := appendToEventStream(ctx, recordedEvents, id); err != nil {
+			return err
+		}
+
+		container.getCustomerStreamNotifier().Notify(es.BuildStreamID(id.String()))
+
+		return nil
+	}
+}
+
+func (container *DIContainer) watchCustomerEventStream(ctx context.Context, id value.CustomerID) <-chan struct{} {
+	return container.getCustomerStreamNotifier().Watch(ctx, es.BuildStreamID(id.String()))
+}
+
+// sendingConfirmationsDirectly is for the in-memory event store, which has no global event log to subscribe to.
+// Failing to send a confirmation must not fail the command once the events are stored, so it's only logged.
+func (container *DIContainer) sendingConfirmationsDirectly(
+	startEventStream application.ForStartingCustomerEventStreams,
+	appendToEventStream application.ForAppendingToCustomerEventStreams,
+) (application.ForStartingCustomerEventStreams, application.ForAppendingToCustomerEventStreams) {
+
+	sendConfirmations := func(ctx context.Context, recordedEvents ...es.DomainEvent) {
+		for _, event := range recordedEvents {
+			if err := container.getConfirmationEmails().HandleEvent(ctx, event); err != nil {
+				container.logger.Warn().Msgf("failed to send confirmation email: %s", err)
+			}
+
+			if err := container.getConfirmationTexts().HandleEvent(ctx, event); err != nil {
+				container.logger.Warn().Msgf("failed to send confirmation text message: %s", err)
+			}
+		}
+	}
+
+	startEventStreamAndSendConfirmations := func(ctx context.Context, customerRegistered domain.CustomerRegistered) error {
+		if err := startEventStream(ctx, customerRegistered); err != nil {
+			return err
+		}
+
+		sendConfirmations(ctx, customerRegistered)
+
+		return nil
+	}
+
+	appendToEventStreamAndSendConfirmations := func(ctx context.Context, recordedEvents es.RecordedEvents, id value.CustomerID) error {
+		if err := appendToEventStream(ctx, recordedEvents, id); err != nil {
+			return err
+		}
+
+		sendConfirmations(ctx, recordedEvents...)
+
+		return nil
+	}
+
+	return startEventStreamAndSendConfirmations, appendToEventStreamAndSendConfirmations
+}
+
 func (container *DIContainer) GetCustomerQueryHandler() *application.CustomerQueryHandler {
 	if container.service.customerQueryHandler == nil {
+		var retrieveView application.ForRetrievingProjectedCustomerViews
+		var searchViews application.ForSearchingProjectedCustomerViews
+
+		if container.infra.useInMemoryEventStore {
+			retrieveView = container.getInMemoryCustomerEventStore().RetrieveView
+			searchViews = container.getInMemoryCustomerEventStore().SearchViews
+		} else {
+			retrieveView = container.GetCustomerViewProjection().RetrieveView
+			searchViews = container.GetCustomerViewProjection().SearchViews
+		}
+
 		container.service.customerQueryHandler = application.NewCustomerQueryHandler(
+			retrieveView,
+			searchViews,
 			container.GetCustomerEventStore().RetrieveEventStream,
+			container.GetCustomerEventStore().RetrieveFullEventStream,
+			container.GetCustomerEventStore().RetrieveEventStreamRange,
+			container.GetCustomerEventStore().RetrieveUniqueEmailAddresses,
+			container.GetCustomerEventStore().RetrieveCustomerIDForEmailAddress,
+			container.watchCustomerEventStream,
+			customerWatchPollInterval,
 		)
 	}
 
 	return container.service.customerQueryHandler
 }
 
+func (container *DIContainer) GetCustomerPurger() *application.CustomerPurger {
+	if container.service.customerPurger == nil {
+		container.service.customerPurger = application.NewCustomerPurger(
+			container.GetCustomerEventStore().RetrieveCustomerIDsDeletedBefore,
+			container.GetCustomerEventStore().PurgeEventStream,
+			container.config.Customer.RestoreGracePeriod,
+		)
+	}
+
+	return container.service.customerPurger
+}
+
 func (container *DIContainer) getGRPCCustomerServer() customergrpcproto.CustomerServer {
 	if container.service.grpcCustomerServer == nil {
 		container.service.grpcCustomerServer = customergrpc.NewCustomerServer(
 			container.GetCustomerCommandHandler().RegisterCustomer,
 			container.GetCustomerCommandHandler().ConfirmCustomerEmailAddress,
+			container.GetCustomerCommandHandler().RequestCustomerEmailAddressConfirmationResend,
 			container.GetCustomerCommandHandler().ChangeCustomerEmailAddress,
+			container.GetCustomerCommandHandler().CancelCustomerEmailAddressChange,
 			container.GetCustomerCommandHandler().ChangeCustomerName,
+			container.GetCustomerCommandHandler().AddCustomerAddress,
+			container.GetCustomerCommandHandler().ChangeCustomerAddress,
+			container.GetCustomerCommandHandler().RemoveCustomerAddress,
+			container.GetCustomerCommandHandler().ChangeCustomerPhoneNumber,
+			container.GetCustomerCommandHandler().ConfirmCustomerPhoneNumber,
+			container.GetCustomerCommandHandler().SuspendCustomer,
+			container.GetCustomerCommandHandler().ReactivateCustomer,
 			container.GetCustomerCommandHandler().DeleteCustomer,
+			container.GetCustomerCommandHandler().RestoreCustomer,
+			container.GetCustomerCommandHandler().ErasePersonalData,
 			container.GetCustomerQueryHandler().CustomerViewByID,
+			container.GetCustomerQueryHandler().CustomerViewByIDAsOf,
+			container.GetCustomerQueryHandler().CustomerViewByEmailAddress,
+			container.GetCustomerQueryHandler().ExportCustomerData,
+			container.GetCustomerQueryHandler().CustomerEventHistory,
+			container.GetCustomerQueryHandler().SearchCustomerViews,
+			container.GetCustomerQueryHandler().WatchCustomer,
 		)
 	}
 
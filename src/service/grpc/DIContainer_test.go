@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -11,11 +12,11 @@ import (
 )
 
 func TestNewDIContainer(t *testing.T) {
-	marshalDomainEvent := func(event es.DomainEvent) ([]byte, error) {
+	marshalDomainEvent := func(ctx context.Context, event es.DomainEvent) ([]byte, error) {
 		return nil, nil
 	}
 
-	unmarshalDomainEvent := func(name string, payload []byte, streamVersion uint) (es.DomainEvent, error) {
+	unmarshalDomainEvent := func(ctx context.Context, name string, payload []byte, streamVersion uint) (es.DomainEvent, error) {
 		return nil, nil
 	}
 
@@ -67,4 +68,24 @@ func TestNewDIContainer(t *testing.T) {
 			So(callback, ShouldPanic)
 		})
 	})
+
+	Convey("When a DIContainer is created with the in-memory event store", t, func() {
+		logger := shared.NewNilLogger()
+		config := MustBuildConfigFromEnv(logger)
+
+		var container *DIContainer
+
+		callback := func() {
+			container = MustBuildDIContainer(config, logger, UseInMemoryCustomerEventStore())
+		}
+
+		Convey("Then it should succeed without a postgres DB connection and without background workers", func() {
+			So(callback, ShouldNotPanic)
+			So(container.GetCustomerEventStore(), ShouldNotBeNil)
+			So(container.GetOutboxRelay(), ShouldBeNil)
+			So(container.GetCustomerViewProjector(), ShouldBeNil)
+			So(container.GetConfirmationEmailer(), ShouldBeNil)
+			So(container.GetConfirmationTexter(), ShouldBeNil)
+		})
+	})
 }
@@ -1,8 +1,11 @@
 package grpc
 
 import (
+	"context"
 	"database/sql"
 
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application/domain/customer"
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/postgres"
 	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/infrastructure/adapter/postgres/database"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 )
@@ -38,5 +41,27 @@ func MustInitPostgresDB(config *Config, logger *shared.Logger) *sql.DB {
 		logger.Panic().Msgf("bootstrapPostgresDB: failed to run DB migrations for customer: %s", err)
 	}
 
+	logger.Info().Msg("bootstrapPostgresDB: canonicalizing unique email addresses for customer ...")
+
+	uniqueCustomerEmailAddresses := postgres.NewUniqueCustomerEmailAddresses(
+		uniqueEmailAddressesTableName,
+		customer.BuildUniqueEmailAddressAssertions,
+	)
+
+	err = uniqueCustomerEmailAddresses.CanonicalizeEmailAddresses(context.Background(), eventStoreTableName, postgresDBConn)
+	if err != nil {
+		logger.Panic().Msgf("bootstrapPostgresDB: failed to canonicalize unique email addresses for customer: %s", err)
+	}
+
+	logger.Info().Msg("bootstrapPostgresDB: canonicalizing email addresses of customer views ...")
+
+	// no event streams are needed to canonicalize the email addresses
+	customerViewProjection := postgres.NewCustomerViewProjection(postgresDBConn, customerViewsTableName, nil)
+
+	err = customerViewProjection.CanonicalizeEmailAddresses(context.Background())
+	if err != nil {
+		logger.Panic().Msgf("bootstrapPostgresDB: failed to canonicalize email addresses of customer views: %s", err)
+	}
+
 	return postgresDBConn
 }
@@ -1,12 +1,21 @@
 package grpc
 
 import (
+	"context"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/AntonStoeckl/go-iddd/src/customeraccounts/hexagon/application"
 	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+)
+
+const (
+	idempotencyKeyPurgeInterval  = time.Hour
+	deletedCustomerPurgeInterval = time.Hour
 )
 
 type Service struct {
@@ -14,6 +23,7 @@ type Service struct {
 	logger       *shared.Logger
 	diContainter *DIContainer
 	exitFn       func()
+	stopWorkers  context.CancelFunc
 }
 
 func InitService(
@@ -49,6 +59,96 @@ func (s *Service) StartGRPCServer() {
 	}
 }
 
+func (s *Service) StartBackgroundWorkers() {
+	var ctx context.Context
+	ctx, s.stopWorkers = context.WithCancel(context.Background())
+
+	if outboxRelay := s.diContainter.GetOutboxRelay(); outboxRelay != nil {
+		s.logger.Info().Msg("starting outbox relay ...")
+		go outboxRelay.Run(ctx)
+	}
+
+	if customerViewProjector := s.diContainter.GetCustomerViewProjector(); customerViewProjector != nil {
+		s.logger.Info().Msg("starting customer view projector ...")
+		go customerViewProjector.Run(ctx)
+	}
+
+	if confirmationEmailer := s.diContainter.GetConfirmationEmailer(); confirmationEmailer != nil {
+		s.logger.Info().Msg("starting confirmation emailer ...")
+		go confirmationEmailer.Run(ctx)
+	}
+
+	if confirmationTexter := s.diContainter.GetConfirmationTexter(); confirmationTexter != nil {
+		s.logger.Info().Msg("starting confirmation texter ...")
+		go confirmationTexter.Run(ctx)
+	}
+
+	if idempotencyKeyStore := s.diContainter.GetIdempotencyKeyStore(); idempotencyKeyStore != nil {
+		s.logger.Info().Msg("starting idempotency key purger ...")
+		go s.purgeExpiredIdempotencyKeys(ctx, idempotencyKeyStore)
+	}
+
+	s.logger.Info().Msg("starting deleted customer purger ...")
+	go s.purgeDeletedCustomers(ctx, s.diContainter.GetCustomerPurger())
+}
+
+// purgeExpiredIdempotencyKeys is only housekeeping, expired keys are ignored anyway.
+func (s *Service) purgeExpiredIdempotencyKeys(ctx context.Context, idempotencyKeyStore *es.IdempotencyKeyStore) {
+	ticker := time.NewTicker(idempotencyKeyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := idempotencyKeyStore.PurgeExpiredIdempotencyKeys(ctx, s.diContainter.GetPostgresDBConn()); err != nil {
+			s.logger.Warn().Msgf("failed to purge expired idempotency keys: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeDeletedCustomers hard-purges Customers which were deleted and not restored within the grace period.
+func (s *Service) purgeDeletedCustomers(ctx context.Context, customerPurger *application.CustomerPurger) {
+	ticker := time.NewTicker(deletedCustomerPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := customerPurger.PurgeDeletedCustomers(ctx); err != nil {
+			s.logger.Warn().Msgf("failed to purge deleted customers: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RebuildCustomerViewProjection rebuilds the customer_views from scratch by replaying the global event log.
+func (s *Service) RebuildCustomerViewProjection(ctx context.Context) error {
+	s.logger.Info().Msg("rebuilding customer view projection ...")
+
+	if err := s.diContainter.GetCustomerViewProjector().Reset(ctx); err != nil {
+		return err
+	}
+
+	if err := s.diContainter.GetCustomerViewProjection().DeleteAllViews(ctx); err != nil {
+		return err
+	}
+
+	if err := s.diContainter.GetCustomerViewProjector().CatchUp(ctx); err != nil {
+		return err
+	}
+
+	s.logger.Info().Msg("rebuilding customer view projection finished")
+
+	return nil
+}
+
 func (s *Service) WaitForStopSignal() {
 	s.logger.Info().Msg("start waiting for stop signal ...")
 
@@ -67,6 +167,11 @@ func (s *Service) WaitForStopSignal() {
 func (s *Service) shutdown() {
 	s.logger.Info().Msg("shutdown: stopping services ...")
 
+	if s.stopWorkers != nil {
+		s.logger.Info().Msg("shutdown: stopping background workers ...")
+		s.stopWorkers()
+	}
+
 	grpcServer := s.diContainter.GetGRPCServer()
 	if grpcServer != nil {
 		s.logger.Info().Msg("shutdown: stopping gRPC server gracefully ...")
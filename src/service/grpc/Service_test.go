@@ -86,24 +86,75 @@ func TestStartGRPCServer(t *testing.T) {
 
 func grpcCustomerServerStub() customergrpcproto.CustomerServer {
 	customerServer := customergrpc.NewCustomerServer(
-		func(customerIDValue value.CustomerID, emailAddress, givenName, familyName string) error {
-			return nil
+		func(ctx context.Context, customerIDValue value.CustomerID, emailAddress, givenName, familyName string) (value.CustomerID, error) {
+			return customerIDValue, nil
 		},
-		func(customerID, confirmationHash string) error {
-			return nil
+		func(ctx context.Context, customerID, confirmationHash string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID, emailAddress string) error {
-			return nil
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID, givenName, familyName string) error {
-			return nil
+		func(ctx context.Context, customerID, emailAddress string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID string) error {
-			return nil
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, givenName, familyName string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, addressKind, street, postalCode, city, countryCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, addressKind string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, phoneNumber string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, confirmationCode string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID, reason string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
 		},
-		func(customerID string) (customer.View, error) {
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string, expectedVersion uint) (uint, error) {
+			return 1, nil
+		},
+		func(ctx context.Context, customerID string) (customer.View, error) {
+			return customer.View{}, nil
+		},
+		func(ctx context.Context, customerID string, asOfVersion uint, asOfTime string) (customer.View, error) {
+			return customer.View{}, nil
+		},
+		func(ctx context.Context, emailAddress string) (customer.View, error) {
 			return customer.View{}, nil
 		},
+		func(ctx context.Context, customerID string) (customer.Export, error) {
+			return customer.Export{}, nil
+		},
+		func(ctx context.Context, customerID string, fromVersion, maxEvents uint) (customer.EventHistoryPage, error) {
+			return customer.EventHistoryPage{}, nil
+		},
+		func(ctx context.Context, criteria customer.ViewSearchCriteria) (customer.ViewPage, error) {
+			return customer.ViewPage{}, nil
+		},
+		func(ctx context.Context, customerID string, afterVersion uint, push func(event customer.HistoricEvent) error) error {
+			return nil
+		},
 	)
 
 	return customerServer
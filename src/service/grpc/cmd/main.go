@@ -12,14 +12,19 @@ func main() {
 	stdLogger := shared.NewStandardLogger()
 	config := grpc.MustBuildConfigFromEnv(stdLogger)
 	exitFn := func() { os.Exit(1) }
-	postgresDBConn := grpc.MustInitPostgresDB(config, stdLogger)
-	diContainer := grpc.MustBuildDIContainer(
-		config,
-		stdLogger,
-		grpc.UsePostgresDBConn(postgresDBConn),
-	)
+	var diOption grpc.DIOption
+
+	if config.Postgres.DSN == "" {
+		stdLogger.Warn().Msg("no Postgres DSN configured, using the in-memory event store ...")
+		diOption = grpc.UseInMemoryCustomerEventStore()
+	} else {
+		diOption = grpc.UsePostgresDBConn(grpc.MustInitPostgresDB(config, stdLogger))
+	}
+
+	diContainer := grpc.MustBuildDIContainer(config, stdLogger, diOption)
 
 	s := grpc.InitService(config, stdLogger, exitFn, diContainer)
+	s.StartBackgroundWorkers()
 	go s.StartGRPCServer()
 	s.WaitForStopSignal()
 }
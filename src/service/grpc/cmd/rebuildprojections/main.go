@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/AntonStoeckl/go-iddd/src/service/grpc"
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// Rebuilds the read model projections from scratch. The gRPC service should not be running meanwhile.
+func main() {
+	stdLogger := shared.NewStandardLogger()
+	config := grpc.MustBuildConfigFromEnv(stdLogger)
+	exitFn := func() { os.Exit(1) }
+	postgresDBConn := grpc.MustInitPostgresDB(config, stdLogger)
+	diContainer := grpc.MustBuildDIContainer(
+		config,
+		stdLogger,
+		grpc.UsePostgresDBConn(postgresDBConn),
+	)
+
+	s := grpc.InitService(config, stdLogger, exitFn, diContainer)
+
+	if err := s.RebuildCustomerViewProjection(context.Background()); err != nil {
+		stdLogger.Error().Msgf("failed to rebuild the customer view projection: %s", err)
+		exitFn()
+	}
+}
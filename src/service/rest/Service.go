@@ -74,6 +74,8 @@ func (s *Service) buildRestServer() {
 
 	rmux := runtime.NewServeMux(
 		runtime.WithProtoErrorHandler(customerrest.CustomHTTPError),
+		runtime.WithIncomingHeaderMatcher(customerrest.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(customerrest.OutgoingHeaderMatcher),
 	)
 
 	if err := customerrestproto.RegisterCustomerHandlerClient(s.ctx, rmux, client); err != nil {
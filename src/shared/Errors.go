@@ -11,6 +11,8 @@ var (
 
 	ErrMaxRetriesExceeded  = errors.New("max retries exceeded")
 	ErrConcurrencyConflict = errors.New("concurrency conflict")
+	ErrVersionMismatch     = errors.New("version mismatch")
+	ErrRateLimited         = errors.New("rate limited")
 
 	ErrMarshalingFailed   = errors.New("marshaling failed")
 	ErrUnmarshalingFailed = errors.New("unmarshaling failed")
@@ -1,14 +1,24 @@
 package shared
 
 import (
+	"context"
+
 	"github.com/cockroachdb/errors"
 )
 
-func RetryOnConcurrencyConflict(originalFunc func() error, maxRetries uint8) error {
+func RetryOnConcurrencyConflict(ctx context.Context, originalFunc func() error, maxRetries uint8) error {
 	var err error
 	var retries uint8
 
 	for retries = 0; retries < maxRetries; retries++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr // not even tried once
+			}
+
+			return errors.WithSecondaryError(ctxErr, err) // the ctx is done, retrying does not make sense anymore
+		}
+
 		// call next method in chain
 		if err = originalFunc(); err == nil {
 			return nil // no need to retry, call to originalFunc was successful
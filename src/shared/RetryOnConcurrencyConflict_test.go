@@ -1,6 +1,7 @@
 package shared_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/AntonStoeckl/go-iddd/src/shared"
@@ -29,7 +30,7 @@ func TestRetryOnConcurrencyConflict(t *testing.T) {
 				retries := uint8(3)
 
 				Convey("Then it should succeed after retrying", func() {
-					err := retryFunc(originalFunc, retries)
+					err := retryFunc(context.Background(), originalFunc, retries)
 					So(err, ShouldBeNil)
 				})
 			})
@@ -52,7 +53,7 @@ func TestRetryOnConcurrencyConflict(t *testing.T) {
 				retries := uint8(3)
 
 				Convey("Then it should fail", func() {
-					err := retryFunc(originalFunc, retries)
+					err := retryFunc(context.Background(), originalFunc, retries)
 					So(err, ShouldBeError)
 					So(errors.Is(err, shared.ErrConcurrencyConflict), ShouldBeTrue)
 				})
@@ -76,11 +77,37 @@ func TestRetryOnConcurrencyConflict(t *testing.T) {
 				retries := uint8(3)
 
 				Convey("Then it should succeed after retrying", func() {
-					err := retryFunc(originalFunc, retries)
+					err := retryFunc(context.Background(), originalFunc, retries)
 					So(err, ShouldBeError)
 					So(errors.Is(err, shared.ErrTechnical), ShouldBeTrue)
 				})
 			})
 		})
+
+		Convey("Assuming the original function always returns a concurrency conflict error", func() {
+			var callCounter uint8
+			originalFunc := func() error {
+				callCounter++
+
+				return errors.Mark(errors.New("mocked concurrency error"), shared.ErrConcurrencyConflict)
+			}
+
+			Convey("When RetryOnConcurrencyConflict is invoked with a ctx that is done meanwhile", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				retries := uint8(3)
+
+				cancellingFunc := func() error {
+					cancel()
+					return originalFunc()
+				}
+
+				Convey("Then it should stop retrying and fail", func() {
+					err := retryFunc(ctx, cancellingFunc, retries)
+					So(err, ShouldBeError)
+					So(errors.Is(err, context.Canceled), ShouldBeTrue)
+					So(callCounter, ShouldEqual, 1)
+				})
+			})
+		})
 	})
 }
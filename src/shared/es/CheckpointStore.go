@@ -0,0 +1,83 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// CheckpointStore persists the GlobalPosition up to which a subscriber has handled the global event log.
+type CheckpointStore struct {
+	checkpointTableName string
+}
+
+func NewCheckpointStore(checkpointTableName string) *CheckpointStore {
+	return &CheckpointStore{
+		checkpointTableName: checkpointTableName,
+	}
+}
+
+// RetrieveCheckpoint returns the zero GlobalPosition (start of the global log) for unknown subscribers.
+func (s *CheckpointStore) RetrieveCheckpoint(
+	ctx context.Context,
+	subscriberID string,
+	db *sql.DB,
+) (GlobalPosition, error) {
+
+	queryTemplate := `SELECT transaction_id, event_id FROM %name% WHERE subscriber_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", s.checkpointTableName, 1)
+
+	var transactionID, eventID uint64
+
+	if err := db.QueryRowContext(ctx, query, subscriberID).Scan(&transactionID, &eventID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GlobalPosition{}, nil
+		}
+
+		return GlobalPosition{}, shared.MarkAndWrapError(err, shared.ErrTechnical, "retrieveCheckpoint")
+	}
+
+	return BuildGlobalPosition(transactionID, eventID), nil
+}
+
+func (s *CheckpointStore) SaveCheckpoint(
+	ctx context.Context,
+	subscriberID string,
+	position GlobalPosition,
+	db *sql.DB,
+) error {
+
+	queryTemplate := `INSERT INTO %name% (subscriber_id, transaction_id, event_id, updated_at)
+						VALUES ($1, $2, $3, now())
+						ON CONFLICT (subscriber_id) DO UPDATE
+							SET transaction_id = EXCLUDED.transaction_id,
+								event_id = EXCLUDED.event_id,
+								updated_at = EXCLUDED.updated_at`
+
+	query := strings.Replace(queryTemplate, "%name%", s.checkpointTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query, subscriberID, position.TransactionID(), position.EventID()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "saveCheckpoint")
+	}
+
+	return nil
+}
+
+func (s *CheckpointStore) ResetCheckpoint(
+	ctx context.Context,
+	subscriberID string,
+	db *sql.DB,
+) error {
+
+	queryTemplate := `DELETE FROM %name% WHERE subscriber_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", s.checkpointTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query, subscriberID); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "resetCheckpoint")
+	}
+
+	return nil
+}
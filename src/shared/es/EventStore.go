@@ -1,8 +1,10 @@
 package es
 
 import (
+	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/AntonStoeckl/go-iddd/src/shared"
 	"github.com/cockroachdb/errors"
@@ -29,6 +31,7 @@ func NewEventStore(
 }
 
 func (s *EventStore) RetrieveEventStream(
+	ctx context.Context,
 	streamID StreamID,
 	fromVersion uint,
 	maxEvents uint,
@@ -45,7 +48,7 @@ func (s *EventStore) RetrieveEventStream(
 
 	query := strings.Replace(queryTemplate, "%name%", s.eventStoreTableName, 1)
 
-	eventRows, err := db.Query(query, streamID.String(), fromVersion, maxEvents)
+	eventRows, err := db.QueryContext(ctx, query, streamID.String(), fromVersion, maxEvents)
 	if err != nil {
 		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 	}
@@ -67,7 +70,7 @@ func (s *EventStore) RetrieveEventStream(
 			return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
 		}
 
-		if domainEvent, err = s.unmarshalDomainEvent(eventName, []byte(payload), streamVersion); err != nil {
+		if domainEvent, err = s.unmarshalDomainEvent(ctx, eventName, []byte(payload), streamVersion); err != nil {
 			return nil, shared.MarkAndWrapError(err, shared.ErrUnmarshalingFailed, wrapWithMsg)
 		}
 
@@ -77,7 +80,131 @@ func (s *EventStore) RetrieveEventStream(
 	return eventStream, nil
 }
 
+// RetrieveGlobalEventStream reads the events of all streams after the given position in global order.
+// Events of transactions that are still in progress (or younger than those) are not returned yet,
+// so that a consumer can never skip an event that gets committed later with a lower position.
+func (s *EventStore) RetrieveGlobalEventStream(
+	ctx context.Context,
+	after GlobalPosition,
+	maxEvents uint,
+	db *sql.DB,
+) (GlobalEventStream, error) {
+
+	var err error
+	wrapWithMsg := "retrieveGlobalEventStream"
+
+	queryTemplate := `SELECT transaction_id, id, stream_id, event_name, payload, stream_version FROM %name%
+						WHERE (transaction_id, id) > ($1, $2)
+							AND transaction_id < txid_snapshot_xmin(txid_current_snapshot())
+						ORDER BY transaction_id ASC, id ASC
+						LIMIT $3`
+
+	query := strings.Replace(queryTemplate, "%name%", s.eventStoreTableName, 1)
+
+	eventRows, err := db.QueryContext(ctx, query, after.TransactionID(), after.EventID(), maxEvents)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	defer eventRows.Close()
+
+	var globalEventStream GlobalEventStream
+	var transactionID, eventID uint64
+	var streamID, eventName, payload string
+	var streamVersion uint
+	var domainEvent DomainEvent
+
+	for eventRows.Next() {
+		if err = eventRows.Scan(&transactionID, &eventID, &streamID, &eventName, &payload, &streamVersion); err != nil {
+			return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+		}
+
+		if domainEvent, err = s.unmarshalDomainEvent(ctx, eventName, []byte(payload), streamVersion); err != nil {
+			return nil, shared.MarkAndWrapError(err, shared.ErrUnmarshalingFailed, wrapWithMsg)
+		}
+
+		globalEventStream = append(
+			globalEventStream,
+			BuildGlobalEvent(BuildGlobalPosition(transactionID, eventID), BuildStreamID(streamID), domainEvent),
+		)
+	}
+
+	if err = eventRows.Err(); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return globalEventStream, nil
+}
+
+// CountGlobalEventsAfter counts the events after the given position, e.g. to measure how far a subscriber lags behind.
+func (s *EventStore) CountGlobalEventsAfter(
+	ctx context.Context,
+	after GlobalPosition,
+	db *sql.DB,
+) (uint, error) {
+
+	queryTemplate := `SELECT count(*) FROM %name% WHERE (transaction_id, id) > ($1, $2)`
+	query := strings.Replace(queryTemplate, "%name%", s.eventStoreTableName, 1)
+
+	var count uint
+
+	if err := db.QueryRowContext(ctx, query, after.TransactionID(), after.EventID()).Scan(&count); err != nil {
+		return 0, shared.MarkAndWrapError(err, shared.ErrTechnical, "countGlobalEventsAfter")
+	}
+
+	return count, nil
+}
+
+// RetrieveStreamIDsWithEventNotFollowedBy retrieves the streams with the given event which occurred before the given time,
+// unless it is followed by the revoking event, independent of which other events follow it.
+func (s *EventStore) RetrieveStreamIDsWithEventNotFollowedBy(
+	ctx context.Context,
+	eventName string,
+	revokingEventName string,
+	occurredBefore time.Time,
+	db *sql.DB,
+) ([]StreamID, error) {
+
+	var err error
+	wrapWithMsg := "retrieveStreamIDsWithEventNotFollowedBy"
+
+	queryTemplate := `SELECT DISTINCT stream_id FROM %name% AS event
+						WHERE event_name = $1 AND occurred_at < $3
+						AND NOT EXISTS (
+							SELECT 1 FROM %name% AS revoking
+							WHERE revoking.stream_id = event.stream_id AND revoking.stream_version > event.stream_version
+							AND revoking.event_name = $2
+						)`
+
+	query := strings.ReplaceAll(queryTemplate, "%name%", s.eventStoreTableName)
+
+	streamRows, err := db.QueryContext(ctx, query, eventName, revokingEventName, occurredBefore)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	defer streamRows.Close()
+
+	var streamIDs []StreamID
+	var streamID string
+
+	for streamRows.Next() {
+		if err = streamRows.Scan(&streamID); err != nil {
+			return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+		}
+
+		streamIDs = append(streamIDs, BuildStreamID(streamID))
+	}
+
+	if err = streamRows.Err(); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return streamIDs, nil
+}
+
 func (s *EventStore) AppendEventsToStream(
+	ctx context.Context,
 	streamID StreamID,
 	events []DomainEvent,
 	tx *sql.Tx,
@@ -93,12 +220,13 @@ func (s *EventStore) AppendEventsToStream(
 	for _, event := range events {
 		var eventJSON []byte
 
-		eventJSON, err = s.marshalDomainEvent(event)
+		eventJSON, err = s.marshalDomainEvent(ctx, event)
 		if err != nil {
 			return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
 		}
 
-		_, err = tx.Exec(
+		_, err = tx.ExecContext(
+			ctx,
 			query,
 			streamID.String(),
 			event.Meta().StreamVersion(),
@@ -116,6 +244,7 @@ func (s *EventStore) AppendEventsToStream(
 }
 
 func (s *EventStore) PurgeEventStream(
+	ctx context.Context,
 	streamID StreamID,
 	tx *sql.Tx,
 ) error {
@@ -123,7 +252,7 @@ func (s *EventStore) PurgeEventStream(
 	queryTemplate := `DELETE FROM %name% WHERE stream_id = $1`
 	query := strings.Replace(queryTemplate, "%name%", s.eventStoreTableName, 1)
 
-	if _, err := tx.Exec(query, streamID.String()); err != nil {
+	if _, err := tx.ExecContext(ctx, query, streamID.String()); err != nil {
 		return shared.MarkAndWrapError(err, shared.ErrTechnical, "purgeEventStream")
 	}
 
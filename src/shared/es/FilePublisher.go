@@ -0,0 +1,68 @@
+package es
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// FilePublisher is a local stand-in for a message broker which appends each message as one line of json to a file.
+type FilePublisher struct {
+	mutex    sync.Mutex
+	filePath string
+}
+
+type outboxMessageForJSON struct {
+	StreamID      string              `json:"streamID"`
+	StreamVersion uint                `json:"streamVersion"`
+	EventName     string              `json:"eventName"`
+	OccurredAt    string              `json:"occurredAt"`
+	Payload       jsoniter.RawMessage `json:"payload"`
+}
+
+func NewFilePublisher(filePath string) *FilePublisher {
+	return &FilePublisher{
+		filePath: filePath,
+	}
+}
+
+func (p *FilePublisher) Publish(_ context.Context, message OutboxMessage) error {
+	wrapWithMsg := "filePublisher.Publish"
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	line, err := jsoniter.ConfigFastest.Marshal(
+		outboxMessageForJSON{
+			StreamID:      message.StreamID().String(),
+			StreamVersion: message.StreamVersion(),
+			EventName:     message.EventName(),
+			OccurredAt:    message.OccurredAt(),
+			Payload:       message.Payload(),
+		},
+	)
+
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
+	}
+
+	file, err := os.OpenFile(p.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if err = file.Close(); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
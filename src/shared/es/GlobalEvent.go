@@ -0,0 +1,27 @@
+package es
+
+type GlobalEvent struct {
+	position GlobalPosition
+	streamID StreamID
+	event    DomainEvent
+}
+
+func BuildGlobalEvent(position GlobalPosition, streamID StreamID, event DomainEvent) GlobalEvent {
+	return GlobalEvent{
+		position: position,
+		streamID: streamID,
+		event:    event,
+	}
+}
+
+func (globalEvent GlobalEvent) Position() GlobalPosition {
+	return globalEvent.position
+}
+
+func (globalEvent GlobalEvent) StreamID() StreamID {
+	return globalEvent.streamID
+}
+
+func (globalEvent GlobalEvent) Event() DomainEvent {
+	return globalEvent.event
+}
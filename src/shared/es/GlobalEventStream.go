@@ -0,0 +1,3 @@
+package es
+
+type GlobalEventStream []GlobalEvent
@@ -0,0 +1,32 @@
+package es
+
+// GlobalPosition is the position of an event in the global log of the EventStore.
+// It consists of the ID of the inserting transaction and the serial ID of the event, because only this combination
+// is gap-free: events are only read once all transactions that could still insert lower positions have finished.
+type GlobalPosition struct {
+	transactionID uint64
+	eventID       uint64
+}
+
+func BuildGlobalPosition(transactionID uint64, eventID uint64) GlobalPosition {
+	return GlobalPosition{
+		transactionID: transactionID,
+		eventID:       eventID,
+	}
+}
+
+func (position GlobalPosition) TransactionID() uint64 {
+	return position.transactionID
+}
+
+func (position GlobalPosition) EventID() uint64 {
+	return position.eventID
+}
+
+func (position GlobalPosition) IsAfter(other GlobalPosition) bool {
+	if position.transactionID != other.transactionID {
+		return position.transactionID > other.transactionID
+	}
+
+	return position.eventID > other.eventID
+}
@@ -0,0 +1,46 @@
+package es
+
+import (
+	"context"
+)
+
+// IdempotencyKey identifies one invocation of a command, so that retries of that invocation can be recognized.
+// It is scoped by the commandName, so the same key can't accidentally replay a different command.
+type IdempotencyKey struct {
+	key         string
+	commandName string
+}
+
+func BuildIdempotencyKey(key string, commandName string) IdempotencyKey {
+	if key == "" || commandName == "" {
+		panic("buildIdempotencyKey: empty input given")
+	}
+
+	return IdempotencyKey{key: key, commandName: commandName}
+}
+
+func (idempotencyKey IdempotencyKey) Key() string {
+	return idempotencyKey.key
+}
+
+func (idempotencyKey IdempotencyKey) CommandName() string {
+	return idempotencyKey.commandName
+}
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey makes the event stores record the key in the same transaction as the events.
+func ContextWithIdempotencyKey(ctx context.Context, idempotencyKey IdempotencyKey) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, idempotencyKey)
+}
+
+// ContextWithoutIdempotencyKey is for results which must not be replayed, e.g. failed commands.
+func ContextWithoutIdempotencyKey(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, nil)
+}
+
+func IdempotencyKeyFrom(ctx context.Context) (IdempotencyKey, bool) {
+	idempotencyKey, ok := ctx.Value(idempotencyKeyContextKey{}).(IdempotencyKey)
+
+	return idempotencyKey, ok
+}
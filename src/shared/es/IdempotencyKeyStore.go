@@ -0,0 +1,134 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// IdempotencyKeyStore remembers which event stream a command with an IdempotencyKey was handled for.
+// Keys are recorded in the same transaction as the events, so a key exists if and only if the command succeeded.
+// Expired keys are ignored and can be recorded again.
+type IdempotencyKeyStore struct {
+	idempotencyKeyTableName string
+	ttl                     time.Duration
+}
+
+func NewIdempotencyKeyStore(idempotencyKeyTableName string, ttl time.Duration) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{
+		idempotencyKeyTableName: idempotencyKeyTableName,
+		ttl:                     ttl,
+	}
+}
+
+// RecordIdempotencyKey fails with ErrConcurrencyConflict if the same key was recorded meanwhile,
+// so that a retry of the command can find the recorded key.
+func (s *IdempotencyKeyStore) RecordIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey IdempotencyKey,
+	streamID StreamID,
+	tx *sql.Tx,
+) error {
+
+	wrapWithMsg := "recordIdempotencyKey"
+
+	queryTemplate := `INSERT INTO %name% (idempotency_key, command_name, stream_id, created_at, expires_at)
+						VALUES ($1, $2, $3, now(), now() + $4 * interval '1 millisecond')
+						ON CONFLICT (idempotency_key) DO UPDATE
+							SET command_name = EXCLUDED.command_name,
+								stream_id = EXCLUDED.stream_id,
+								created_at = EXCLUDED.created_at,
+								expires_at = EXCLUDED.expires_at
+							WHERE %name%.expires_at <= now()`
+
+	query := strings.ReplaceAll(queryTemplate, "%name%", s.idempotencyKeyTableName)
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		idempotencyKey.Key(),
+		idempotencyKey.CommandName(),
+		streamID.String(),
+		s.ttl.Milliseconds(),
+	)
+
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if rowsAffected == 0 {
+		err := errors.Newf("idempotency key [%s] was recorded meanwhile", idempotencyKey.Key())
+		return shared.MarkAndWrapError(err, shared.ErrConcurrencyConflict, wrapWithMsg)
+	}
+
+	return nil
+}
+
+// RetrieveStreamIDForIdempotencyKey fails with ErrNotFound if the key was not recorded or is expired and with
+// ErrInputIsInvalid if the key was recorded for a different command.
+func (s *IdempotencyKeyStore) RetrieveStreamIDForIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey IdempotencyKey,
+	db *sql.DB,
+) (StreamID, error) {
+
+	wrapWithMsg := "retrieveStreamIDForIdempotencyKey"
+
+	queryTemplate := `SELECT command_name, stream_id FROM %name%
+						WHERE idempotency_key = $1 AND expires_at > now()`
+
+	query := strings.Replace(queryTemplate, "%name%", s.idempotencyKeyTableName, 1)
+
+	var commandName, streamID string
+
+	if err := db.QueryRowContext(ctx, query, idempotencyKey.Key()).Scan(&commandName, &streamID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", shared.MarkAndWrapError(errors.New("idempotency key not found"), shared.ErrNotFound, wrapWithMsg)
+		}
+
+		return "", shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if commandName != idempotencyKey.CommandName() {
+		err := errors.Newf("idempotency key [%s] was already used for command [%s]", idempotencyKey.Key(), commandName)
+		return "", shared.MarkAndWrapError(err, shared.ErrInputIsInvalid, wrapWithMsg)
+	}
+
+	return StreamID(streamID), nil
+}
+
+func (s *IdempotencyKeyStore) PurgeIdempotencyKeys(
+	ctx context.Context,
+	streamID StreamID,
+	tx *sql.Tx,
+) error {
+
+	queryTemplate := `DELETE FROM %name% WHERE stream_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", s.idempotencyKeyTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, query, streamID.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "purgeIdempotencyKeys")
+	}
+
+	return nil
+}
+
+func (s *IdempotencyKeyStore) PurgeExpiredIdempotencyKeys(ctx context.Context, db *sql.DB) error {
+	queryTemplate := `DELETE FROM %name% WHERE expires_at <= now()`
+	query := strings.Replace(queryTemplate, "%name%", s.idempotencyKeyTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "purgeExpiredIdempotencyKeys")
+	}
+
+	return nil
+}
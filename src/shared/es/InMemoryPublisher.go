@@ -0,0 +1,35 @@
+package es
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryPublisher is a local stand-in for a message broker, e.g. for tests.
+type InMemoryPublisher struct {
+	mutex     sync.RWMutex
+	published []OutboxMessage
+}
+
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{}
+}
+
+func (p *InMemoryPublisher) Publish(_ context.Context, message OutboxMessage) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.published = append(p.published, message)
+
+	return nil
+}
+
+func (p *InMemoryPublisher) PublishedMessages() []OutboxMessage {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	published := make([]OutboxMessage, len(p.published))
+	copy(published, p.published)
+
+	return published
+}
@@ -0,0 +1,5 @@
+package es
+
+import "context"
+
+type MarshalSnapshot func(ctx context.Context, snapshot DomainEvent) ([]byte, error)
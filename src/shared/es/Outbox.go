@@ -0,0 +1,252 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+)
+
+// Outbox stores events to be published in the same transaction in which they are appended to the EventStore,
+// so that no event can get lost or be published without being committed.
+type Outbox struct {
+	outboxTableName    string
+	marshalDomainEvent MarshalDomainEvent
+}
+
+func NewOutbox(
+	outboxTableName string,
+	marshalDomainEvent MarshalDomainEvent,
+) *Outbox {
+
+	return &Outbox{
+		outboxTableName:    outboxTableName,
+		marshalDomainEvent: marshalDomainEvent,
+	}
+}
+
+func (o *Outbox) AddToOutbox(
+	ctx context.Context,
+	streamID StreamID,
+	events []DomainEvent,
+	tx *sql.Tx,
+) error {
+
+	var err error
+	wrapWithMsg := "addToOutbox"
+
+	queryTemplate := `INSERT INTO %name% (stream_id, stream_version, event_name, occurred_at, payload, next_attempt_at)
+						VALUES ($1, $2, $3, $4, $5, now())`
+	query := strings.Replace(queryTemplate, "%name%", o.outboxTableName, 1)
+
+	for _, event := range events {
+		var eventJSON []byte
+
+		eventJSON, err = o.marshalDomainEvent(ctx, event)
+		if err != nil {
+			return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			streamID.String(),
+			event.Meta().StreamVersion(),
+			event.Meta().EventName(),
+			event.Meta().OccurredAt(),
+			eventJSON,
+		)
+
+		if err != nil {
+			return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+		}
+	}
+
+	return nil
+}
+
+// ClaimPendingMessages returns messages which are neither delivered nor dead-lettered and are due for (re)delivery,
+// and claims them for claimFor, so that relays of other service instances don't publish them as well.
+// Messages which follow a message of the same stream that waits for its next attempt or is claimed are not due yet,
+// so that the messages of a stream are never published by two relays at the same time.
+// Claiming is serialized with an advisory lock, otherwise two relays could both see a stream as unclaimed.
+func (o *Outbox) ClaimPendingMessages(
+	ctx context.Context,
+	maxMessages uint,
+	claimFor time.Duration,
+	db *sql.DB,
+) ([]OutboxMessage, error) {
+
+	var err error
+	wrapWithMsg := "claimPendingMessages"
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	messages, err := o.claimPendingMessages(ctx, maxMessages, claimFor, tx)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return messages, nil
+}
+
+func (o *Outbox) claimPendingMessages(
+	ctx context.Context,
+	maxMessages uint,
+	claimFor time.Duration,
+	tx *sql.Tx,
+) ([]OutboxMessage, error) {
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, o.outboxTableName); err != nil {
+		return nil, err
+	}
+
+	queryTemplate := `UPDATE %name% SET claimed_until = $2
+						WHERE id IN (
+							SELECT id FROM %name% AS message
+							WHERE delivered_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= now()
+							AND (claimed_until IS NULL OR claimed_until <= now())
+							AND NOT EXISTS (
+								SELECT 1 FROM %name% AS earlier
+								WHERE earlier.stream_id = message.stream_id AND earlier.id < message.id
+								AND earlier.delivered_at IS NULL AND earlier.dead_lettered_at IS NULL
+								AND (earlier.next_attempt_at > now() OR earlier.claimed_until > now())
+							)
+							ORDER BY id ASC
+							LIMIT $1
+						)
+						RETURNING id, stream_id, stream_version, event_name, occurred_at, payload, attempts`
+
+	query := strings.ReplaceAll(queryTemplate, "%name%", o.outboxTableName)
+
+	messageRows, err := tx.QueryContext(ctx, query, maxMessages, time.Now().Add(claimFor))
+	if err != nil {
+		return nil, err
+	}
+
+	defer messageRows.Close()
+
+	var messages []OutboxMessage
+	var id uint64
+	var streamID, eventName, payload string
+	var occurredAt time.Time
+	var streamVersion, attempts uint
+
+	for messageRows.Next() {
+		if err = messageRows.Scan(&id, &streamID, &streamVersion, &eventName, &occurredAt, &payload, &attempts); err != nil {
+			return nil, err
+		}
+
+		messages = append(
+			messages,
+			RebuildOutboxMessage(
+				id,
+				streamID,
+				streamVersion,
+				eventName,
+				occurredAt.Format(metaTimestampFormat),
+				[]byte(payload),
+				attempts,
+			),
+		)
+	}
+
+	if err = messageRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID() < messages[j].ID()
+	})
+
+	return messages, nil
+}
+
+func (o *Outbox) MarkMessageAsDelivered(
+	ctx context.Context,
+	message OutboxMessage,
+	db *sql.DB,
+) error {
+
+	queryTemplate := `UPDATE %name% SET delivered_at = now(), attempts = attempts + 1, claimed_until = NULL WHERE id = $1`
+	query := strings.Replace(queryTemplate, "%name%", o.outboxTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query, message.ID()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "markMessageAsDelivered")
+	}
+
+	return nil
+}
+
+// MarkMessageAsFailed schedules the next delivery attempt or moves the message to the dead letters (deadLetter == true).
+func (o *Outbox) MarkMessageAsFailed(
+	ctx context.Context,
+	message OutboxMessage,
+	failure error,
+	nextAttemptAt time.Time,
+	deadLetter bool,
+	db *sql.DB,
+) error {
+
+	queryTemplate := `UPDATE %name% 
+						SET attempts = attempts + 1,
+							last_error = $2,
+							next_attempt_at = $3,
+							dead_lettered_at = CASE WHEN $4::boolean THEN now() END,
+							claimed_until = NULL
+						WHERE id = $1`
+
+	query := strings.Replace(queryTemplate, "%name%", o.outboxTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query, message.ID(), failure.Error(), nextAttemptAt, deadLetter); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "markMessageAsFailed")
+	}
+
+	return nil
+}
+
+// PruneDeliveredMessages deletes messages which were delivered before deliveredBefore, dead letters are kept.
+func (o *Outbox) PruneDeliveredMessages(
+	ctx context.Context,
+	deliveredBefore time.Time,
+	db *sql.DB,
+) error {
+
+	queryTemplate := `DELETE FROM %name% WHERE delivered_at < $1`
+	query := strings.Replace(queryTemplate, "%name%", o.outboxTableName, 1)
+
+	if _, err := db.ExecContext(ctx, query, deliveredBefore); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "pruneDeliveredMessages")
+	}
+
+	return nil
+}
+
+func (o *Outbox) PurgeMessages(
+	ctx context.Context,
+	streamID StreamID,
+	tx *sql.Tx,
+) error {
+
+	queryTemplate := `DELETE FROM %name% WHERE stream_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", o.outboxTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, query, streamID.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "purgeMessages")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package es
+
+type OutboxMessage struct {
+	id            uint64
+	streamID      StreamID
+	streamVersion uint
+	eventName     string
+	occurredAt    string
+	payload       []byte
+	attempts      uint
+}
+
+func RebuildOutboxMessage(
+	id uint64,
+	streamID string,
+	streamVersion uint,
+	eventName string,
+	occurredAt string,
+	payload []byte,
+	attempts uint,
+) OutboxMessage {
+
+	return OutboxMessage{
+		id:            id,
+		streamID:      BuildStreamID(streamID),
+		streamVersion: streamVersion,
+		eventName:     eventName,
+		occurredAt:    occurredAt,
+		payload:       payload,
+		attempts:      attempts,
+	}
+}
+
+func (message OutboxMessage) ID() uint64 {
+	return message.id
+}
+
+func (message OutboxMessage) StreamID() StreamID {
+	return message.streamID
+}
+
+func (message OutboxMessage) StreamVersion() uint {
+	return message.streamVersion
+}
+
+func (message OutboxMessage) EventName() string {
+	return message.eventName
+}
+
+func (message OutboxMessage) OccurredAt() string {
+	return message.occurredAt
+}
+
+func (message OutboxMessage) Payload() []byte {
+	return message.payload
+}
+
+func (message OutboxMessage) Attempts() uint {
+	return message.attempts
+}
@@ -0,0 +1,185 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+type ForPublishingOutboxMessages func(ctx context.Context, message OutboxMessage) error
+type ForClaimingPendingOutboxMessages func(ctx context.Context, maxMessages uint, claimFor time.Duration, db *sql.DB) ([]OutboxMessage, error)
+type ForMarkingOutboxMessagesAsDelivered func(ctx context.Context, message OutboxMessage, db *sql.DB) error
+type ForMarkingOutboxMessagesAsFailed func(ctx context.Context, message OutboxMessage, failure error, nextAttemptAt time.Time, deadLetter bool, db *sql.DB) error
+type ForPruningDeliveredOutboxMessages func(ctx context.Context, deliveredBefore time.Time, db *sql.DB) error
+
+// OutboxRelay delivers the pending messages of an Outbox to a publisher with at-least-once semantics:
+// a message is only marked as delivered after it was published, so it can be published again after a crash.
+// Failed deliveries are retried with exponential backoff, up to maxRetryBackoff, and dead-lettered after maxAttempts.
+// The messages of a stream are published in order, so later ones are held back while an earlier one is failing.
+// Messages are claimed for claimDuration before they are published, so several service instances can run a relay.
+// Delivered messages are pruned once they are older than the deliveredRetention.
+type OutboxRelay struct {
+	db                     *sql.DB
+	claimPendingMessages   ForClaimingPendingOutboxMessages
+	markAsDelivered        ForMarkingOutboxMessagesAsDelivered
+	markAsFailed           ForMarkingOutboxMessagesAsFailed
+	pruneDeliveredMessages ForPruningDeliveredOutboxMessages
+	publish                ForPublishingOutboxMessages
+	batchSize              uint
+	maxAttempts            uint
+	retryBackoff           time.Duration
+	maxRetryBackoff        time.Duration
+	pollInterval           time.Duration
+	claimDuration          time.Duration
+	deliveredRetention     time.Duration
+	logger                 *shared.Logger
+}
+
+func NewOutboxRelay(
+	db *sql.DB,
+	claimPendingMessages ForClaimingPendingOutboxMessages,
+	markAsDelivered ForMarkingOutboxMessagesAsDelivered,
+	markAsFailed ForMarkingOutboxMessagesAsFailed,
+	pruneDeliveredMessages ForPruningDeliveredOutboxMessages,
+	publish ForPublishingOutboxMessages,
+	batchSize uint,
+	maxAttempts uint,
+	retryBackoff time.Duration,
+	maxRetryBackoff time.Duration,
+	pollInterval time.Duration,
+	claimDuration time.Duration,
+	deliveredRetention time.Duration,
+	logger *shared.Logger,
+) *OutboxRelay {
+
+	return &OutboxRelay{
+		db:                     db,
+		claimPendingMessages:   claimPendingMessages,
+		markAsDelivered:        markAsDelivered,
+		markAsFailed:           markAsFailed,
+		pruneDeliveredMessages: pruneDeliveredMessages,
+		publish:                publish,
+		batchSize:              batchSize,
+		maxAttempts:            maxAttempts,
+		retryBackoff:           retryBackoff,
+		maxRetryBackoff:        maxRetryBackoff,
+		pollInterval:           pollInterval,
+		claimDuration:          claimDuration,
+		deliveredRetention:     deliveredRetention,
+		logger:                 logger,
+	}
+}
+
+// RelayPendingMessages delivers all messages which are currently due, batch by batch.
+// The pending messages must be claimed in order and without those which follow a message of their stream
+// that waits for its next attempt, so that holding back the rest of a stream within the batch is enough.
+func (r *OutboxRelay) RelayPendingMessages(ctx context.Context) error {
+	wrapWithMsg := "outboxRelay.RelayPendingMessages"
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		messages, err := r.claimPendingMessages(ctx, r.batchSize, r.claimDuration, r.db)
+		if err != nil {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		failedStreams := make(map[StreamID]bool)
+
+		for _, message := range messages {
+			if failedStreams[message.StreamID()] {
+				continue
+			}
+
+			isDelivered, err := r.relay(ctx, message)
+			if err != nil {
+				return errors.Wrap(err, wrapWithMsg)
+			}
+
+			if !isDelivered {
+				failedStreams[message.StreamID()] = true
+			}
+		}
+
+		if uint(len(messages)) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+func (r *OutboxRelay) relay(ctx context.Context, message OutboxMessage) (bool, error) {
+	publishErr := r.publish(ctx, message)
+	if publishErr == nil {
+		return true, r.markAsDelivered(ctx, message, r.db)
+	}
+
+	attempts := message.Attempts() + 1
+	deadLetter := attempts >= r.maxAttempts
+	nextAttemptAt := time.Now().Add(r.retryBackoffFor(attempts))
+
+	if deadLetter {
+		r.logger.Warn().Msgf(
+			"outboxRelay: dead-lettered message [%d] of stream [%s] after %d attempts: %s",
+			message.ID(),
+			message.StreamID(),
+			attempts,
+			publishErr,
+		)
+	}
+
+	return false, r.markAsFailed(ctx, message, publishErr, nextAttemptAt, deadLetter, r.db)
+}
+
+// retryBackoffFor doubles the retryBackoff for each attempt, it is capped so that it can't overflow.
+func (r *OutboxRelay) retryBackoffFor(attempts uint) time.Duration {
+	backoff := r.retryBackoff
+
+	for attempt := uint(1); attempt < attempts; attempt++ {
+		if backoff > r.maxRetryBackoff/2 {
+			return r.maxRetryBackoff
+		}
+
+		backoff *= 2
+	}
+
+	if backoff > r.maxRetryBackoff {
+		return r.maxRetryBackoff
+	}
+
+	return backoff
+}
+
+func (r *OutboxRelay) PruneDeliveredMessages(ctx context.Context) error {
+	if err := r.pruneDeliveredMessages(ctx, time.Now().Add(-r.deliveredRetention), r.db); err != nil {
+		return errors.Wrap(err, "outboxRelay.PruneDeliveredMessages")
+	}
+
+	return nil
+}
+
+// Run relays and prunes every pollInterval until the ctx is done. Failures are logged and retried with the next poll.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RelayPendingMessages(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error().Msgf("outboxRelay failed: %s", err)
+		}
+
+		if err := r.PruneDeliveredMessages(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error().Msgf("outboxRelay failed: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
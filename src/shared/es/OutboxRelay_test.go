@@ -0,0 +1,181 @@
+package es_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOutboxRelay_RelayPendingMessages(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		type failure struct {
+			nextAttemptAt time.Time
+			deadLetter    bool
+		}
+
+		pending := []es.OutboxMessage{
+			es.RebuildOutboxMessage(1, "customer-123", 1, "CustomerRegistered", "now", []byte(`{}`), 0),
+			es.RebuildOutboxMessage(2, "customer-123", 2, "CustomerNameChanged", "now", []byte(`{}`), 0),
+		}
+
+		otherStreamsMessage := es.RebuildOutboxMessage(3, "customer-456", 1, "CustomerRegistered", "now", []byte(`{}`), 0)
+
+		delivered := make(map[uint64]bool)
+		failed := make(map[uint64]failure)
+		publisher := es.NewInMemoryPublisher()
+		publishingFails := false
+		var failingMessageID uint64
+		var prunedBefore time.Time
+		var claimedFor time.Duration
+
+		claimPendingMessages := func(ctx context.Context, maxMessages uint, claimFor time.Duration, db *sql.DB) ([]es.OutboxMessage, error) {
+			var messages []es.OutboxMessage
+			claimedFor = claimFor
+
+			for _, message := range pending {
+				if !delivered[message.ID()] && uint(len(messages)) < maxMessages {
+					messages = append(messages, message)
+				}
+			}
+
+			pending = nil // each message is only due once within this test
+
+			return messages, nil
+		}
+
+		markAsDelivered := func(ctx context.Context, message es.OutboxMessage, db *sql.DB) error {
+			delivered[message.ID()] = true
+			return nil
+		}
+
+		markAsFailed := func(ctx context.Context, message es.OutboxMessage, publishErr error, nextAttemptAt time.Time, deadLetter bool, db *sql.DB) error {
+			failed[message.ID()] = failure{nextAttemptAt: nextAttemptAt, deadLetter: deadLetter}
+			return nil
+		}
+
+		publish := func(ctx context.Context, message es.OutboxMessage) error {
+			if publishingFails || message.ID() == failingMessageID {
+				return errors.New("broker unavailable")
+			}
+
+			return publisher.Publish(ctx, message)
+		}
+
+		pruneDeliveredMessages := func(ctx context.Context, deliveredBefore time.Time, db *sql.DB) error {
+			prunedBefore = deliveredBefore
+			return nil
+		}
+
+		buildRelay := func(maxAttempts uint) *es.OutboxRelay {
+			return es.NewOutboxRelay(
+				nil,
+				claimPendingMessages,
+				markAsDelivered,
+				markAsFailed,
+				pruneDeliveredMessages,
+				publish,
+				10,
+				maxAttempts,
+				time.Minute,
+				time.Hour,
+				time.Second,
+				time.Minute,
+				time.Hour,
+				shared.NewNilLogger(),
+			)
+		}
+
+		Convey("When pending messages are relayed", func() {
+			err := buildRelay(3).RelayPendingMessages(context.Background())
+
+			Convey("Then they should be claimed for the claim duration", func() {
+				So(err, ShouldBeNil)
+				So(claimedFor, ShouldEqual, time.Minute)
+			})
+
+			Convey("and they should be published in order and marked as delivered", func() {
+				So(err, ShouldBeNil)
+				So(publisher.PublishedMessages(), ShouldHaveLength, 2)
+				So(publisher.PublishedMessages()[0].EventName(), ShouldEqual, "CustomerRegistered")
+				So(publisher.PublishedMessages()[1].EventName(), ShouldEqual, "CustomerNameChanged")
+				So(delivered[1], ShouldBeTrue)
+				So(delivered[2], ShouldBeTrue)
+			})
+		})
+
+		Convey("When publishing fails", func() {
+			publishingFails = true
+			before := time.Now()
+			err := buildRelay(3).RelayPendingMessages(context.Background())
+
+			Convey("Then the messages should be scheduled for a retry with backoff", func() {
+				So(err, ShouldBeNil)
+				So(delivered, ShouldBeEmpty)
+				So(failed[1].deadLetter, ShouldBeFalse)
+				So(failed[1].nextAttemptAt, ShouldHappenOnOrAfter, before.Add(time.Minute))
+			})
+		})
+
+		Convey("When publishing fails for the last allowed attempt", func() {
+			publishingFails = true
+			pending[0] = es.RebuildOutboxMessage(1, "customer-123", 1, "CustomerRegistered", "now", []byte(`{}`), 2)
+			err := buildRelay(3).RelayPendingMessages(context.Background())
+
+			Convey("Then the message should be dead-lettered", func() {
+				So(err, ShouldBeNil)
+				So(failed[1].deadLetter, ShouldBeTrue)
+				So(failed[2].deadLetter, ShouldBeFalse)
+			})
+		})
+
+		Convey("When publishing fails after many attempts", func() {
+			publishingFails = true
+			pending[0] = es.RebuildOutboxMessage(1, "customer-123", 1, "CustomerRegistered", "now", []byte(`{}`), 63)
+			before := time.Now()
+			err := buildRelay(100).RelayPendingMessages(context.Background())
+
+			Convey("Then the backoff should be capped at the max retry backoff", func() {
+				So(err, ShouldBeNil)
+				So(failed[1].deadLetter, ShouldBeFalse)
+				So(failed[1].nextAttemptAt, ShouldHappenOnOrAfter, before.Add(time.Hour))
+				So(failed[1].nextAttemptAt, ShouldHappenWithin, time.Second, before.Add(time.Hour))
+			})
+		})
+
+		Convey("When publishing fails for the first message of a stream", func() {
+			failingMessageID = 1
+			pending = append(pending, otherStreamsMessage)
+			err := buildRelay(3).RelayPendingMessages(context.Background())
+
+			Convey("Then the later messages of that stream should be held back", func() {
+				So(err, ShouldBeNil)
+				So(failed, ShouldContainKey, uint64(1))
+				So(delivered[2], ShouldBeFalse)
+				So(failed, ShouldNotContainKey, uint64(2))
+			})
+
+			Convey("and the messages of other streams should be delivered", func() {
+				So(publisher.PublishedMessages(), ShouldHaveLength, 1)
+				So(publisher.PublishedMessages()[0].StreamID(), ShouldEqual, otherStreamsMessage.StreamID())
+				So(delivered[3], ShouldBeTrue)
+			})
+		})
+
+		Convey("When delivered messages are pruned", func() {
+			before := time.Now()
+			err := buildRelay(3).PruneDeliveredMessages(context.Background())
+
+			Convey("Then those which were delivered before the retention should be deleted", func() {
+				So(err, ShouldBeNil)
+				So(prunedBefore, ShouldHappenOnOrAfter, before.Add(-time.Hour))
+				So(prunedBefore, ShouldHappenWithin, time.Second, before.Add(-time.Hour))
+			})
+		})
+	})
+}
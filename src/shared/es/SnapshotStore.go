@@ -0,0 +1,130 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+// SnapshotStore persists the folded state of an event stream at a certain stream version.
+// Each snapshot is stored with the formatVersion it was written with. Snapshots with a different
+// formatVersion are ignored, so they get rebuilt from the full event stream once the projection logic changes.
+type SnapshotStore struct {
+	snapshotTableName string
+	formatVersion     uint
+	marshalSnapshot   MarshalSnapshot
+	unmarshalSnapshot UnmarshalSnapshot
+}
+
+func NewSnapshotStore(
+	snapshotTableName string,
+	formatVersion uint,
+	marshalSnapshot MarshalSnapshot,
+	unmarshalSnapshot UnmarshalSnapshot,
+) *SnapshotStore {
+
+	return &SnapshotStore{
+		snapshotTableName: snapshotTableName,
+		formatVersion:     formatVersion,
+		marshalSnapshot:   marshalSnapshot,
+		unmarshalSnapshot: unmarshalSnapshot,
+	}
+}
+
+// RetrieveSnapshot returns nil (and no error) if there is no snapshot with the current formatVersion.
+func (s *SnapshotStore) RetrieveSnapshot(
+	ctx context.Context,
+	streamID StreamID,
+	db *sql.DB,
+) (DomainEvent, error) {
+
+	var err error
+	wrapWithMsg := "retrieveSnapshot"
+
+	queryTemplate := `SELECT payload, stream_version FROM %name%
+						WHERE stream_id = $1 AND format_version = $2`
+
+	query := strings.Replace(queryTemplate, "%name%", s.snapshotTableName, 1)
+
+	var payload string
+	var streamVersion uint
+
+	err = db.QueryRowContext(ctx, query, streamID.String(), s.formatVersion).Scan(&payload, &streamVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	snapshot, err := s.unmarshalSnapshot(ctx, []byte(payload), streamVersion)
+	if err != nil {
+		return nil, shared.MarkAndWrapError(err, shared.ErrUnmarshalingFailed, wrapWithMsg)
+	}
+
+	return snapshot, nil
+}
+
+// SaveSnapshot only replaces an existing snapshot if it is older or was written with a different formatVersion.
+func (s *SnapshotStore) SaveSnapshot(
+	ctx context.Context,
+	streamID StreamID,
+	snapshot DomainEvent,
+	db *sql.DB,
+) error {
+
+	var err error
+	wrapWithMsg := "saveSnapshot"
+
+	queryTemplate := `INSERT INTO %name% (stream_id, stream_version, format_version, payload, created_at)
+						VALUES ($1, $2, $3, $4, now())
+						ON CONFLICT (stream_id) DO UPDATE
+							SET stream_version = EXCLUDED.stream_version,
+								format_version = EXCLUDED.format_version,
+								payload = EXCLUDED.payload,
+								created_at = EXCLUDED.created_at
+							WHERE %name%.stream_version < EXCLUDED.stream_version
+								OR %name%.format_version <> EXCLUDED.format_version`
+
+	query := strings.ReplaceAll(queryTemplate, "%name%", s.snapshotTableName)
+
+	snapshotJSON, err := s.marshalSnapshot(ctx, snapshot)
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrMarshalingFailed, wrapWithMsg)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		query,
+		streamID.String(),
+		snapshot.Meta().StreamVersion(),
+		s.formatVersion,
+		snapshotJSON,
+	)
+
+	if err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, wrapWithMsg)
+	}
+
+	return nil
+}
+
+func (s *SnapshotStore) PurgeSnapshot(
+	ctx context.Context,
+	streamID StreamID,
+	tx *sql.Tx,
+) error {
+
+	queryTemplate := `DELETE FROM %name% WHERE stream_id = $1`
+	query := strings.Replace(queryTemplate, "%name%", s.snapshotTableName, 1)
+
+	if _, err := tx.ExecContext(ctx, query, streamID.String()); err != nil {
+		return shared.MarkAndWrapError(err, shared.ErrTechnical, "purgeSnapshot")
+	}
+
+	return nil
+}
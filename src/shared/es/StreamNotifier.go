@@ -0,0 +1,63 @@
+package es
+
+import (
+	"context"
+	"sync"
+)
+
+// StreamNotifier wakes up the watchers of a stream after events were appended to it in the same process.
+// A notification only tells that there is something new, the watchers read the events from the EventStore themselves.
+// So notifications which arrive while a watcher is busy are merged into one, and Notify never blocks the writer.
+type StreamNotifier struct {
+	mutex    sync.Mutex
+	watchers map[StreamID]map[chan struct{}]struct{}
+}
+
+func NewStreamNotifier() *StreamNotifier {
+	return &StreamNotifier{
+		watchers: make(map[StreamID]map[chan struct{}]struct{}),
+	}
+}
+
+// Watch returns a channel which receives a notification after events were appended to the stream.
+// The watcher is removed once the ctx is done.
+func (n *StreamNotifier) Watch(ctx context.Context, streamID StreamID) <-chan struct{} {
+	notifications := make(chan struct{}, 1)
+
+	n.mutex.Lock()
+
+	if _, found := n.watchers[streamID]; !found {
+		n.watchers[streamID] = make(map[chan struct{}]struct{})
+	}
+
+	n.watchers[streamID][notifications] = struct{}{}
+
+	n.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+
+		delete(n.watchers[streamID], notifications)
+
+		if len(n.watchers[streamID]) == 0 {
+			delete(n.watchers, streamID)
+		}
+	}()
+
+	return notifications
+}
+
+func (n *StreamNotifier) Notify(streamID StreamID) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for notifications := range n.watchers[streamID] {
+		select {
+		case notifications <- struct{}{}:
+		default: // the watcher was notified already and did not read the stream yet
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package es_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStreamNotifier(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		notifier := es.NewStreamNotifier()
+		streamID := es.BuildStreamID("customer-123")
+		otherStreamID := es.BuildStreamID("customer-456")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		Convey("Given two watchers of a stream", func() {
+			notifications := notifier.Watch(ctx, streamID)
+			otherNotifications := notifier.Watch(ctx, streamID)
+
+			Convey("When events were appended to the stream twice", func() {
+				notifier.Notify(streamID)
+				notifier.Notify(streamID)
+
+				Convey("Then both watchers should receive one merged notification", func() {
+					So(notifications, ShouldHaveLength, 1)
+					So(otherNotifications, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When events were appended to another stream", func() {
+				notifier.Notify(otherStreamID)
+
+				Convey("Then the watchers should not be notified", func() {
+					So(notifications, ShouldBeEmpty)
+					So(otherNotifications, ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,192 @@
+package es
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/cockroachdb/errors"
+)
+
+type ForRetrievingGlobalEventStreams func(ctx context.Context, after GlobalPosition, maxEvents uint, db *sql.DB) (GlobalEventStream, error)
+type ForRetrievingCheckpoints func(ctx context.Context, subscriberID string, db *sql.DB) (GlobalPosition, error)
+type ForSavingCheckpoints func(ctx context.Context, subscriberID string, position GlobalPosition, db *sql.DB) error
+type ForResettingCheckpoints func(ctx context.Context, subscriberID string, db *sql.DB) error
+type ForCountingGlobalEvents func(ctx context.Context, after GlobalPosition, db *sql.DB) (uint, error)
+type ForHandlingGlobalEvents func(ctx context.Context, globalEvent GlobalEvent) error
+
+// Subscription is a catch-up subscription to the global event log with a checkpoint per subscriber.
+// It pulls at most batchSize events at a time and only pulls the next batch once the handler has processed
+// the previous one, so a slow handler can never be flooded with events (back-pressure).
+// Events are delivered at-least-once: a crash between handling an event and saving the checkpoint redelivers it.
+// Events which can never be handled - the handler marks the error as shared.ErrInputIsInvalid - are logged and skipped,
+// so that they don't hold up the subscription forever.
+type Subscription struct {
+	mutex                     sync.Mutex
+	subscriberID              string
+	db                        *sql.DB
+	retrieveGlobalEventStream ForRetrievingGlobalEventStreams
+	retrieveCheckpoint        ForRetrievingCheckpoints
+	saveCheckpoint            ForSavingCheckpoints
+	resetCheckpoint           ForResettingCheckpoints
+	countGlobalEvents         ForCountingGlobalEvents
+	handle                    ForHandlingGlobalEvents
+	batchSize                 uint
+	pollInterval              time.Duration
+	logger                    *shared.Logger
+}
+
+func NewSubscription(
+	subscriberID string,
+	db *sql.DB,
+	retrieveGlobalEventStream ForRetrievingGlobalEventStreams,
+	retrieveCheckpoint ForRetrievingCheckpoints,
+	saveCheckpoint ForSavingCheckpoints,
+	resetCheckpoint ForResettingCheckpoints,
+	countGlobalEvents ForCountingGlobalEvents,
+	handle ForHandlingGlobalEvents,
+	batchSize uint,
+	pollInterval time.Duration,
+	logger *shared.Logger,
+) *Subscription {
+
+	return &Subscription{
+		subscriberID:              subscriberID,
+		db:                        db,
+		retrieveGlobalEventStream: retrieveGlobalEventStream,
+		retrieveCheckpoint:        retrieveCheckpoint,
+		saveCheckpoint:            saveCheckpoint,
+		resetCheckpoint:           resetCheckpoint,
+		countGlobalEvents:         countGlobalEvents,
+		handle:                    handle,
+		batchSize:                 batchSize,
+		pollInterval:              pollInterval,
+		logger:                    logger,
+	}
+}
+
+func (s *Subscription) SubscriberID() string {
+	return s.subscriberID
+}
+
+// CatchUp handles all events after the subscriber's checkpoint, batch by batch, until there are no more events.
+// The checkpoint is saved after each batch, or up to the last successfully handled event if the handler fails.
+func (s *Subscription) CatchUp(ctx context.Context) error {
+	wrapWithMsg := "subscription.CatchUp"
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoint, err := s.retrieveCheckpoint(ctx, s.subscriberID, s.db)
+	if err != nil {
+		return errors.Wrap(err, wrapWithMsg)
+	}
+
+	var globalEventStream GlobalEventStream
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		globalEventStream, err = s.retrieveGlobalEventStream(ctx, checkpoint, s.batchSize, s.db)
+		if err != nil {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		if len(globalEventStream) == 0 {
+			return nil
+		}
+
+		handled := checkpoint
+
+		for _, globalEvent := range globalEventStream {
+			if err = s.handle(ctx, globalEvent); err != nil {
+				if !errors.Is(err, shared.ErrInputIsInvalid) {
+					break
+				}
+
+				s.logger.Warn().Msgf(
+					"subscription [%s]: skipped event [%s] of stream [%s] which can't be handled: %s",
+					s.subscriberID,
+					globalEvent.Event().Meta().EventName(),
+					globalEvent.StreamID(),
+					err,
+				)
+
+				err = nil
+			}
+
+			handled = globalEvent.Position()
+		}
+
+		if handled != checkpoint {
+			if saveErr := s.saveCheckpoint(ctx, s.subscriberID, handled, s.db); saveErr != nil {
+				return errors.Wrap(saveErr, wrapWithMsg)
+			}
+
+			checkpoint = handled
+		}
+
+		if err != nil {
+			return errors.Wrap(err, wrapWithMsg)
+		}
+
+		if uint(len(globalEventStream)) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// Lag is the number of events in the global log which the subscriber has not handled yet.
+func (s *Subscription) Lag(ctx context.Context) (uint, error) {
+	wrapWithMsg := "subscription.Lag"
+
+	checkpoint, err := s.retrieveCheckpoint(ctx, s.subscriberID, s.db)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	lag, err := s.countGlobalEvents(ctx, checkpoint, s.db)
+	if err != nil {
+		return 0, errors.Wrap(err, wrapWithMsg)
+	}
+
+	return lag, nil
+}
+
+// Reset moves the subscriber back to the start of the global log, so that the next CatchUp handles all events again.
+func (s *Subscription) Reset(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.resetCheckpoint(ctx, s.subscriberID, s.db); err != nil {
+		return errors.Wrap(err, "subscription.Reset")
+	}
+
+	return nil
+}
+
+// Run catches up every pollInterval until the ctx is done. Failures are logged and retried with the next poll.
+func (s *Subscription) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CatchUp(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error().Msgf("subscription [%s] failed: %s", s.subscriberID, err)
+		}
+
+		if lag, err := s.Lag(ctx); err == nil && lag > 0 {
+			s.logger.Info().Msgf("subscription [%s] lags %d events behind", s.subscriberID, lag)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
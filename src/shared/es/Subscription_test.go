@@ -0,0 +1,207 @@
+package es_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonStoeckl/go-iddd/src/shared"
+	"github.com/AntonStoeckl/go-iddd/src/shared/es"
+	"github.com/cockroachdb/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSubscription_CatchUp(t *testing.T) {
+	Convey("Prepare test artifacts", t, func() {
+		var globalEventLog es.GlobalEventStream
+		checkpoints := make(map[string]es.GlobalPosition)
+		var handledEvents es.GlobalEventStream
+		var retrievedBatchSizes []int
+		failOnEventID := uint64(0)
+		neverHandleEventID := uint64(0)
+
+		for eventID := uint64(1); eventID <= 5; eventID++ {
+			globalEventLog = append(
+				globalEventLog,
+				es.BuildGlobalEvent(es.BuildGlobalPosition(100, eventID), es.BuildStreamID("customer-123"), someEvent{}),
+			)
+		}
+
+		retrieveGlobalEventStream := func(ctx context.Context, after es.GlobalPosition, maxEvents uint, db *sql.DB) (es.GlobalEventStream, error) {
+			var batch es.GlobalEventStream
+
+			for _, globalEvent := range globalEventLog {
+				if globalEvent.Position().IsAfter(after) && uint(len(batch)) < maxEvents {
+					batch = append(batch, globalEvent)
+				}
+			}
+
+			retrievedBatchSizes = append(retrievedBatchSizes, len(batch))
+
+			return batch, nil
+		}
+
+		retrieveCheckpoint := func(ctx context.Context, subscriberID string, db *sql.DB) (es.GlobalPosition, error) {
+			return checkpoints[subscriberID], nil
+		}
+
+		saveCheckpoint := func(ctx context.Context, subscriberID string, position es.GlobalPosition, db *sql.DB) error {
+			checkpoints[subscriberID] = position
+			return nil
+		}
+
+		resetCheckpoint := func(ctx context.Context, subscriberID string, db *sql.DB) error {
+			delete(checkpoints, subscriberID)
+			return nil
+		}
+
+		countGlobalEvents := func(ctx context.Context, after es.GlobalPosition, db *sql.DB) (uint, error) {
+			var count uint
+
+			for _, globalEvent := range globalEventLog {
+				if globalEvent.Position().IsAfter(after) {
+					count++
+				}
+			}
+
+			return count, nil
+		}
+
+		handle := func(ctx context.Context, globalEvent es.GlobalEvent) error {
+			if globalEvent.Position().EventID() == failOnEventID {
+				return errors.Mark(errors.New("handler failed"), shared.ErrTechnical)
+			}
+
+			if globalEvent.Position().EventID() == neverHandleEventID {
+				return errors.Mark(errors.New("recipient rejected"), shared.ErrInputIsInvalid)
+			}
+
+			handledEvents = append(handledEvents, globalEvent)
+
+			return nil
+		}
+
+		subscription := es.NewSubscription(
+			"some-subscriber",
+			nil,
+			retrieveGlobalEventStream,
+			retrieveCheckpoint,
+			saveCheckpoint,
+			resetCheckpoint,
+			countGlobalEvents,
+			handle,
+			2,
+			time.Second,
+			shared.NewNilLogger(),
+		)
+
+		Convey("When a new subscriber catches up", func() {
+			err := subscription.CatchUp(context.Background())
+
+			Convey("Then it should handle all events in order, batch by batch", func() {
+				So(err, ShouldBeNil)
+				So(handledEvents, ShouldResemble, globalEventLog)
+				So(retrievedBatchSizes, ShouldResemble, []int{2, 2, 1})
+				So(checkpoints["some-subscriber"], ShouldResemble, es.BuildGlobalPosition(100, 5))
+			})
+
+			Convey("Then it should not lag behind", func() {
+				lag, err := subscription.Lag(context.Background())
+				So(err, ShouldBeNil)
+				So(lag, ShouldEqual, 0)
+			})
+
+			Convey("and when it is reset and catches up again", func() {
+				handledEvents = nil
+				err = subscription.Reset(context.Background())
+				So(err, ShouldBeNil)
+				err = subscription.CatchUp(context.Background())
+
+				Convey("Then it should handle all events again", func() {
+					So(err, ShouldBeNil)
+					So(handledEvents, ShouldResemble, globalEventLog)
+				})
+			})
+
+			Convey("and when it catches up again", func() {
+				handledEvents = nil
+				err = subscription.CatchUp(context.Background())
+
+				Convey("Then it should not handle any events again", func() {
+					So(err, ShouldBeNil)
+					So(handledEvents, ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When the handler fails for an event", func() {
+			failOnEventID = 4
+			err := subscription.CatchUp(context.Background())
+
+			Convey("Then it should fail", func() {
+				So(errors.Is(err, shared.ErrTechnical), ShouldBeTrue)
+			})
+
+			Convey("Then the checkpoint should point to the last successfully handled event", func() {
+				So(checkpoints["some-subscriber"], ShouldResemble, es.BuildGlobalPosition(100, 3))
+			})
+
+			Convey("Then it should lag behind by the unhandled events", func() {
+				lag, err := subscription.Lag(context.Background())
+				So(err, ShouldBeNil)
+				So(lag, ShouldEqual, 2)
+			})
+
+			Convey("and when it catches up again after the handler recovered", func() {
+				failOnEventID = 0
+				handledEvents = nil
+				err = subscription.CatchUp(context.Background())
+
+				Convey("Then it should continue with the failed event", func() {
+					So(err, ShouldBeNil)
+					So(handledEvents, ShouldResemble, globalEventLog[3:])
+				})
+			})
+		})
+
+		Convey("When the handler can never handle an event", func() {
+			neverHandleEventID = 2
+			err := subscription.CatchUp(context.Background())
+
+			Convey("Then it should skip that event and handle the others", func() {
+				So(err, ShouldBeNil)
+				So(handledEvents, ShouldHaveLength, 4)
+				So(handledEvents[1], ShouldResemble, globalEventLog[2])
+				So(checkpoints["some-subscriber"], ShouldResemble, es.BuildGlobalPosition(100, 5))
+			})
+		})
+
+		Convey("When the ctx is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := subscription.CatchUp(ctx)
+
+			Convey("Then it should stop without handling events", func() {
+				So(errors.Is(err, context.Canceled), ShouldBeTrue)
+				So(handledEvents, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+/***** a mock event for the global event log *****/
+
+type someEvent struct{}
+
+func (event someEvent) Meta() es.EventMeta {
+	return es.RebuildEventMeta("SomeEvent", "never", "someID", "someID", 1)
+}
+
+func (event someEvent) IsFailureEvent() bool {
+	return false
+}
+
+func (event someEvent) FailureReason() error {
+	return nil
+}
@@ -1,3 +1,5 @@
 package es
 
-type UnmarshalDomainEvent func(name string, payload []byte, streamVersion uint) (DomainEvent, error)
+import "context"
+
+type UnmarshalDomainEvent func(ctx context.Context, name string, payload []byte, streamVersion uint) (DomainEvent, error)
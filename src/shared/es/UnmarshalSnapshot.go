@@ -0,0 +1,5 @@
+package es
+
+import "context"
+
+type UnmarshalSnapshot func(ctx context.Context, payload []byte, streamVersion uint) (DomainEvent, error)